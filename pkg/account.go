@@ -3,6 +3,8 @@ package moov
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 	"time"
@@ -259,6 +261,30 @@ type TermsOfService struct {
 	TermsOfServiceManual TermsOfServiceManual `json:"termsOfServiceManual,omitempty"`
 }
 
+// ErrInvalidTermsOfService is returned when terms of service acceptance is neither a token nor a manual acceptance
+var ErrInvalidTermsOfService = errors.New("terms of service must be accepted with either a token or the accepted date and IP")
+
+// Validate checks that the acceptance was captured in exactly one way, either as a token from Moov's hosted flow or
+// manually with at least the date and IP it was accepted from
+func (t TermsOfService) Validate() error {
+	hasToken := t.TermsOfServiceToken != (TermsOfServiceToken{})
+	hasManual := t.TermsOfServiceManual != (TermsOfServiceManual{})
+
+	switch {
+	case hasToken && hasManual:
+		return fmt.Errorf("%w: not both", ErrInvalidTermsOfService)
+	case hasToken:
+		return nil
+	case hasManual:
+		if t.TermsOfServiceManual.AcceptedDate == "" || t.TermsOfServiceManual.AcceptedIP == "" {
+			return fmt.Errorf("%w: manual acceptance needs the accepted date and IP", ErrInvalidTermsOfService)
+		}
+		return nil
+	default:
+		return ErrInvalidTermsOfService
+	}
+}
+
 func (t TermsOfService) jsonValue() interface{} {
 	if t.TermsOfServiceToken != (TermsOfServiceToken{}) {
 		return t.TermsOfServiceToken
@@ -281,6 +307,18 @@ type TermsOfServiceManual struct {
 	AcceptedDomain    string `json:"acceptedDomain,omitempty"`
 }
 
+// GenerateTermsOfServiceToken generates a token for accepting Moov's terms of service, pass it in TermsOfService when
+// creating or updating an account
+// https://docs.moov.io/api/moov-accounts/accounts/tos-token/
+func (c Client) GenerateTermsOfServiceToken(ctx context.Context) (*TermsOfServiceToken, error) {
+	resp, err := c.CallHttp(ctx, Endpoint(http.MethodGet, pathTermsOfServiceToken), AcceptJson())
+	if err != nil {
+		return nil, err
+	}
+
+	return CompletedObjectOrError[TermsOfServiceToken](resp)
+}
+
 type CustomerSupport struct {
 	Phone   Phone   `json:"phone,omitempty"`
 	Email   string  `json:"email,omitempty"`
@@ -288,6 +326,24 @@ type CustomerSupport struct {
 	Website string  `json:"website,omitempty"`
 }
 
+func (c CustomerSupport) jsonValue() interface{} {
+	if c != (CustomerSupport{}) {
+		type Alias struct {
+			Phone   interface{} `json:"phone,omitempty"`
+			Email   string      `json:"email,omitempty"`
+			Address interface{} `json:"address,omitempty"`
+			Website string      `json:"website,omitempty"`
+		}
+		return Alias{
+			Phone:   c.Phone.jsonValue(),
+			Email:   c.Email,
+			Address: c.Address.jsonValue(),
+			Website: c.Website,
+		}
+	}
+	return nil
+}
+
 type CardPayment struct {
 	StatementDescriptor string `json:"statementDescriptor,omitempty"`
 }
@@ -302,13 +358,23 @@ type Settings struct {
 }
 
 func (s Settings) jsonValue() interface{} {
+	type Alias struct {
+		CardPayment *CardPayment `json:"cardPayment,omitempty"`
+		AchPayment  *AchPayment  `json:"achPayment,omitempty"`
+	}
+
+	alias := Alias{}
 	if s.CardPayment.StatementDescriptor != "" {
-		return s.CardPayment
+		alias.CardPayment = &s.CardPayment
 	}
 	if s.AchPayment.CompanyName != "" {
-		return s.AchPayment
+		alias.AchPayment = &s.AchPayment
 	}
-	return nil
+
+	if alias == (Alias{}) {
+		return nil
+	}
+	return alias
 }
 
 type Verification struct {
@@ -349,19 +415,32 @@ func (a Account) MarshalJSON() ([]byte, error) {
 		CustomerSupport interface{} `json:"customerSupport,omitempty"`
 		Profile         interface{} `json:"profile,omitempty"`
 		Settings        interface{} `json:"settings,omitempty"`
+		CreatedOn       *time.Time  `json:"createdOn,omitempty"`
+		UpdatedOn       *time.Time  `json:"updatedOn,omitempty"`
+		DisabledOn      *time.Time  `json:"disabledOn,omitempty"`
 	}
 
 	return json.Marshal(AliasWithInterface{
-		Alias:          Alias(a),
-		Verification:   a.Verification.jsonValue(),
-		Profile:        a.Profile.jsonValue(),
-		TermsOfService: a.TermsOfService.jsonValue(),
-		Settings:       a.Settings.jsonValue(),
+		Alias:           Alias(a),
+		Verification:    a.Verification.jsonValue(),
+		Profile:         a.Profile.jsonValue(),
+		TermsOfService:  a.TermsOfService.jsonValue(),
+		CustomerSupport: a.CustomerSupport.jsonValue(),
+		Settings:        a.Settings.jsonValue(),
+		CreatedOn:       timeOrNil(a.CreatedOn),
+		UpdatedOn:       timeOrNil(a.UpdatedOn),
+		DisabledOn:      timeOrNil(a.DisabledOn),
 	})
 }
 
 // CreateAccount creates a new account.
 func (c Client) CreateAccount(ctx context.Context, account Account) (*Account, *Account, error) {
+	if account.TermsOfService != (TermsOfService{}) {
+		if err := account.TermsOfService.Validate(); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	resp, err := c.CallHttp(ctx,
 		Endpoint(http.MethodPost, "/accounts"),
 		AcceptJson(),
@@ -408,13 +487,44 @@ func (c Client) UpdateAccount(ctx context.Context, account Account) (*Account, e
 	return CompletedObjectOrError[Account](resp)
 }
 
+// UpdateAccountMetadata replaces the metadata of an account
+func (c Client) UpdateAccountMetadata(ctx context.Context, accountID string, metadata map[string]string) (*Account, error) {
+	if err := validateMetadata(metadata); err != nil {
+		return nil, err
+	}
+
+	if metadata == nil {
+		metadata = map[string]string{}
+	}
+
+	resp, err := c.CallHttp(ctx,
+		Endpoint(http.MethodPatch, "/accounts/%s", accountID),
+		AcceptJson(),
+		JsonBody(map[string]map[string]string{"metadata": metadata}))
+	if err != nil {
+		return nil, err
+	}
+
+	return CompletedObjectOrError[Account](resp)
+}
+
+// PatchAccountMetadata adds and removes keys from the account's metadata while keeping the rest of it
+func (c Client) PatchAccountMetadata(ctx context.Context, accountID string, patch MetadataPatch) (*Account, error) {
+	account, err := c.GetAccount(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.UpdateAccountMetadata(ctx, accountID, patch.apply(account.Metadata))
+}
+
 // Func that applies a filter and returns an error if validation fails
 type ListAccountFilter callArg
 
 // WithAccountName if provided, this query will attempt to find matches against the following Account and Profile fields: diplayName, firstName, middleName, lastName, legalBusinessName
 func WithAccountName(name string) ListAccountFilter {
 	return callBuilderFn(func(call *callBuilder) error {
-		call.params["name"] = name
+		call.params.Set("name", name)
 		return nil
 	})
 }
@@ -422,7 +532,7 @@ func WithAccountName(name string) ListAccountFilter {
 // WithAccountEmail filter connected accounts by email address.
 func WithAccountEmail(email string) ListAccountFilter {
 	return callBuilderFn(func(call *callBuilder) error {
-		call.params["email"] = email
+		call.params.Set("email", email)
 		return nil
 	})
 }
@@ -430,7 +540,7 @@ func WithAccountEmail(email string) ListAccountFilter {
 // WithAccountType filter type possible values: individual, business
 func WithAccountType(accountType string) ListAccountFilter {
 	return callBuilderFn(func(call *callBuilder) error {
-		call.params["type"] = accountType
+		call.params.Set("type", accountType)
 		return nil
 	})
 }
@@ -438,7 +548,7 @@ func WithAccountType(accountType string) ListAccountFilter {
 // WithAccountForeignID filter as an optional alias from a foreign/external system which can be used to reference this resource.
 func WithAccountForeignID(foreignID string) ListAccountFilter {
 	return callBuilderFn(func(call *callBuilder) error {
-		call.params["foreignID"] = foreignID
+		call.params.Set("foreignID", foreignID)
 		return nil
 	})
 }
@@ -446,7 +556,7 @@ func WithAccountForeignID(foreignID string) ListAccountFilter {
 // WithAccountVerificationStatus possible values: unverified, pending, resubmit, review, verified, failed
 func WithAccountVerificationStatus(verificationStatus string) ListAccountFilter {
 	return callBuilderFn(func(call *callBuilder) error {
-		call.params["verification_status"] = verificationStatus
+		call.params.Set("verification_status", verificationStatus)
 		return nil
 	})
 }
@@ -454,7 +564,7 @@ func WithAccountVerificationStatus(verificationStatus string) ListAccountFilter
 // WithAccountIncludeDisconnected if true, the response will include disconnected accounts.
 func WithAccountIncludeDisconnected() ListAccountFilter {
 	return callBuilderFn(func(call *callBuilder) error {
-		call.params["includeDisconnected"] = "true"
+		call.params.Set("includeDisconnected", "true")
 		return nil
 	})
 }
@@ -462,7 +572,7 @@ func WithAccountIncludeDisconnected() ListAccountFilter {
 // WithAccountCount value to limit the number of results in the query. Default is 20
 func WithAccountCount(count int) ListAccountFilter {
 	return callBuilderFn(func(call *callBuilder) error {
-		call.params["count"] = strconv.Itoa(count)
+		call.params.Set("count", strconv.Itoa(count))
 		return nil
 	})
 }
@@ -470,7 +580,7 @@ func WithAccountCount(count int) ListAccountFilter {
 // WithAccountSkip the number of items to offset before starting to collect the result set
 func WithAccountSkip(skip int) ListAccountFilter {
 	return callBuilderFn(func(call *callBuilder) error {
-		call.params["skip"] = strconv.Itoa(skip)
+		call.params.Set("skip", strconv.Itoa(skip))
 		return nil
 	})
 }
@@ -479,7 +589,7 @@ func WithAccountSkip(skip int) ListAccountFilter {
 func (c Client) ListAccounts(ctx context.Context, opts ...ListAccountFilter) ([]Account, error) {
 	resp, err := c.CallHttp(ctx,
 		Endpoint(http.MethodGet, "/accounts"),
-		prependArgs(opts, AcceptJson())...)
+		prependArgs(opts, AcceptJson(), paged())...)
 	if err != nil {
 		return nil, err
 	}
@@ -487,6 +597,176 @@ func (c Client) ListAccounts(ctx context.Context, opts ...ListAccountFilter) ([]
 	return CompletedListOrError[Account](resp)
 }
 
+// AccountBlocker describes something preventing an account from moving money
+type AccountBlocker struct {
+	// Capability the blocker applies to, empty when it applies to the account as a whole
+	Capability string `json:"capability,omitempty"`
+	Reason     string `json:"reason,omitempty"`
+}
+
+// AccountIssue lists everything blocking a single account
+type AccountIssue struct {
+	AccountID   string           `json:"accountID,omitempty"`
+	DisplayName string           `json:"displayName,omitempty"`
+	Blockers    []AccountBlocker `json:"blockers,omitempty"`
+}
+
+// ListBlockedAccounts pages through all connected accounts and returns the ones with verification or capability
+// requirements that need action, along with the specific blockers for each account.
+func (c Client) ListBlockedAccounts(ctx context.Context) ([]AccountIssue, error) {
+	const pageSize = 100
+
+	accounts := []Account{}
+	for skip := 0; ; skip += pageSize {
+		page, err := c.ListAccounts(ctx, WithAccountCount(pageSize), WithAccountSkip(skip))
+		if err != nil {
+			return nil, err
+		}
+
+		accounts = append(accounts, page...)
+		if len(page) < pageSize {
+			break
+		}
+	}
+
+	blockers := make([][]AccountBlocker, len(accounts))
+	errs := make([]error, len(accounts))
+
+	err := forEachConcurrent(ctx, len(accounts), defaultConcurrency, func(ctx context.Context, i int) {
+		caps, err := c.ListCapabilities(ctx, accounts[i].AccountID)
+		if err != nil {
+			errs[i] = err
+			return
+		}
+
+		blockers[i] = accountBlockers(accounts[i], caps)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	issues := []AccountIssue{}
+	for i, account := range accounts {
+		if len(blockers[i]) == 0 {
+			continue
+		}
+
+		issues = append(issues, AccountIssue{
+			AccountID:   account.AccountID,
+			DisplayName: account.DisaplayName,
+			Blockers:    blockers[i],
+		})
+	}
+
+	return issues, nil
+}
+
+func accountBlockers(account Account, caps []Capability) []AccountBlocker {
+	blockers := []AccountBlocker{}
+
+	status := account.Verification.VerificationStatus
+	if status == "" {
+		status = account.Verification.Status
+	}
+
+	// accounts start out unverified, anything short of verified still needs action
+	switch status {
+	case "verified":
+	case "":
+		blockers = append(blockers, AccountBlocker{Reason: "verification status is unknown"})
+	default:
+		blockers = append(blockers, AccountBlocker{Reason: "verification status is " + status})
+	}
+
+	for _, capability := range caps {
+		for _, due := range capability.Requirements.CurrentlyDue {
+			blockers = append(blockers, AccountBlocker{Capability: string(capability.Capability), Reason: "currently due: " + due})
+		}
+
+		for _, e := range capability.Requirements.Errors {
+			blockers = append(blockers, AccountBlocker{Capability: string(capability.Capability), Reason: e.Requirement + ": " + e.ErrorCode})
+		}
+
+		if capability.Status == CAPABILITY_DISABLED {
+			reason := "capability is disabled"
+			if capability.DisabledReason != "" {
+				reason += ": " + capability.DisabledReason
+			}
+			blockers = append(blockers, AccountBlocker{Capability: string(capability.Capability), Reason: reason})
+		}
+	}
+
+	return blockers
+}
+
+// Readiness is whether an account can accept payments, and what is blocking it when it can't
+type Readiness struct {
+	Ready    bool             `json:"ready"`
+	Blockers []AccountBlocker `json:"blockers,omitempty"`
+}
+
+// readinessCapabilities are the capabilities an account needs before it can accept payments
+var readinessCapabilities = []CapabilityName{CAPABILITY_TRANSFERS, CAPABILITY_COLLECT_FUNDS}
+
+// TransferReadiness checks if an account can accept payments, requiring the account to be verified, the transfers and
+// collect-funds capabilities to be enabled and at least one verified bank account to fund transfers.
+func (c Client) TransferReadiness(ctx context.Context, accountID string) (Readiness, error) {
+	account, err := c.GetAccount(ctx, accountID)
+	if err != nil {
+		return Readiness{}, err
+	}
+
+	caps, err := c.ListCapabilities(ctx, accountID)
+	if err != nil {
+		return Readiness{}, err
+	}
+
+	bankAccounts, err := c.ListBankAccounts(ctx, accountID)
+	if err != nil {
+		return Readiness{}, err
+	}
+
+	byName := make(map[CapabilityName]Capability, len(caps))
+	for _, capability := range caps {
+		byName[capability.Capability] = capability
+	}
+
+	relevant := []Capability{}
+	missing := []AccountBlocker{}
+	for _, name := range readinessCapabilities {
+		capability, ok := byName[name]
+		if !ok {
+			missing = append(missing, AccountBlocker{Capability: string(name), Reason: "capability has not been requested"})
+			continue
+		}
+		relevant = append(relevant, capability)
+	}
+
+	blockers := accountBlockers(*account, relevant)
+	blockers = append(blockers, missing...)
+
+	for _, capability := range relevant {
+		requirements := capability.Requirements
+		if capability.Status == CAPABILITY_PENDING && len(requirements.CurrentlyDue) == 0 && len(requirements.Errors) == 0 {
+			blockers = append(blockers, AccountBlocker{Capability: string(capability.Capability), Reason: "capability is pending review"})
+		}
+	}
+
+	verified := false
+	for _, bankAccount := range bankAccounts {
+		verified = verified || bankAccount.Status == "verified"
+	}
+	if !verified {
+		blockers = append(blockers, AccountBlocker{Reason: "no verified bank account"})
+	}
+
+	return Readiness{Ready: len(blockers) == 0, Blockers: blockers}, nil
+}
+
 // DeleteAccount deletes an account.
 // TODO: Delete is not currently supported by the api
 // https://docs.moov.io/guides/dashboard/accounts/#disconnect-accounts
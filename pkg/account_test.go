@@ -4,6 +4,11 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
 	"testing"
 
 	moov "github.com/moovfinancial/moov-go/pkg"
@@ -26,6 +31,373 @@ func TestAccountMarshalResponse(t *testing.T) {
 	t.Logf("%#v", account)
 }
 
+func TestListBlockedAccounts(t *testing.T) {
+	// enough accounts to require paging through more than one page
+	accounts := []string{}
+	for i := 0; i < 102; i++ {
+		verification := "verified"
+		switch i {
+		case 0:
+			verification = "pending"
+		case 1:
+			verification = "unverified"
+		}
+		accounts = append(accounts, fmt.Sprintf(`{"accountID": "account-%d", "displayName": "Account %d", "verification": {"verificationStatus": %q}}`, i, i, verification))
+	}
+
+	capabilities := map[string]string{
+		"account-50":  `[{"capability": "transfers", "accountID": "account-50", "status": "pending", "requirements": {"currentlyDue": ["individual.ssn"]}}]`,
+		"account-101": `[{"capability": "wallet", "accountID": "account-101", "status": "disabled", "disabledReason": "account closed"}]`,
+	}
+
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/accounts" {
+			skip, _ := strconv.Atoi(r.URL.Query().Get("skip"))
+			count, _ := strconv.Atoi(r.URL.Query().Get("count"))
+			end := min(skip+count, len(accounts))
+			WriteJson(w, http.StatusOK, "["+strings.Join(accounts[skip:end], ",")+"]")
+			return
+		}
+
+		accountID := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/accounts/"), "/capabilities")
+		caps, ok := capabilities[accountID]
+		if !ok {
+			caps = fmt.Sprintf(`[{"capability": "transfers", "accountID": %q, "status": "enabled"}]`, accountID)
+		}
+		WriteJson(w, http.StatusOK, caps)
+	})
+
+	issues, err := mc.ListBlockedAccounts(BgCtx())
+	require.NoError(t, err)
+
+	require.Equal(t, []moov.AccountIssue{
+		{
+			AccountID:   "account-0",
+			DisplayName: "Account 0",
+			Blockers:    []moov.AccountBlocker{{Reason: "verification status is pending"}},
+		},
+		{
+			AccountID:   "account-1",
+			DisplayName: "Account 1",
+			Blockers:    []moov.AccountBlocker{{Reason: "verification status is unverified"}},
+		},
+		{
+			AccountID:   "account-50",
+			DisplayName: "Account 50",
+			Blockers:    []moov.AccountBlocker{{Capability: "transfers", Reason: "currently due: individual.ssn"}},
+		},
+		{
+			AccountID:   "account-101",
+			DisplayName: "Account 101",
+			Blockers:    []moov.AccountBlocker{{Capability: "wallet", Reason: "capability is disabled: account closed"}},
+		},
+	}, issues)
+}
+
+func TestTransferReadiness(t *testing.T) {
+	const ready = `{"accountID": "ready", "verification": {"verificationStatus": "verified"}}`
+	const enabled = `[{"capability": "transfers", "status": "enabled"}, {"capability": "collect-funds", "status": "enabled"}]`
+	const verifiedBank = `[{"bankAccountID": "bank-1", "status": "new"}, {"bankAccountID": "bank-2", "status": "verified"}]`
+
+	cases := []struct {
+		name         string
+		account      string
+		capabilities string
+		bankAccounts string
+		blockers     []moov.AccountBlocker
+	}{
+		{"ready", ready, enabled, verifiedBank, []moov.AccountBlocker{}},
+		{
+			"unverified account",
+			`{"accountID": "ready", "verification": {"verificationStatus": "unverified"}}`,
+			enabled,
+			verifiedBank,
+			[]moov.AccountBlocker{{Reason: "verification status is unverified"}},
+		},
+		{
+			"resubmit account",
+			`{"accountID": "ready", "verification": {"verificationStatus": "resubmit"}}`,
+			enabled,
+			verifiedBank,
+			[]moov.AccountBlocker{{Reason: "verification status is resubmit"}},
+		},
+		{
+			"capability missing and pending",
+			ready,
+			`[{"capability": "transfers", "status": "pending"}, {"capability": "wallet", "status": "disabled"}]`,
+			verifiedBank,
+			[]moov.AccountBlocker{
+				{Capability: "collect-funds", Reason: "capability has not been requested"},
+				{Capability: "transfers", Reason: "capability is pending review"},
+			},
+		},
+		{
+			"capability requirements due",
+			ready,
+			`[{"capability": "transfers", "status": "pending", "requirements": {"currentlyDue": ["individual.ssn"]}}, {"capability": "collect-funds", "status": "enabled"}]`,
+			verifiedBank,
+			[]moov.AccountBlocker{{Capability: "transfers", Reason: "currently due: individual.ssn"}},
+		},
+		{
+			"no verified bank account",
+			ready,
+			enabled,
+			`[{"bankAccountID": "bank-1", "status": "pending"}]`,
+			[]moov.AccountBlocker{{Reason: "no verified bank account"}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/accounts/account-id":
+					WriteJson(w, http.StatusOK, tc.account)
+				case "/accounts/account-id/capabilities":
+					WriteJson(w, http.StatusOK, tc.capabilities)
+				case "/accounts/account-id/bank-accounts":
+					WriteJson(w, http.StatusOK, tc.bankAccounts)
+				default:
+					WriteJson(w, http.StatusNotFound, `{}`)
+				}
+			})
+
+			readiness, err := mc.TransferReadiness(BgCtx(), "account-id")
+			require.NoError(t, err)
+			require.Equal(t, len(tc.blockers) == 0, readiness.Ready)
+			require.Equal(t, tc.blockers, readiness.Blockers)
+		})
+	}
+}
+
+func TestCreateAccount_Payloads(t *testing.T) {
+	cases := []struct {
+		name     string
+		account  moov.Account
+		expected string
+	}{
+		{
+			name: "individual",
+			account: moov.Account{
+				AccountType: moov.INDIVIDUAL,
+				Profile: moov.Profile{
+					Individual: moov.Individual{
+						Name:      moov.Name{FirstName: "Wade", LastName: "Arnold"},
+						Email:     "wade@example.com",
+						Phone:     moov.Phone{Number: "5555555555", CountryCode: "1"},
+						BirthDate: moov.BirthDate{Day: 9, Month: 11, Year: 1989},
+						GovernmentID: moov.GovernmentID{
+							Ssn: moov.Ssn{LastFour: "1234"},
+						},
+					},
+				},
+				TermsOfService: moov.TermsOfService{
+					TermsOfServiceToken: moov.TermsOfServiceToken{Token: "tos-token"},
+				},
+				Metadata: map[string]string{"customerID": "42"},
+			},
+			expected: `{
+				"accountType": "individual",
+				"profile": {
+					"individual": {
+						"name": {"firstName": "Wade", "lastName": "Arnold"},
+						"email": "wade@example.com",
+						"phone": {"number": "5555555555", "countryCode": "1"},
+						"birthDate": {"day": 9, "month": 11, "year": 1989},
+						"governmentID": {"lastFour": "1234"}
+					}
+				},
+				"termsOfService": {"token": "tos-token"},
+				"metadata": {"customerID": "42"}
+			}`,
+		},
+		{
+			name: "manual terms of service",
+			account: moov.Account{
+				AccountType: moov.INDIVIDUAL,
+				TermsOfService: moov.TermsOfService{
+					TermsOfServiceManual: moov.TermsOfServiceManual{
+						AcceptedDate:      "2024-05-01T14:15:22Z",
+						AcceptedIP:        "192.0.2.1",
+						AcceptedUserAgent: "Mozilla/5.0",
+						AcceptedDomain:    "https://wbfitness.com",
+					},
+				},
+			},
+			expected: `{
+				"accountType": "individual",
+				"termsOfService": {
+					"acceptedDate": "2024-05-01T14:15:22Z",
+					"acceptedIP": "192.0.2.1",
+					"acceptedUserAgent": "Mozilla/5.0",
+					"acceptedDomain": "https://wbfitness.com"
+				}
+			}`,
+		},
+		{
+			name: "business",
+			account: moov.Account{
+				AccountType: moov.BUSINESS,
+				Profile: moov.Profile{
+					Business: moov.Business{
+						LegalBusinessName: "Whole Body Fitness LLC",
+						BusinessType:      moov.BUSINESS_TYPE_LLC,
+						Website:           "wbfitness.com",
+						TaxID:             moov.TaxID{Ein: moov.Ein{Number: "123-45-6789"}},
+						Address: moov.Address{
+							AddressLine1:    "123 Main Street",
+							City:            "Boulder",
+							StateOrProvince: "CO",
+							PostalCode:      "80301",
+							Country:         "US",
+						},
+					},
+				},
+				CustomerSupport: moov.CustomerSupport{Email: "support@wbfitness.com"},
+				Settings: moov.Settings{
+					CardPayment: moov.CardPayment{StatementDescriptor: "Whole Body Fitness"},
+				},
+				Capabilities: []string{moov.CAPABILITIES_TRANSFERS, moov.CAPABILITIES_COLLECT_FUNDS},
+			},
+			expected: `{
+				"accountType": "business",
+				"profile": {
+					"business": {
+						"legalBusinessName": "Whole Body Fitness LLC",
+						"businessType": "llc",
+						"website": "wbfitness.com",
+						"taxID": {"ein": {"number": "123-45-6789"}},
+						"address": {
+							"addressLine1": "123 Main Street",
+							"city": "Boulder",
+							"stateOrProvince": "CO",
+							"postalCode": "80301",
+							"country": "US"
+						}
+					}
+				},
+				"customerSupport": {"email": "support@wbfitness.com"},
+				"settings": {"cardPayment": {"statementDescriptor": "Whole Body Fitness"}},
+				"capabilities": ["transfers", "collect-funds"]
+			}`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, http.MethodPost, r.Method)
+				require.Equal(t, "/accounts", r.URL.Path)
+
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				require.JSONEq(t, tc.expected, string(body))
+
+				WriteJson(w, http.StatusOK, `{"accountID": "638481a5-5205-406c-84c7-2fc2239105d1", "accountType": "`+tc.account.AccountType+`"}`)
+			})
+
+			completed, started, err := mc.CreateAccount(BgCtx(), tc.account)
+			require.NoError(t, err)
+			require.Nil(t, started)
+			require.Equal(t, "638481a5-5205-406c-84c7-2fc2239105d1", completed.AccountID)
+			require.Equal(t, tc.account.AccountType, completed.AccountType)
+		})
+	}
+}
+
+func TestCreateAccount_InvalidTermsOfService(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Fail(t, "no request should be made for invalid terms of service")
+	})
+
+	cases := map[string]moov.TermsOfService{
+		"both": {
+			TermsOfServiceToken:  moov.TermsOfServiceToken{Token: "tos-token"},
+			TermsOfServiceManual: moov.TermsOfServiceManual{AcceptedDate: "2024-05-01T14:15:22Z", AcceptedIP: "192.0.2.1"},
+		},
+		"manual without ip": {
+			TermsOfServiceManual: moov.TermsOfServiceManual{AcceptedDate: "2024-05-01T14:15:22Z"},
+		},
+	}
+
+	for name, tos := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, _, err := mc.CreateAccount(BgCtx(), moov.Account{AccountType: moov.INDIVIDUAL, TermsOfService: tos})
+			require.ErrorIs(t, err, moov.ErrInvalidTermsOfService)
+		})
+	}
+
+	require.ErrorIs(t, moov.TermsOfService{}.Validate(), moov.ErrInvalidTermsOfService)
+}
+
+func TestGenerateTermsOfServiceToken(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		require.Equal(t, "/tos-token", r.URL.Path)
+
+		WriteJson(w, http.StatusOK, `{"token": "tos-token"}`)
+	})
+
+	token, err := mc.GenerateTermsOfServiceToken(BgCtx())
+	require.NoError(t, err)
+	require.Equal(t, "tos-token", token.Token)
+}
+
+func TestAccountMetadata(t *testing.T) {
+	metadata := map[string]string{"customerID": "42", "plan": "basic"}
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/accounts/account-id", r.URL.Path)
+
+		if r.Method == http.MethodPatch {
+			body := map[string]map[string]string{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			require.Len(t, body, 1, "only metadata should be sent")
+			metadata = body["metadata"]
+		}
+
+		account, err := json.Marshal(map[string]any{"accountID": "account-id", "metadata": metadata})
+		require.NoError(t, err)
+		WriteJson(w, http.StatusOK, string(account))
+	})
+
+	account, err := mc.GetAccount(BgCtx(), "account-id")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"customerID": "42", "plan": "basic"}, account.Metadata)
+
+	t.Run("set", func(t *testing.T) {
+		account, err := mc.UpdateAccountMetadata(BgCtx(), "account-id", map[string]string{"customerID": "43"})
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"customerID": "43"}, account.Metadata)
+	})
+
+	t.Run("patch", func(t *testing.T) {
+		metadata = map[string]string{"customerID": "42", "plan": "basic"}
+
+		account, err := mc.PatchAccountMetadata(BgCtx(), "account-id", moov.MetadataPatch{
+			Set:    map[string]string{"plan": "premium", "region": "us"},
+			Remove: []string{"customerID"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"plan": "premium", "region": "us"}, account.Metadata)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		tooMany := map[string]string{}
+		for i := 0; i < 21; i++ {
+			tooMany[strconv.Itoa(i)] = "value"
+		}
+
+		_, err := mc.UpdateAccountMetadata(BgCtx(), "account-id", tooMany)
+		require.ErrorIs(t, err, moov.ErrInvalidMetadata)
+
+		_, err = mc.UpdateAccountMetadata(BgCtx(), "account-id", map[string]string{"key": strings.Repeat("a", 501)})
+		require.ErrorIs(t, err, moov.ErrInvalidMetadata)
+
+		_, err = mc.UpdateTransferMetaData(BgCtx(), "transfer-id", "", map[string]string{strings.Repeat("k", 41): "value"})
+		require.ErrorIs(t, err, moov.ErrInvalidMetadata)
+	})
+}
+
 func TestCreateAccountIndividual(t *testing.T) {
 	account := moov.Account{
 		AccountType: moov.INDIVIDUAL,
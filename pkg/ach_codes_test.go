@@ -0,0 +1,29 @@
+package moov_test
+
+import (
+	"testing"
+
+	moov "github.com/moovfinancial/moov-go/pkg"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReturn_DecodedReason(t *testing.T) {
+	require.Equal(t, "Insufficient funds", moov.Return{Code: "R01"}.DecodedReason())
+	require.Equal(t, "Account closed", moov.Return{Code: "R02"}.DecodedReason())
+	require.Equal(t, "Authorization revoked by customer", moov.Return{Code: "R07"}.DecodedReason())
+
+	// the reason Moov gave wins
+	require.Equal(t, "insufficient funds in account", moov.Return{Code: "R01", Reason: "insufficient funds in account"}.DecodedReason())
+
+	require.Empty(t, moov.Return{Code: "R99"}.DecodedReason())
+	require.Empty(t, moov.Return{}.DecodedReason())
+}
+
+func TestCorrection_DecodedReason(t *testing.T) {
+	require.Equal(t, "Incorrect bank account number", moov.Correction{Code: "C01"}.DecodedReason())
+	require.Equal(t, "Incorrect transit/routing number", moov.Correction{Code: "C02"}.DecodedReason())
+	require.Equal(t, "incorrect account", moov.Correction{Code: "C01", Reason: "incorrect account"}.DecodedReason())
+
+	// return codes aren't correction codes
+	require.Empty(t, moov.Correction{Code: "R01"}.DecodedReason())
+}
@@ -0,0 +1,155 @@
+package moov
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+)
+
+var (
+	ErrInvalidDecimalAmount = errors.New("amount is not a valid decimal number")
+	ErrAmountOverflow       = errors.New("amount is too large to be represented in minor units")
+	ErrInvalidCurrency      = errors.New("currency is not an ISO 4217 currency code")
+)
+
+// currencyExponents holds the number of minor-unit digits for currencies that don't use the common two.
+var currencyExponents = map[string]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0, "PYG": 0,
+	"RWF": 0, "UGX": 0, "UYI": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+	"CLF": 4, "UYW": 4,
+}
+
+// iso4217Codes are the active ISO 4217 currency codes, leaving out the codes for precious metals, bond market units,
+// special drawing rights, testing and no currency
+var iso4217Codes = func() map[string]bool {
+	codes := map[string]bool{}
+	for _, code := range strings.Fields(`
+AED AFN ALL AMD ANG AOA ARS AUD AWG AZN BAM BBD
+BDT BGN BHD BIF BMD BND BOB BOV BRL BSD BTN BWP
+BYN BZD CAD CDF CHE CHF CHW CLF CLP CNY COP COU
+CRC CUC CUP CVE CZK DJF DKK DOP DZD EGP ERN ETB
+EUR FJD FKP GBP GEL GHS GIP GMD GNF GTQ GYD HKD
+HNL HTG HUF IDR ILS INR IQD IRR ISK JMD JOD JPY
+KES KGS KHR KMF KPW KRW KWD KYD KZT LAK LBP LKR
+LRD LSL LYD MAD MDL MGA MKD MMK MNT MOP MRU MUR
+MVR MWK MXN MXV MYR MZN NAD NGN NIO NOK NPR NZD
+OMR PAB PEN PGK PHP PKR PLN PYG QAR RON RSD RUB
+RWF SAR SBD SCR SDG SEK SGD SHP SLE SLL SOS SRD
+SSP STN SVC SYP SZL THB TJS TMT TND TOP TRY TTD
+TWD TZS UAH UGX USD USN UYI UYU UYW UZS VED VES
+VND VUV WST XAF XCD XOF XPF YER ZAR ZMW ZWL
+`) {
+		codes[code] = true
+	}
+	return codes
+}()
+
+// ValidateCurrency checks the code is an ISO 4217 currency code, ignoring case, ie: "USD" or "usd"
+func ValidateCurrency(code string) error {
+	if !iso4217Codes[strings.ToUpper(code)] {
+		return fmt.Errorf("%w: %q", ErrInvalidCurrency, code)
+	}
+	return nil
+}
+
+// CurrencyExponent returns the number of digits after the decimal point used by the currency's minor unit.
+func CurrencyExponent(currency string) int {
+	if exp, ok := currencyExponents[strings.ToUpper(currency)]; ok {
+		return exp
+	}
+	return 2
+}
+
+// Decimal formats the amount's minor units as a decimal string using the currency's exponent, ie: 1204 USD is "12.04"
+func (a Amount) Decimal() string {
+	return formatDecimal(int64(a.Value), CurrencyExponent(a.Currency))
+}
+
+// AmountFromDecimal parses a human readable decimal string like "12.04" into the minor units of the currency.
+// Digits beyond the currency's exponent are rounded half away from zero.
+func AmountFromDecimal(currency string, decimal string) (Amount, error) {
+	value, err := parseDecimal(decimal, CurrencyExponent(currency))
+	if err != nil {
+		return Amount{}, err
+	}
+
+	if value > math.MaxInt || value < math.MinInt {
+		return Amount{}, ErrAmountOverflow
+	}
+
+	return Amount{
+		Currency: currency,
+		Value:    int(value),
+	}, nil
+}
+
+// parseDecimal converts a decimal string into an integer scaled by 10^scale, rounding half away from zero.
+func parseDecimal(decimal string, scale int) (int64, error) {
+	s := strings.TrimSpace(decimal)
+
+	negative := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+
+	whole, frac, _ := strings.Cut(s, ".")
+	if (whole == "" && frac == "") || !isDigits(whole) || !isDigits(frac) {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidDecimalAmount, decimal)
+	}
+
+	roundUp := false
+	if len(frac) > scale {
+		roundUp = frac[scale] >= '5'
+		frac = frac[:scale]
+	}
+	frac += strings.Repeat("0", scale-len(frac))
+
+	value, ok := new(big.Int).SetString("0"+whole+frac, 10)
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidDecimalAmount, decimal)
+	}
+
+	if roundUp {
+		value.Add(value, big.NewInt(1))
+	}
+
+	if negative {
+		value.Neg(value)
+	}
+
+	if !value.IsInt64() {
+		return 0, ErrAmountOverflow
+	}
+
+	return value.Int64(), nil
+}
+
+// formatDecimal formats an integer scaled by 10^scale as a decimal string.
+func formatDecimal(value int64, scale int) string {
+	digits := new(big.Int).Abs(big.NewInt(value)).String()
+
+	sign := ""
+	if value < 0 {
+		sign = "-"
+	}
+
+	if scale <= 0 {
+		return sign + digits
+	}
+
+	if len(digits) <= scale {
+		digits = strings.Repeat("0", scale-len(digits)+1) + digits
+	}
+
+	return sign + digits[:len(digits)-scale] + "." + digits[len(digits)-scale:]
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
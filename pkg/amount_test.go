@@ -0,0 +1,82 @@
+package moov_test
+
+import (
+	"testing"
+
+	moov "github.com/moovfinancial/moov-go/pkg"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAmountDecimal(t *testing.T) {
+	cases := []struct {
+		amount   moov.Amount
+		expected string
+	}{
+		{moov.Amount{Currency: "USD", Value: 1204}, "12.04"},
+		{moov.Amount{Currency: "USD", Value: 5}, "0.05"},
+		{moov.Amount{Currency: "usd", Value: -1204}, "-12.04"},
+		{moov.Amount{Currency: "JPY", Value: 1204}, "1204"},
+		{moov.Amount{Currency: "BHD", Value: 1204}, "1.204"},
+		{moov.Amount{Currency: "BHD", Value: 4}, "0.004"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.expected, func(t *testing.T) {
+			require.Equal(t, tc.expected, tc.amount.Decimal())
+		})
+	}
+}
+
+func TestAmountFromDecimal(t *testing.T) {
+	cases := []struct {
+		currency string
+		decimal  string
+		expected int
+	}{
+		{"USD", "12.04", 1204},
+		{"USD", "12", 1200},
+		{"USD", ".5", 50},
+		{"USD", "0.1", 10},
+		{"USD", "12.045", 1205},
+		{"USD", "12.044", 1204},
+		{"USD", "-12.045", -1205},
+		{"USD", " 19.99 ", 1999},
+		{"JPY", "1204", 1204},
+		{"JPY", "1204.5", 1205},
+		{"BHD", "1.204", 1204},
+		{"BHD", "1.2", 1200},
+		{"BHD", "1.2045", 1205},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.currency+" "+tc.decimal, func(t *testing.T) {
+			amount, err := moov.AmountFromDecimal(tc.currency, tc.decimal)
+			require.NoError(t, err)
+			require.Equal(t, tc.currency, amount.Currency)
+			require.Equal(t, tc.expected, amount.Value)
+		})
+	}
+}
+
+func TestAmountFromDecimal_Invalid(t *testing.T) {
+	for _, input := range []string{"", ".", "-", "abc", "1.2.3", "12,04", "1e5", "$12.04", "--1"} {
+		t.Run(input, func(t *testing.T) {
+			_, err := moov.AmountFromDecimal("USD", input)
+			require.ErrorIs(t, err, moov.ErrInvalidDecimalAmount)
+		})
+	}
+
+	_, err := moov.AmountFromDecimal("USD", "92233720368547758.08")
+	require.ErrorIs(t, err, moov.ErrAmountOverflow)
+}
+
+func TestValidateCurrency(t *testing.T) {
+	for _, code := range []string{"USD", "EUR", "JPY", "KWD", "usd", "Gbp"} {
+		require.NoError(t, moov.ValidateCurrency(code), code)
+	}
+
+	// funds, precious metals and testing codes aren't currencies transfers can be made in
+	for _, code := range []string{"", "US", "USDD", "ABC", "$", "XXX", "XTS", "XAU", "XAG", "XPD", "XPT", "XBA", "XDR", "XSU", "XUA"} {
+		require.ErrorIs(t, moov.ValidateCurrency(code), moov.ErrInvalidCurrency, code)
+	}
+}
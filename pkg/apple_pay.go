@@ -2,7 +2,6 @@ package moov
 
 import (
 	"context"
-	"errors"
 	"net/http"
 	"time"
 )
@@ -24,6 +23,9 @@ type ApplePaymentDataHeader struct {
 	EphemeralPublicKey string `json:"ephemeralPublicKey"`
 	PublicKeyHash      string `json:"publicKeyHash"`
 	TransactionId      string `json:"transactionId"`
+
+	// Key the payment data is encrypted with, encrypted to the merchant's RSA key. Only set for RSA_v1 tokens.
+	WrappedKey string `json:"wrappedKey,omitempty"`
 }
 
 type ApplePaymentData struct {
@@ -53,11 +55,17 @@ type ApplePayBillingContact struct {
 	CountryCode        string   `json:"countryCode,omitempty"`
 }
 
+// StartApplePaySession starts an Apple Pay session from the merchant validation event on a registered domain
 type StartApplePaySession struct {
 	Domain      string `json:"domain"`
 	DisplayName string `json:"displayName"`
+	// The validationURL of the onvalidatemerchant event raised by Apple Pay JS
+	ValidationURL string `json:"validationURL,omitempty"`
 }
 
+// ApplePaySessionRequest is the request of CreateApplePaySession, the same as StartApplePaySession
+type ApplePaySessionRequest = StartApplePaySession
+
 type LinkApplePay struct {
 	Token          ApplePayToken          `json:"token"`
 	BillingContact ApplePayBillingContact `json:"billingContact,omitempty"`
@@ -90,16 +98,23 @@ func (c Client) CreateApplePayDomain(ctx context.Context, accountID string, doma
 		return nil, err
 	}
 
-	errors.Join()
-
 	switch resp.Status() {
 	case StatusCompleted:
 		return CompletedObjectOrError[ApplePayDomainsResponse](resp)
+	case StatusStateConflict:
+		return nil, ErrDuplicatedApplePayDomain
 	default:
 		return nil, resp.Error()
 	}
 }
 
+// RegisterApplePayDomain registers the domains Apple Pay will be offered on for the given customer account
+// https://docs.moov.io/api/#tag/Cards/operation/registerApplePayMerchantDomains
+func (c Client) RegisterApplePayDomain(ctx context.Context, accountID string, domains []string) error {
+	_, err := c.CreateApplePayDomain(ctx, accountID, ApplePayDomains{Domains: domains})
+	return err
+}
+
 type PatchApplyPayDomains struct {
 	AddDomains    []string `json:"addDomains,omitempty"`
 	RemoveDomains []string `json:"removeDomains,omitempty"`
@@ -129,12 +144,36 @@ func (c Client) GetApplePayDomain(ctx context.Context, accountID string) (*Apple
 		return nil, err
 	}
 
-	return CompletedObjectOrError[ApplePayDomainsResponse](resp)
+	switch resp.Status() {
+	case StatusCompleted:
+		return UnmarshalObjectResponse[ApplePayDomainsResponse](resp)
+	case StatusNotFound:
+		return nil, ErrDomainsNotRegistered
+	default:
+		return nil, resp.Error()
+	}
 }
 
-// CreateApplePaySession creates a new Apple Pay session for the given customer account
-// https://docs.moov.io/api/#tag/Cards/operation/getApplePayMerchantDomains
+// StartApplePaySession creates a new Apple Pay session for the given customer account
+//
+// Deprecated: use CreateApplePaySession, which returns the typed session and errors for unregistered domains
 func (c Client) StartApplePaySession(ctx context.Context, accountID string, req StartApplePaySession) (*string, error) {
+	session, err := c.CreateApplePaySession(ctx, accountID, req)
+	if err != nil {
+		return nil, err
+	}
+	return &session.Payload, nil
+}
+
+// ApplePaySession is the merchant session from Apple
+type ApplePaySession struct {
+	// Opaque session payload to pass as is to completeMerchantValidation
+	Payload string
+}
+
+// CreateApplePaySession creates a new Apple Pay merchant session for the given customer account
+// https://docs.moov.io/api/#tag/Cards/operation/createApplePaySession
+func (c Client) CreateApplePaySession(ctx context.Context, accountID string, req ApplePaySessionRequest) (*ApplePaySession, error) {
 	resp, err := c.CallHttp(ctx,
 		Endpoint(http.MethodPost, pathApplePaySessions, accountID),
 		AcceptJson(),
@@ -145,7 +184,15 @@ func (c Client) StartApplePaySession(ctx context.Context, accountID string, req
 
 	switch resp.Status() {
 	case StatusCompleted:
-		return UnmarshalObjectResponse[string](resp)
+		payload, err := UnmarshalObjectResponse[string](resp)
+		if err != nil {
+			return nil, err
+		}
+		return &ApplePaySession{Payload: *payload}, nil
+	case StatusFailedValidation:
+		return nil, ErrDomainsNotVerified
+	case StatusNotFound:
+		return nil, ErrDomainsNotRegistered
 	default:
 		return nil, resp.Error()
 	}
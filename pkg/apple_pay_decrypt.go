@@ -0,0 +1,224 @@
+package moov
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrApplePayVersion       = errors.New("unsupported Apple Pay payment token version")
+	ErrApplePayMerchantKey   = errors.New("apple Pay payment token wasn't encrypted to the merchant certificate")
+	ErrApplePayMerchantCert  = errors.New("invalid Apple Pay merchant certificate")
+	ErrApplePayDecryptFailed = errors.New("unable to decrypt Apple Pay payment token")
+)
+
+const (
+	ApplePayVersionEC  = "EC_v1"
+	ApplePayVersionRSA = "RSA_v1"
+)
+
+// oidApplePayMerchantID is the extension of the merchant identity certificate holding the hash of the merchant ID
+var oidApplePayMerchantID = asn1.ObjectIdentifier{1, 2, 840, 113635, 100, 6, 32}
+
+// DecryptedApplePayToken is the payment data of an Apple Pay payment token
+// https://developer.apple.com/documentation/passkit/apple_pay/payment_token_format_reference
+type DecryptedApplePayToken struct {
+	ApplicationPrimaryAccountNumber string `json:"applicationPrimaryAccountNumber"`
+	// Expiration of the card as YYMMDD
+	ApplicationExpirationDate    string                    `json:"applicationExpirationDate"`
+	CurrencyCode                 string                    `json:"currencyCode"`
+	TransactionAmount            int64                     `json:"transactionAmount"`
+	CardholderName               string                    `json:"cardholderName,omitempty"`
+	DeviceManufacturerIdentifier string                    `json:"deviceManufacturerIdentifier"`
+	PaymentDataType              string                    `json:"paymentDataType"`
+	PaymentData                  DecryptedApplePaymentData `json:"paymentData"`
+}
+
+type DecryptedApplePaymentData struct {
+	// Set when PaymentDataType is 3DSecure
+	OnlinePaymentCryptogram string `json:"onlinePaymentCryptogram,omitempty"`
+	EciIndicator            string `json:"eciIndicator,omitempty"`
+
+	// Set when PaymentDataType is EMV
+	EmvData          string `json:"emvData,omitempty"`
+	EncryptedPINData string `json:"encryptedPINData,omitempty"`
+}
+
+// Expiration returns the month and year the card expires
+func (t DecryptedApplePayToken) Expiration() Expiration {
+	return Expiration{
+		Month: t.ApplicationExpirationDate[2:4],
+		Year:  t.ApplicationExpirationDate[0:2],
+	}
+}
+
+// DecryptApplePayToken decrypts the payment data of an EC_v1 or RSA_v1 Apple Pay payment token with the merchant's
+// payment processing certificate and its private key. This is only needed when processing the token yourself, tokens
+// are linked to Moov as is with LinkApplePayToken.
+//
+// The signature of the token isn't verified against Apple's root certificate, tokens must only be accepted from the
+// Apple Pay JS or PassKit APIs.
+func DecryptApplePayToken(token ApplePayToken, merchantCert tls.Certificate) (*DecryptedApplePayToken, error) {
+	if len(merchantCert.Certificate) == 0 {
+		return nil, fmt.Errorf("%w: no certificate", ErrApplePayMerchantCert)
+	}
+	cert, err := x509.ParseCertificate(merchantCert.Certificate[0])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrApplePayMerchantCert, err)
+	}
+
+	data := token.PaymentData
+
+	keyHash := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	if data.Header.PublicKeyHash != base64.StdEncoding.EncodeToString(keyHash[:]) {
+		return nil, ErrApplePayMerchantKey
+	}
+
+	var key []byte
+	switch data.Version {
+	case ApplePayVersionEC:
+		key, err = applePayECKey(data.Header, cert, merchantCert.PrivateKey)
+	case ApplePayVersionRSA:
+		key, err = applePayRSAKey(data.Header, merchantCert.PrivateKey)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrApplePayVersion, data.Version)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ciphertext, err := base64.StdEncoding.DecodeString(data.Data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrApplePayDecryptFailed, err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrApplePayDecryptFailed, err)
+	}
+	// Apple uses a 16 byte IV of zeros, the key is only ever used once
+	gcm, err := cipher.NewGCMWithNonceSize(block, 16)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrApplePayDecryptFailed, err)
+	}
+
+	plaintext, err := gcm.Open(nil, make([]byte, 16), ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrApplePayDecryptFailed, err)
+	}
+
+	decrypted := &DecryptedApplePayToken{}
+	if err := json.Unmarshal(plaintext, decrypted); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrApplePayDecryptFailed, err)
+	}
+	if len(decrypted.ApplicationExpirationDate) != 6 {
+		return nil, fmt.Errorf("%w: invalid expiration date", ErrApplePayDecryptFailed)
+	}
+
+	return decrypted, nil
+}
+
+// applePayECKey derives the symmetric key of an EC_v1 token from the ephemeral public key and the merchant's private key
+func applePayECKey(header ApplePaymentDataHeader, cert *x509.Certificate, privateKey any) ([]byte, error) {
+	merchantKey, ok := privateKey.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%w: EC_v1 tokens need an EC private key", ErrApplePayMerchantCert)
+	}
+	merchantECDH, err := merchantKey.ECDH()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrApplePayMerchantCert, err)
+	}
+
+	der, err := base64.StdEncoding.DecodeString(header.EphemeralPublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrApplePayDecryptFailed, err)
+	}
+	parsed, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrApplePayDecryptFailed, err)
+	}
+	ephemeralKey, ok := parsed.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("%w: ephemeral public key isn't an EC key", ErrApplePayDecryptFailed)
+	}
+	ephemeralECDH, err := ephemeralKey.ECDH()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrApplePayDecryptFailed, err)
+	}
+
+	sharedSecret, err := merchantECDH.ECDH(ephemeralECDH)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrApplePayDecryptFailed, err)
+	}
+
+	merchantID, err := applePayMerchantID(cert)
+	if err != nil {
+		return nil, err
+	}
+
+	// NIST SP 800-56A single step KDF with SHA-256, a single round gives the 32 bytes of an AES-256 key
+	kdf := sha256.New()
+	kdf.Write([]byte{0, 0, 0, 1})
+	kdf.Write(sharedSecret)
+	kdf.Write([]byte("\x0did-aes256-GCM"))
+	kdf.Write([]byte("Apple"))
+	kdf.Write(merchantID)
+
+	return kdf.Sum(nil), nil
+}
+
+// applePayRSAKey unwraps the symmetric key of an RSA_v1 token with the merchant's private key
+func applePayRSAKey(header ApplePaymentDataHeader, privateKey any) ([]byte, error) {
+	merchantKey, ok := privateKey.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%w: RSA_v1 tokens need an RSA private key", ErrApplePayMerchantCert)
+	}
+
+	wrappedKey, err := base64.StdEncoding.DecodeString(header.WrappedKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrApplePayDecryptFailed, err)
+	}
+
+	key, err := rsa.DecryptOAEP(sha256.New(), rand.Reader, merchantKey, wrappedKey, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrApplePayDecryptFailed, err)
+	}
+
+	return key, nil
+}
+
+// applePayMerchantID returns the SHA-256 hash of the merchant ID held hex encoded in the merchant certificate
+func applePayMerchantID(cert *x509.Certificate) ([]byte, error) {
+	for _, ext := range cert.Extensions {
+		if !ext.Id.Equal(oidApplePayMerchantID) {
+			continue
+		}
+
+		value := string(ext.Value)
+		var str string
+		if _, err := asn1.Unmarshal(ext.Value, &str); err == nil {
+			value = str
+		}
+
+		merchantID, err := hex.DecodeString(strings.TrimSpace(value))
+		if err != nil || len(merchantID) != sha256.Size {
+			return nil, fmt.Errorf("%w: invalid merchant ID", ErrApplePayMerchantCert)
+		}
+		return merchantID, nil
+	}
+
+	return nil, fmt.Errorf("%w: no merchant ID", ErrApplePayMerchantCert)
+}
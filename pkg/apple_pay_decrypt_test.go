@@ -0,0 +1,203 @@
+package moov_test
+
+import (
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"math/big"
+	"testing"
+	"time"
+
+	moov "github.com/moovfinancial/moov-go/pkg"
+	"github.com/stretchr/testify/require"
+)
+
+const applePayPlaintext = `{
+	"applicationPrimaryAccountNumber": "4109370251004320",
+	"applicationExpirationDate": "301231",
+	"currencyCode": "840",
+	"transactionAmount": 100,
+	"deviceManufacturerIdentifier": "040010030273",
+	"paymentDataType": "3DSecure",
+	"paymentData": {
+		"onlinePaymentCryptogram": "Af9x/QwAA/DjmU65oyc1MAABAAA=",
+		"eciIndicator": "5"
+	}
+}`
+
+// applePayMerchantID is the hash of the merchant ID in the merchant certificates made by newApplePayMerchant
+var applePayMerchantID = sha256.Sum256([]byte("merchant.io.moov.test"))
+
+// newApplePayMerchant returns a self signed payment processing certificate for the key, like the one Apple issues
+func newApplePayMerchant(t *testing.T, key crypto.Signer) tls.Certificate {
+	merchantID, err := asn1.MarshalWithParams(hex.EncodeToString(applePayMerchantID[:]), "ia5")
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "merchant.io.moov.test"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtraExtensions: []pkix.Extension{
+			{Id: asn1.ObjectIdentifier{1, 2, 840, 113635, 100, 6, 32}, Value: merchantID},
+		},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	require.NoError(t, err)
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+// applePaySeal encrypts the payment data the way Apple does, with a 16 byte IV of zeros
+func applePaySeal(t *testing.T, key []byte, plaintext string) string {
+	block, err := aes.NewCipher(key)
+	require.NoError(t, err)
+	gcm, err := cipher.NewGCMWithNonceSize(block, 16)
+	require.NoError(t, err)
+
+	return base64.StdEncoding.EncodeToString(gcm.Seal(nil, make([]byte, 16), []byte(plaintext), nil))
+}
+
+func applePayKeyHash(t *testing.T, merchant tls.Certificate) string {
+	cert, err := x509.ParseCertificate(merchant.Certificate[0])
+	require.NoError(t, err)
+
+	hash := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	return base64.StdEncoding.EncodeToString(hash[:])
+}
+
+// newECApplePayToken encrypts the plaintext into an EC_v1 token for the merchant
+func newECApplePayToken(t *testing.T, merchant tls.Certificate, plaintext string) moov.ApplePayToken {
+	ephemeral, err := ecdh.P256().GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	merchantKey, err := merchant.PrivateKey.(*ecdsa.PrivateKey).PublicKey.ECDH()
+	require.NoError(t, err)
+	sharedSecret, err := ephemeral.ECDH(merchantKey)
+	require.NoError(t, err)
+
+	kdf := sha256.New()
+	kdf.Write([]byte{0, 0, 0, 1})
+	kdf.Write(sharedSecret)
+	kdf.Write([]byte("\x0did-aes256-GCM"))
+	kdf.Write([]byte("Apple"))
+	kdf.Write(applePayMerchantID[:])
+
+	ephemeralDER, err := x509.MarshalPKIXPublicKey(ephemeral.PublicKey())
+	require.NoError(t, err)
+
+	return moov.ApplePayToken{
+		PaymentData: moov.ApplePaymentData{
+			Version: moov.ApplePayVersionEC,
+			Data:    applePaySeal(t, kdf.Sum(nil), plaintext),
+			Header: moov.ApplePaymentDataHeader{
+				EphemeralPublicKey: base64.StdEncoding.EncodeToString(ephemeralDER),
+				PublicKeyHash:      applePayKeyHash(t, merchant),
+				TransactionId:      "transaction-id",
+			},
+		},
+	}
+}
+
+// newRSAApplePayToken encrypts the plaintext into an RSA_v1 token for the merchant
+func newRSAApplePayToken(t *testing.T, merchant tls.Certificate, plaintext string) moov.ApplePayToken {
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+
+	wrappedKey, err := rsa.EncryptOAEP(sha256.New(), rand.Reader, &merchant.PrivateKey.(*rsa.PrivateKey).PublicKey, key, nil)
+	require.NoError(t, err)
+
+	return moov.ApplePayToken{
+		PaymentData: moov.ApplePaymentData{
+			Version: moov.ApplePayVersionRSA,
+			Data:    applePaySeal(t, key, plaintext),
+			Header: moov.ApplePaymentDataHeader{
+				WrappedKey:    base64.StdEncoding.EncodeToString(wrappedKey),
+				PublicKeyHash: applePayKeyHash(t, merchant),
+				TransactionId: "transaction-id",
+			},
+		},
+	}
+}
+
+func requireDecryptedApplePayToken(t *testing.T, decrypted *moov.DecryptedApplePayToken) {
+	require.Equal(t, "4109370251004320", decrypted.ApplicationPrimaryAccountNumber)
+	require.Equal(t, moov.Expiration{Month: "12", Year: "30"}, decrypted.Expiration())
+	require.Equal(t, "3DSecure", decrypted.PaymentDataType)
+	require.Equal(t, "Af9x/QwAA/DjmU65oyc1MAABAAA=", decrypted.PaymentData.OnlinePaymentCryptogram)
+	require.Equal(t, "5", decrypted.PaymentData.EciIndicator)
+	require.Equal(t, int64(100), decrypted.TransactionAmount)
+}
+
+func TestDecryptApplePayToken_EC(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	merchant := newApplePayMerchant(t, key)
+
+	decrypted, err := moov.DecryptApplePayToken(newECApplePayToken(t, merchant, applePayPlaintext), merchant)
+	require.NoError(t, err)
+	requireDecryptedApplePayToken(t, decrypted)
+}
+
+func TestDecryptApplePayToken_RSA(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	merchant := newApplePayMerchant(t, key)
+
+	decrypted, err := moov.DecryptApplePayToken(newRSAApplePayToken(t, merchant, applePayPlaintext), merchant)
+	require.NoError(t, err)
+	requireDecryptedApplePayToken(t, decrypted)
+}
+
+func TestDecryptApplePayToken_Errors(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	merchant := newApplePayMerchant(t, key)
+
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	otherMerchant := newApplePayMerchant(t, otherKey)
+
+	t.Run("other merchant", func(t *testing.T) {
+		_, err := moov.DecryptApplePayToken(newECApplePayToken(t, otherMerchant, applePayPlaintext), merchant)
+		require.ErrorIs(t, err, moov.ErrApplePayMerchantKey)
+	})
+
+	t.Run("unknown version", func(t *testing.T) {
+		token := newECApplePayToken(t, merchant, applePayPlaintext)
+		token.PaymentData.Version = "EC_v2"
+
+		_, err := moov.DecryptApplePayToken(token, merchant)
+		require.ErrorIs(t, err, moov.ErrApplePayVersion)
+	})
+
+	t.Run("tampered", func(t *testing.T) {
+		token := newECApplePayToken(t, merchant, applePayPlaintext)
+		data, err := base64.StdEncoding.DecodeString(token.PaymentData.Data)
+		require.NoError(t, err)
+		data[0] ^= 1
+		token.PaymentData.Data = base64.StdEncoding.EncodeToString(data)
+
+		_, err = moov.DecryptApplePayToken(token, merchant)
+		require.ErrorIs(t, err, moov.ErrApplePayDecryptFailed)
+	})
+
+	t.Run("no certificate", func(t *testing.T) {
+		_, err := moov.DecryptApplePayToken(newECApplePayToken(t, merchant, applePayPlaintext), tls.Certificate{})
+		require.ErrorIs(t, err, moov.ErrApplePayMerchantCert)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"net/http"
 	"testing"
 
 	moov "github.com/moovfinancial/moov-go/pkg"
@@ -38,6 +39,70 @@ func TestApplePayMarshal(t *testing.T) {
 	assert.Equal(t, "Visa 1234", applePay.CardDisplayName)
 }
 
+func TestRegisterApplePayDomain(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/accounts/account-id/apple-pay/domains", r.URL.Path)
+
+		if r.Method == http.MethodGet {
+			WriteJson(w, http.StatusNotFound, `{"error": "no domains registered"}`)
+			return
+		}
+
+		domains := moov.ApplePayDomains{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&domains))
+
+		if domains.Domains[0] == "registered.classbooker.dev" {
+			WriteJson(w, http.StatusConflict, `{"error": "domains already registered"}`)
+			return
+		}
+
+		require.Equal(t, []string{"checkout.classbooker.dev"}, domains.Domains)
+		WriteJson(w, http.StatusOK, `{"accountID": "account-id", "domains": ["checkout.classbooker.dev"]}`)
+	})
+
+	err := mc.RegisterApplePayDomain(BgCtx(), "account-id", []string{"checkout.classbooker.dev"})
+	require.NoError(t, err)
+
+	err = mc.RegisterApplePayDomain(BgCtx(), "account-id", []string{"registered.classbooker.dev"})
+	require.ErrorIs(t, err, moov.ErrDuplicatedApplePayDomain)
+
+	_, err = mc.GetApplePayDomain(BgCtx(), "account-id")
+	require.ErrorIs(t, err, moov.ErrDomainsNotRegistered)
+}
+
+func TestCreateApplePaySession(t *testing.T) {
+	const session = `{"epochTimestamp":1700000000000,"merchantSessionIdentifier":"SSH6F3A","nonce":"a1b2c3","signature":"3080..."}`
+
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/accounts/account-id/apple-pay/sessions", r.URL.Path)
+
+		req := moov.ApplePaySessionRequest{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		require.Equal(t, moov.ApplePaySessionRequest{
+			DisplayName:   "Example Merchant",
+			Domain:        "checkout.classbooker.dev",
+			ValidationURL: "https://apple-pay-gateway.apple.com/paymentservices/startSession",
+		}, req)
+
+		WriteJson(w, http.StatusOK, session)
+	})
+
+	req := moov.ApplePaySessionRequest{
+		DisplayName:   "Example Merchant",
+		Domain:        "checkout.classbooker.dev",
+		ValidationURL: "https://apple-pay-gateway.apple.com/paymentservices/startSession",
+	}
+
+	resp, err := mc.CreateApplePaySession(BgCtx(), "account-id", req)
+	require.NoError(t, err)
+	require.Equal(t, session, resp.Payload)
+
+	payload, err := mc.StartApplePaySession(BgCtx(), "account-id", req)
+	require.NoError(t, err)
+	require.Equal(t, session, *payload)
+}
+
 type ApplePayTestSuite struct {
 	suite.Suite
 	// values for testing will be set in init()
@@ -107,8 +172,8 @@ func (s *ApplePayTestSuite) TestGetApplePayDomain() {
 func (s *ApplePayTestSuite) TestCreateApplePaySession() {
 	mc := NewTestClient(s.T())
 
-	_, err := mc.StartApplePaySession(BgCtx(), s.accountID,
-		moov.StartApplePaySession{
+	_, err := mc.CreateApplePaySession(BgCtx(), s.accountID,
+		moov.ApplePaySessionRequest{
 			Domain:      "checkout.classbooker.dev",
 			DisplayName: "Example Merchant",
 		})
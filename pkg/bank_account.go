@@ -2,27 +2,91 @@ package moov
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"time"
 )
 
 var (
-	ErrDuplicateBankAccount = errors.New("duplciate bank account or invalid routing number")
+	ErrDuplicateBankAccount = errors.New("the bank account already exists on the account")
 	ErrNoMicroDeposit       = errors.New("no account with the specified accountID was found or micro-deposits have not been sent for the source")
+	ErrNoBankAccount        = errors.New("no bank account with the specified bankAccountID was found")
+	ErrBankAccountConflict  = errors.New("the bank account can't be updated in its current state")
+	ErrMicroDepositFailed   = errors.New("the micro-deposit verification failed and can't be confirmed")
+	ErrInvalidPlaidToken    = errors.New("exactly one of the Plaid token, Plaid Link token or MX authorization code must be set")
+	ErrInvalidHolderType    = errors.New("unknown bank account holder type")
+	ErrInvalidAccountType   = errors.New("unknown bank account type")
 )
 
+// HolderType is who owns a bank account
+type HolderType string
+
+const (
+	HolderTypeIndividual HolderType = "individual"
+	HolderTypeBusiness   HolderType = "business"
+)
+
+// ParseHolderType converts the Moov API representation of a holder type into a HolderType
+func ParseHolderType(s string) (HolderType, error) {
+	t := HolderType(s)
+	if err := t.Validate(); err != nil {
+		return "", err
+	}
+	return t, nil
+}
+
+// Validate returns ErrInvalidHolderType unless the holder type is one of HolderType*
+func (t HolderType) Validate() error {
+	switch t {
+	case HolderTypeIndividual, HolderTypeBusiness:
+		return nil
+	default:
+		return fmt.Errorf("%w: %q", ErrInvalidHolderType, string(t))
+	}
+}
+
+// BankAccountType is the kind of account held at the bank
+type BankAccountType string
+
+const (
+	BankAccountTypeChecking      BankAccountType = "checking"
+	BankAccountTypeSavings       BankAccountType = "savings"
+	BankAccountTypeGeneralLedger BankAccountType = "general-ledger"
+	BankAccountTypeLoan          BankAccountType = "loan"
+)
+
+// ParseBankAccountType converts the Moov API representation of a bank account type into a BankAccountType
+func ParseBankAccountType(s string) (BankAccountType, error) {
+	t := BankAccountType(s)
+	if err := t.Validate(); err != nil {
+		return "", err
+	}
+	return t, nil
+}
+
+// Validate returns ErrInvalidAccountType unless the bank account type is one of BankAccountType*
+func (t BankAccountType) Validate() error {
+	switch t {
+	case BankAccountTypeChecking, BankAccountTypeSavings, BankAccountTypeGeneralLedger, BankAccountTypeLoan:
+		return nil
+	default:
+		return fmt.Errorf("%w: %q", ErrInvalidAccountType, string(t))
+	}
+}
+
 type BankAccount struct {
-	BankAccountID         string `json:"bankAccountID,omitempty"`
-	Fingerprint           string `json:"fingerprint,omitempty"`
-	Status                string `json:"status,omitempty"`
-	HolderName            string `json:"holderName,omitempty"`
-	HolderType            string `json:"holderType,omitempty"`
-	BankName              string `json:"bankName,omitempty"`
-	BankAccountType       string `json:"bankAccountType,omitempty"`
-	AccountNumber         string `json:"accountNumber,omitempty"`
-	RoutingNumber         string `json:"routingNumber,omitempty"`
-	LastFourAccountNumber string `json:"lastFourAccountNumber,omitempty"`
+	BankAccountID         string          `json:"bankAccountID,omitempty"`
+	Fingerprint           string          `json:"fingerprint,omitempty"`
+	Status                string          `json:"status,omitempty"`
+	HolderName            string          `json:"holderName,omitempty"`
+	HolderType            HolderType      `json:"holderType,omitempty"`
+	BankName              string          `json:"bankName,omitempty"`
+	BankAccountType       BankAccountType `json:"bankAccountType,omitempty"`
+	AccountNumber         string          `json:"accountNumber,omitempty"`
+	RoutingNumber         string          `json:"routingNumber,omitempty"`
+	LastFourAccountNumber string          `json:"lastFourAccountNumber,omitempty"`
 }
 
 type AchDetails struct {
@@ -56,16 +120,105 @@ type ACHStatusUpdates struct {
 	Completed  time.Time `json:"completed,omitempty"`
 }
 
+// MarshalJSON leaves out the status updates that haven't happened
+func (u ACHStatusUpdates) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Initiated  *time.Time `json:"initiated,omitempty"`
+		Originated *time.Time `json:"originated,omitempty"`
+		Corrected  *time.Time `json:"corrected,omitempty"`
+		Returned   *time.Time `json:"returned,omitempty"`
+		Completed  *time.Time `json:"completed,omitempty"`
+	}{
+		Initiated:  timeOrNil(u.Initiated),
+		Originated: timeOrNil(u.Originated),
+		Corrected:  timeOrNil(u.Corrected),
+		Returned:   timeOrNil(u.Returned),
+		Completed:  timeOrNil(u.Completed),
+	})
+}
+
+// PlaidToken links a bank account already verified by a processor instead of using its account and routing numbers.
+// Exactly one of the fields must be set.
+type PlaidToken struct {
+	// Processor token from Plaid's processor token flow
+	PlaidToken string
+	// Public token from Plaid Link
+	PlaidLinkToken string
+	// Authorization code from MX
+	MxAuthorizationCode string
+}
+
+type bankAccountTokenPayload struct {
+	Plaid     *plaidPayload     `json:"plaid,omitempty"`
+	PlaidLink *plaidLinkPayload `json:"plaidLink,omitempty"`
+	Mx        *mxPayload        `json:"mx,omitempty"`
+}
+
+type plaidPayload struct {
+	Token string `json:"token"`
+}
+
+type plaidLinkPayload struct {
+	PublicToken string `json:"publicToken"`
+}
+
+type mxPayload struct {
+	AuthorizationCode string `json:"authorizationCode"`
+}
+
+func (t PlaidToken) payload() (bankAccountTokenPayload, error) {
+	payload := bankAccountTokenPayload{}
+	set := 0
+
+	if t.PlaidToken != "" {
+		payload.Plaid = &plaidPayload{Token: t.PlaidToken}
+		set++
+	}
+	if t.PlaidLinkToken != "" {
+		payload.PlaidLink = &plaidLinkPayload{PublicToken: t.PlaidLinkToken}
+		set++
+	}
+	if t.MxAuthorizationCode != "" {
+		payload.Mx = &mxPayload{AuthorizationCode: t.MxAuthorizationCode}
+		set++
+	}
+
+	if set != 1 {
+		return payload, ErrInvalidPlaidToken
+	}
+	return payload, nil
+}
+
+// BankAccountUpdate holds the fields of a bank account that can be changed after it was created
+type BankAccountUpdate struct {
+	HolderName string     `json:"holderName,omitempty"`
+	HolderType HolderType `json:"holderType,omitempty"`
+}
+
 type BankAccountPayload struct {
 	Account BankAccount `json:"account"`
 }
 
 // CreateBankAccount creates a new bank account for the given customer account
 func (c Client) CreateBankAccount(ctx context.Context, accountID string, bankAccount BankAccount) (*BankAccount, error) {
+	if err := ValidateRoutingNumber(bankAccount.RoutingNumber); err != nil {
+		return nil, err
+	}
+	if bankAccount.HolderType != "" {
+		if err := bankAccount.HolderType.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	if bankAccount.BankAccountType != "" {
+		if err := bankAccount.BankAccountType.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
 	resp, err := c.CallHttp(ctx,
 		Endpoint(http.MethodPost, pathBankAccounts, accountID),
 		AcceptJson(),
-		JsonBody(bankAccount))
+		EncryptedJsonBody(bankAccount))
 	if err != nil {
 		return nil, err
 	}
@@ -80,10 +233,79 @@ func (c Client) CreateBankAccount(ctx context.Context, accountID string, bankAcc
 	}
 }
 
+// CreateBankAccountFromToken links a bank account verified through Plaid or MX to the given customer account.
+// Bank accounts linked this way are returned as verified without needing micro-deposits.
+func (c Client) CreateBankAccountFromToken(ctx context.Context, accountID string, token PlaidToken) (*BankAccount, error) {
+	payload, err := token.payload()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.CallHttp(ctx,
+		Endpoint(http.MethodPost, pathBankAccounts, accountID),
+		AcceptJson(),
+		JsonBody(payload))
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.Status() {
+	case StatusCompleted:
+		return CompletedObjectOrError[BankAccount](resp)
+	case StatusStateConflict:
+		return nil, ErrDuplicateBankAccount
+	default:
+		return nil, resp.Error()
+	}
+}
+
+// BankAccountResult is the outcome of creating one of the bank accounts passed to CreateBankAccounts
+type BankAccountResult struct {
+	BankAccount *BankAccount
+	Err         error
+}
+
+// CreateBankAccounts creates several bank accounts for the given customer account concurrently. Results are returned in
+// the same order as the accounts with ErrDuplicateBankAccount set for accounts that already exist or are repeated
+// in the list. If the context is done before all accounts were created the context's error is returned and set on
+// the accounts that weren't attempted.
+func (c Client) CreateBankAccounts(ctx context.Context, accountID string, accounts []BankAccount) ([]BankAccountResult, error) {
+	results := make([]BankAccountResult, len(accounts))
+
+	// skip sending accounts repeated in the list, only the first one would be created
+	seen := map[[2]string]bool{}
+	pending := []int{}
+	for i, account := range accounts {
+		key := [2]string{account.RoutingNumber, account.AccountNumber}
+		if seen[key] {
+			results[i].Err = ErrDuplicateBankAccount
+			continue
+		}
+		seen[key] = true
+		pending = append(pending, i)
+	}
+
+	err := forEachConcurrent(ctx, len(pending), defaultConcurrency, func(ctx context.Context, i int) {
+		idx := pending[i]
+		created, err := c.CreateBankAccount(ctx, accountID, accounts[idx])
+		results[idx] = BankAccountResult{BankAccount: created, Err: err}
+	})
+	if err != nil {
+		for i := range results {
+			if results[i].BankAccount == nil && results[i].Err == nil {
+				results[i].Err = err
+			}
+		}
+		return results, err
+	}
+
+	return results, nil
+}
+
 // GetBankAccount retrieves a bank account for the given customer account
 func (c Client) GetBankAccount(ctx context.Context, accountID string, bankAccountID string) (*BankAccount, error) {
 	resp, err := c.CallHttp(ctx,
-		Endpoint(http.MethodGet, pathBankAccounts, accountID),
+		Endpoint(http.MethodGet, pathBankAccount, accountID, bankAccountID),
 		AcceptJson())
 	if err != nil {
 		return nil, err
@@ -92,9 +314,37 @@ func (c Client) GetBankAccount(ctx context.Context, accountID string, bankAccoun
 	return CompletedObjectOrError[BankAccount](resp)
 }
 
+// UpdateBankAccount updates the holder details of a bank account for the given customer account
+func (c Client) UpdateBankAccount(ctx context.Context, accountID string, bankAccountID string, update BankAccountUpdate) (*BankAccount, error) {
+	if update.HolderType != "" {
+		if err := update.HolderType.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := c.CallHttp(ctx,
+		Endpoint(http.MethodPatch, pathBankAccount, accountID, bankAccountID),
+		AcceptJson(),
+		JsonBody(update))
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.Status() {
+	case StatusCompleted:
+		return UnmarshalObjectResponse[BankAccount](resp)
+	case StatusNotFound:
+		return nil, ErrNoBankAccount
+	case StatusStateConflict:
+		return nil, ErrBankAccountConflict
+	default:
+		return nil, resp.Error()
+	}
+}
+
 // DeleteBankAccount deletes a bank account for the given customer account
 func (c Client) DeleteBankAccount(ctx context.Context, accountID string, bankAccountID string) error {
-	resp, err := c.CallHttp(ctx, Endpoint(http.MethodDelete, pathBankAccounts, accountID))
+	resp, err := c.CallHttp(ctx, Endpoint(http.MethodDelete, pathBankAccount, accountID, bankAccountID))
 	if err != nil {
 		return err
 	}
@@ -124,24 +374,96 @@ func (c Client) MicroDepositInitiate(ctx context.Context, accountID string, bank
 	return CompletedNilOrError(resp)
 }
 
-// MicroDepositConfirm confirms a micro deposit verification for the given bank account
-func (c Client) MicroDepositConfirm(ctx context.Context, accountID string, bankAccountID string, amounts []int) error {
+const (
+	MICRO_DEPOSIT_PENDING               = "pending"
+	MICRO_DEPOSIT_SENT                  = "sent"
+	MICRO_DEPOSIT_VERIFIED              = "verified"
+	MICRO_DEPOSIT_ERRORED               = "errored"
+	MICRO_DEPOSIT_MAX_ATTEMPTS_EXCEEDED = "max-attempts-exceeded"
+)
+
+// MicroDepositVerification is the state of verifying a bank account with micro-deposits
+type MicroDepositVerification struct {
+	Status            string `json:"status,omitempty"`
+	AttemptsUsed      int    `json:"attemptsUsed,omitempty"`
+	AttemptsRemaining int    `json:"attemptsRemaining,omitempty"`
+}
+
+// MicroDepositStatus retrieves the state of the micro deposit verification for the given bank account
+func (c Client) MicroDepositStatus(ctx context.Context, accountID string, bankAccountID string) (*MicroDepositVerification, error) {
+	resp, err := c.CallHttp(ctx,
+		Endpoint(http.MethodGet, pathMicroDeposits, accountID, bankAccountID),
+		AcceptJson())
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.Status() {
+	case StatusCompleted:
+		return UnmarshalObjectResponse[MicroDepositVerification](resp)
+	case StatusNotFound:
+		return nil, ErrNoMicroDeposit
+	default:
+		return nil, resp.Error()
+	}
+}
+
+// WaitForMicroDeposits polls the micro deposit verification every interval until the deposits have been sent and
+// can be confirmed with MicroDepositConfirm, or the context is done. ErrMicroDepositFailed is returned when the
+// verification errored or ran out of attempts instead.
+func (c Client) WaitForMicroDeposits(ctx context.Context, accountID string, bankAccountID string, interval time.Duration) error {
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+
+	for {
+		verification, err := c.MicroDepositStatus(ctx, accountID, bankAccountID)
+		if err != nil {
+			return err
+		}
+
+		switch verification.Status {
+		case MICRO_DEPOSIT_SENT, MICRO_DEPOSIT_VERIFIED:
+			return nil
+		case MICRO_DEPOSIT_ERRORED, MICRO_DEPOSIT_MAX_ATTEMPTS_EXCEEDED:
+			return fmt.Errorf("%w: %s", ErrMicroDepositFailed, verification.Status)
+		}
+
+		if err := sleep(ctx, interval); err != nil {
+			return err
+		}
+	}
+}
+
+// MicroDepositConfirm confirms a micro deposit verification for the given bank account and returns the updated
+// state of the verification. When the amounts are incorrect the state is returned along with ErrAmountIncorrect
+// so callers can tell if attempts remain, or ErrAmountIncorrect wrapping the error retrieving the state.
+func (c Client) MicroDepositConfirm(ctx context.Context, accountID string, bankAccountID string, amounts []int) (*MicroDepositVerification, error) {
 	resp, err := c.CallHttp(ctx,
 		Endpoint(http.MethodPut, pathMicroDeposits, accountID, bankAccountID),
 		AcceptJson(),
 		JsonBody(map[string][]int{"amounts": amounts}))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	switch resp.Status() {
 	case StatusCompleted:
-		return nil
+		// the bank account is verified at this point, failing to retrieve the rest of the state doesn't undo that
+		verification, err := c.MicroDepositStatus(ctx, accountID, bankAccountID)
+		if err != nil {
+			return &MicroDepositVerification{Status: MICRO_DEPOSIT_VERIFIED}, nil
+		}
+		return verification, nil
 	case StatusNotFound:
-		return ErrNoMicroDeposit
+		return nil, ErrNoMicroDeposit
 	case StatusStateConflict:
-		return ErrAmountIncorrect
+		verification, err := c.MicroDepositStatus(ctx, accountID, bankAccountID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrAmountIncorrect, err)
+		}
+		return verification, ErrAmountIncorrect
 	default:
-		return resp.Error()
+		return nil, resp.Error()
 	}
 }
@@ -6,8 +6,12 @@ import (
 	"crypto/rand"
 	"encoding/json"
 	"fmt"
+	"io"
 	"math/big"
+	"net/http"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	moov "github.com/moovfinancial/moov-go/pkg"
 	"github.com/stretchr/testify/require"
@@ -19,6 +23,299 @@ func randomBankAccountNumber() string {
 	return fmt.Sprintf("%d", 100000000+n.Int64())
 }
 
+func TestCreateBankAccounts(t *testing.T) {
+	requests := int32(0)
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		require.Equal(t, "/accounts/account-id/bank-accounts", r.URL.Path)
+
+		account := moov.BankAccount{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&account))
+
+		if account.AccountNumber == "existing" {
+			WriteJson(w, http.StatusConflict, `{"error": "duplicate bank account"}`)
+			return
+		}
+
+		WriteJson(w, http.StatusOK, fmt.Sprintf(`{"bankAccountID": "id-%s", "status": "new"}`, account.AccountNumber))
+	})
+
+	results, err := mc.CreateBankAccounts(BgCtx(), "account-id", []moov.BankAccount{
+		{RoutingNumber: "273976369", AccountNumber: "1"},
+		{RoutingNumber: "273976369", AccountNumber: "existing"},
+		{RoutingNumber: "273976369", AccountNumber: "2"},
+		{RoutingNumber: "273976369", AccountNumber: "1"},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 4)
+
+	require.NoError(t, results[0].Err)
+	require.Equal(t, "id-1", results[0].BankAccount.BankAccountID)
+
+	require.ErrorIs(t, results[1].Err, moov.ErrDuplicateBankAccount)
+	require.Nil(t, results[1].BankAccount)
+
+	require.NoError(t, results[2].Err)
+	require.Equal(t, "id-2", results[2].BankAccount.BankAccountID)
+
+	// repeated in the list so never sent
+	require.ErrorIs(t, results[3].Err, moov.ErrDuplicateBankAccount)
+	require.Equal(t, int32(3), atomic.LoadInt32(&requests))
+}
+
+func TestCreateBankAccounts_Canceled(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("no requests should be made with a canceled context")
+	})
+
+	ctx, cancel := context.WithCancel(BgCtx())
+	cancel()
+
+	results, err := mc.CreateBankAccounts(ctx, "account-id", []moov.BankAccount{
+		{RoutingNumber: "273976369", AccountNumber: "1"},
+	})
+	require.ErrorIs(t, err, context.Canceled)
+	require.ErrorIs(t, results[0].Err, context.Canceled)
+}
+
+func TestMicroDepositStatus(t *testing.T) {
+	cases := []struct {
+		name       string
+		status     string
+		confirmErr error
+	}{
+		{"verified", moov.MICRO_DEPOSIT_VERIFIED, nil},
+		{"pending", moov.MICRO_DEPOSIT_PENDING, moov.ErrAmountIncorrect},
+		{"max attempts exceeded", moov.MICRO_DEPOSIT_MAX_ATTEMPTS_EXCEEDED, moov.ErrAmountIncorrect},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, "/accounts/account-id/bank-accounts/bank-account-id/microdeposits", r.URL.Path)
+
+				switch r.Method {
+				case http.MethodPut:
+					if tc.confirmErr != nil {
+						WriteJson(w, http.StatusConflict, `{"error": "amounts incorrect"}`)
+						return
+					}
+					w.WriteHeader(http.StatusNoContent)
+				case http.MethodGet:
+					WriteJson(w, http.StatusOK, fmt.Sprintf(`{"status": %q, "attemptsUsed": 2, "attemptsRemaining": 1}`, tc.status))
+				}
+			})
+
+			verification, err := mc.MicroDepositStatus(BgCtx(), "account-id", "bank-account-id")
+			require.NoError(t, err)
+			require.Equal(t, tc.status, verification.Status)
+			require.Equal(t, 2, verification.AttemptsUsed)
+			require.Equal(t, 1, verification.AttemptsRemaining)
+
+			verification, err = mc.MicroDepositConfirm(BgCtx(), "account-id", "bank-account-id", []int{18, 21})
+			if tc.confirmErr != nil {
+				require.ErrorIs(t, err, tc.confirmErr)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.status, verification.Status)
+		})
+	}
+}
+
+func TestMicroDepositConfirm_StatusUnavailable(t *testing.T) {
+	confirmStatus := http.StatusNoContent
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut:
+			WriteJson(w, confirmStatus, `{}`)
+		case http.MethodGet:
+			WriteJson(w, http.StatusInternalServerError, `{"error": "unavailable"}`)
+		}
+	})
+
+	verification, err := mc.MicroDepositConfirm(BgCtx(), "account-id", "bank-account-id", []int{18, 21})
+	require.NoError(t, err)
+	require.Equal(t, moov.MICRO_DEPOSIT_VERIFIED, verification.Status)
+
+	confirmStatus = http.StatusConflict
+	_, err = mc.MicroDepositConfirm(BgCtx(), "account-id", "bank-account-id", []int{18, 21})
+	require.ErrorIs(t, err, moov.ErrAmountIncorrect)
+
+	var apiErr *moov.APIError
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, http.StatusInternalServerError, apiErr.StatusCode())
+}
+
+func TestWaitForMicroDeposits(t *testing.T) {
+	var polls atomic.Int32
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		require.Equal(t, "/accounts/account-id/bank-accounts/bank-account-id/microdeposits", r.URL.Path)
+
+		status := moov.MICRO_DEPOSIT_PENDING
+		if polls.Add(1) > 2 {
+			status = moov.MICRO_DEPOSIT_SENT
+		}
+		WriteJson(w, http.StatusOK, fmt.Sprintf(`{"status": %q}`, status))
+	})
+
+	err := mc.WaitForMicroDeposits(BgCtx(), "account-id", "bank-account-id", time.Millisecond)
+	require.NoError(t, err)
+	require.Equal(t, int32(3), polls.Load())
+}
+
+func TestWaitForMicroDeposits_Failed(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		WriteJson(w, http.StatusOK, `{"status": "errored"}`)
+	})
+
+	err := mc.WaitForMicroDeposits(BgCtx(), "account-id", "bank-account-id", time.Millisecond)
+	require.ErrorIs(t, err, moov.ErrMicroDepositFailed)
+}
+
+func TestWaitForMicroDeposits_ContextCanceled(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		WriteJson(w, http.StatusOK, `{"status": "pending"}`)
+	})
+
+	ctx, cancel := context.WithTimeout(BgCtx(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := mc.WaitForMicroDeposits(ctx, "account-id", "bank-account-id", time.Hour)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Less(t, time.Since(start), time.Second)
+}
+
+func TestCreateBankAccountFromToken(t *testing.T) {
+	cases := []struct {
+		name     string
+		token    moov.PlaidToken
+		expected string
+	}{
+		{"plaid", moov.PlaidToken{PlaidToken: "processor-token"}, `{"plaid":{"token":"processor-token"}}`},
+		{"plaid link", moov.PlaidToken{PlaidLinkToken: "public-token"}, `{"plaidLink":{"publicToken":"public-token"}}`},
+		{"mx", moov.PlaidToken{MxAuthorizationCode: "auth-code"}, `{"mx":{"authorizationCode":"auth-code"}}`},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, http.MethodPost, r.Method)
+				require.Equal(t, "/accounts/account-id/bank-accounts", r.URL.Path)
+
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				require.JSONEq(t, tc.expected, string(body))
+
+				WriteJson(w, http.StatusOK, `{"bankAccountID": "bank-account-id", "status": "verified"}`)
+			})
+
+			account, err := mc.CreateBankAccountFromToken(BgCtx(), "account-id", tc.token)
+			require.NoError(t, err)
+			require.Equal(t, "verified", account.Status)
+		})
+	}
+
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("invalid tokens should not be sent")
+	})
+
+	_, err := mc.CreateBankAccountFromToken(BgCtx(), "account-id", moov.PlaidToken{})
+	require.ErrorIs(t, err, moov.ErrInvalidPlaidToken)
+
+	_, err = mc.CreateBankAccountFromToken(BgCtx(), "account-id", moov.PlaidToken{PlaidToken: "a", MxAuthorizationCode: "b"})
+	require.ErrorIs(t, err, moov.ErrInvalidPlaidToken)
+}
+
+func TestUpdateBankAccount(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPatch, r.Method)
+
+		switch r.URL.Path {
+		case "/accounts/account-id/bank-accounts/bank-account-id":
+			update := map[string]string{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&update))
+			require.Equal(t, map[string]string{"holderName": "Jules Jackson"}, update)
+
+			WriteJson(w, http.StatusOK, `{"bankAccountID": "bank-account-id", "holderName": "Jules Jackson", "holderType": "individual"}`)
+		case "/accounts/account-id/bank-accounts/verified-id":
+			WriteJson(w, http.StatusConflict, `{"error": "bank account is verified"}`)
+		default:
+			WriteJson(w, http.StatusNotFound, `{"error": "not found"}`)
+		}
+	})
+
+	t.Run("updated", func(t *testing.T) {
+		account, err := mc.UpdateBankAccount(BgCtx(), "account-id", "bank-account-id", moov.BankAccountUpdate{HolderName: "Jules Jackson"})
+		require.NoError(t, err)
+		require.Equal(t, "Jules Jackson", account.HolderName)
+		require.Equal(t, moov.HolderTypeIndividual, account.HolderType)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := mc.UpdateBankAccount(BgCtx(), "account-id", "missing-id", moov.BankAccountUpdate{HolderName: "Jules Jackson"})
+		require.ErrorIs(t, err, moov.ErrNoBankAccount)
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		_, err := mc.UpdateBankAccount(BgCtx(), "account-id", "verified-id", moov.BankAccountUpdate{HolderType: "business"})
+		require.ErrorIs(t, err, moov.ErrBankAccountConflict)
+	})
+}
+
+func TestParseBankAccountTypes(t *testing.T) {
+	for _, holderType := range []moov.HolderType{moov.HolderTypeIndividual, moov.HolderTypeBusiness} {
+		parsed, err := moov.ParseHolderType(string(holderType))
+		require.NoError(t, err)
+		require.Equal(t, holderType, parsed)
+	}
+
+	accountTypes := []moov.BankAccountType{
+		moov.BankAccountTypeChecking,
+		moov.BankAccountTypeSavings,
+		moov.BankAccountTypeGeneralLedger,
+		moov.BankAccountTypeLoan,
+	}
+	for _, accountType := range accountTypes {
+		parsed, err := moov.ParseBankAccountType(string(accountType))
+		require.NoError(t, err)
+		require.Equal(t, accountType, parsed)
+	}
+
+	_, err := moov.ParseHolderType("trust")
+	require.ErrorIs(t, err, moov.ErrInvalidHolderType)
+
+	_, err = moov.ParseBankAccountType("brokerage")
+	require.ErrorIs(t, err, moov.ErrInvalidAccountType)
+
+	_, err = moov.ParseBankAccountType("")
+	require.ErrorIs(t, err, moov.ErrInvalidAccountType)
+}
+
+func TestCreateBankAccount_InvalidTypes(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Fail(t, "no request should be made for an invalid bank account")
+	})
+
+	_, err := mc.CreateBankAccount(BgCtx(), "account-id", moov.BankAccount{
+		RoutingNumber:   "273976369",
+		AccountNumber:   "1",
+		HolderType:      moov.HolderTypeIndividual,
+		BankAccountType: "brokerage",
+	})
+	require.ErrorIs(t, err, moov.ErrInvalidAccountType)
+
+	_, err = mc.CreateBankAccount(BgCtx(), "account-id", moov.BankAccount{
+		RoutingNumber:   "273976369",
+		AccountNumber:   "1",
+		HolderType:      "trust",
+		BankAccountType: moov.BankAccountTypeChecking,
+	})
+	require.ErrorIs(t, err, moov.ErrInvalidHolderType)
+}
+
 func TestBankAccountMarshal(t *testing.T) {
 	input := []byte(`{
 		"bankAccountID": "ec7e1848-dc80-4ab0-8827-dd7fc0737b43",
@@ -182,6 +479,6 @@ func (s *BankAccountTestSuite) TestMicroDepositConfirm() {
 
 	// sample data
 	amounts := []int{0, 0}
-	err = mc.MicroDepositConfirm(context.Background(), s.accountID, s.bankAccountID, amounts)
+	_, err = mc.MicroDepositConfirm(context.Background(), s.accountID, s.bankAccountID, amounts)
 	s.NoError(err)
 }
@@ -5,6 +5,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"mime/multipart"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
 )
 
 type CallStatus struct {
@@ -23,6 +29,8 @@ var (
 	StatusCompleted = callStatus("completed", false) // Completely fully
 	StatusStarted   = callStatus("started", true)    // Returned as async. This can be due to timing out, or started as async
 
+	StatusNotModified = callStatus("not_modified", false) // Conditional request for something that hasn't changed
+
 	StatusBadRequest       = callStatus("bad_request", false)       // bad request, body, headers, etc...
 	StatusStateConflict    = callStatus("state_conflict", false)    // violates some stateful constraint.
 	StatusFailedValidation = callStatus("failed_validation", false) // request structure is valid but failed validation.
@@ -39,17 +47,32 @@ var (
 type callBuilder struct {
 	method string
 	path   string
-	params map[string]string
+	params url.Values
+
+	// Method and path template of the call, ie: "GET /transfers/{id}"
+	endpoint string
 
 	headers map[string]string
 	token   *string
 
+	// Always authenticate with the API keys, even when the client uses access tokens
+	basicAuth bool
+
+	// List call that accepts a count, which gets the client's default page size when it isn't set
+	paged bool
+
+	// Leave the body of a successful response unread for the caller to stream
+	streamed bool
+
 	body io.Reader
+
+	// Body is encrypted to the client's encryption key when it has one
+	encrypted bool
 }
 
 func newCall(endpoint EndpointArg, args ...callArg) (*callBuilder, error) {
 	call := &callBuilder{
-		params:  make(map[string]string),
+		params:  url.Values{},
 		headers: make(map[string]string),
 	}
 
@@ -97,6 +120,7 @@ func Endpoint(method string, pathFmt string, args ...any) EndpointArg {
 	return callBuilderFn(func(call *callBuilder) error {
 		call.method = method
 		call.path = fmt.Sprintf(pathFmt, args...)
+		call.endpoint = method + " " + strings.ReplaceAll(pathFmt, "%s", "{id}")
 
 		return nil
 	})
@@ -116,6 +140,104 @@ func JsonBody(body any) callArg {
 	})
 }
 
+// multipartBody sends the fields and files written by fn as a multipart/form-data body
+func multipartBody(fn func(w *multipart.Writer) error) callArg {
+	return callBuilderFn(func(call *callBuilder) error {
+		body := &bytes.Buffer{}
+		w := multipart.NewWriter(body)
+
+		if err := fn(w); err != nil {
+			return err
+		}
+		if err := w.Close(); err != nil {
+			return err
+		}
+
+		call.headers["Content-Type"] = w.FormDataContentType()
+		call.body = body
+
+		return nil
+	})
+}
+
+// multipartStream is like multipartBody but fn writes into the request as it's sent instead of into memory, for
+// bodies too large to buffer. Streamed bodies can't be replayed so the call isn't retried.
+func multipartStream(fn func(w *multipart.Writer) error) callArg {
+	return callBuilderFn(func(call *callBuilder) error {
+		pr, pw := io.Pipe()
+		body := &streamedBody{pr: pr, pw: pw, w: multipart.NewWriter(pw), write: fn}
+
+		call.headers["Content-Type"] = body.w.FormDataContentType()
+		call.body = body
+
+		return nil
+	})
+}
+
+// streamedBody pipes what write writes into the request body. Writing starts on the first read so nothing is left
+// blocked on the pipe when the request is never sent, and closing the body stops the writer.
+type streamedBody struct {
+	once  sync.Once
+	pr    *io.PipeReader
+	pw    *io.PipeWriter
+	w     *multipart.Writer
+	write func(w *multipart.Writer) error
+}
+
+func (b *streamedBody) Read(p []byte) (int, error) {
+	b.once.Do(func() {
+		go func() {
+			err := b.write(b.w)
+			if err == nil {
+				err = b.w.Close()
+			}
+			b.pw.CloseWithError(err)
+		}()
+	})
+	return b.pr.Read(p)
+}
+
+func (b *streamedBody) Close() error {
+	return b.pr.Close()
+}
+
+// paged marks a list call as taking a count, so the client's default page size is sent when the call doesn't set one
+func paged() callArg {
+	return callBuilderFn(func(call *callBuilder) error {
+		call.paged = true
+		return nil
+	})
+}
+
+// streamed leaves the body of a successful response unread so it can be streamed from responseStream instead of being
+// read into memory, for downloads
+func streamed() callArg {
+	return callBuilderFn(func(call *callBuilder) error {
+		call.streamed = true
+		return nil
+	})
+}
+
+// QueryParam adds the key and value onto the query string of the request
+func QueryParam(key string, value string) callArg {
+	return callBuilderFn(func(call *callBuilder) error {
+		call.params.Add(key, value)
+		return nil
+	})
+}
+
+// QueryParams adds all the values onto the query string of the request, keeping repeated keys
+func QueryParams(values url.Values) callArg {
+	return callBuilderFn(func(call *callBuilder) error {
+		for k, vs := range values {
+			for _, v := range vs {
+				call.params.Add(k, v)
+			}
+		}
+		return nil
+	})
+}
+
 func AcceptJson() callArg {
 	return callBuilderFn(func(call *callBuilder) error {
 		call.headers["Accept"] = "application/json"
@@ -130,17 +252,46 @@ func WaitFor(state string) callArg {
 	})
 }
 
+// IdempotencyKey sets the key the server uses to recognize a request that is sent again, ie: retrying after a timeout,
+// so the request is only processed once.
+func IdempotencyKey(key string) callArg {
+	return callBuilderFn(func(call *callBuilder) error {
+		call.headers["X-Idempotency-Key"] = key
+		return nil
+	})
+}
+
+// IfNoneMatch makes the request conditional on the item having changed since it was retrieved with the ETag
+func IfNoneMatch(etag string) callArg {
+	return callBuilderFn(func(call *callBuilder) error {
+		call.headers["If-None-Match"] = etag
+		return nil
+	})
+}
+
+// withBasicAuth authenticates the call with the API keys instead of the client's access token
+func withBasicAuth() callArg {
+	return callBuilderFn(func(call *callBuilder) error {
+		call.basicAuth = true
+		return nil
+	})
+}
+
 // Response
 
 type CallResponse interface {
 	Status() CallStatus
 
 	// Deserializes the body of the response into the item.
-	// This is here so the response can handle any content type.
+	// This is here so the response can handle any content type. Any type can be decoded into, including ones
+	// defined outside this package, and *string or *[]byte get the raw body.
 	Unmarshal(item any) error
 
 	// Convert response into an golang error
 	Error() error
+
+	// Value of the response header, empty when it wasn't sent
+	Header(name string) string
 }
 
 func UnmarshalObjectResponse[A interface{}](resp CallResponse) (*A, error) {
@@ -171,22 +322,76 @@ func CompletedNilOrError(resp CallResponse) error {
 	}
 }
 
-// Helper for a common pattern of successful API calls returning an object body or an error
+// Helper for a common pattern of successful API calls returning an object body or an error. ErrCallStarted is
+// returned when the call was accepted without the object being ready.
 func CompletedObjectOrError[A interface{}](resp CallResponse) (*A, error) {
 	switch resp.Status() {
 	case StatusCompleted:
 		return UnmarshalObjectResponse[A](resp)
+	case StatusStarted:
+		return nil, ErrCallStarted
 	default:
 		return nil, resp.Error()
 	}
 }
 
-// Helper for a common pattern of successful API calls returning a body with a slice of objects or an error
+// Helper for a common pattern of successful API calls returning a body with a slice of objects or an error.
+// ErrCallStarted is returned when the call was accepted without the list being ready.
 func CompletedListOrError[A interface{}](resp CallResponse) ([]A, error) {
 	switch resp.Status() {
 	case StatusCompleted:
 		return UnmarshalListResponse[A](resp)
+	case StatusStarted:
+		return nil, ErrCallStarted
 	default:
 		return nil, resp.Error()
 	}
 }
+
+// TotalCount returns the total number of items a list call would return across all pages from the X-Total-Count
+// header, or -1 when the header is missing or invalid.
+func TotalCount(resp CallResponse) int {
+	total, err := strconv.Atoi(resp.Header("X-Total-Count"))
+	if err != nil || total < 0 {
+		return -1
+	}
+	return total
+}
+
+// unknownFields returns the top level fields in data that don't map to a json field of the struct v points to.
+func unknownFields(data []byte, v any) (map[string]json.RawMessage, error) {
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	typ := reflect.TypeOf(v)
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		// encoding/json matches field names case-insensitively so do the same here
+		for key := range fields {
+			if strings.EqualFold(key, name) {
+				delete(fields, key)
+			}
+		}
+	}
+
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	return fields, nil
+}
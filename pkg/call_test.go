@@ -0,0 +1,122 @@
+package moov_test
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	moov "github.com/moovfinancial/moov-go/pkg"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryParams(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/ping", r.URL.Path)
+		require.Equal(t, "a=1&a=2&b=hello+world&c=%26&d=3&d=4", r.URL.RawQuery)
+
+		query := r.URL.Query()
+		require.Equal(t, []string{"1", "2"}, query["a"])
+		require.Equal(t, []string{"3", "4"}, query["d"])
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	resp, err := mc.CallHttp(BgCtx(),
+		moov.Endpoint(http.MethodGet, "/ping"),
+		moov.QueryParam("a", "1"),
+		moov.QueryParam("a", "2"),
+		moov.QueryParams(url.Values{
+			"b": {"hello world"},
+			"c": {"&"},
+			"d": {"3", "4"},
+		}))
+	require.NoError(t, err)
+	require.NoError(t, moov.CompletedNilOrError(resp))
+}
+
+func TestAPIError(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-ID", "request-id")
+		WriteJson(w, http.StatusTeapot, `{"error":"short and stout"}`)
+	})
+
+	err := mc.Ping(BgCtx())
+
+	var apiErr *moov.APIError
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, http.StatusTeapot, apiErr.StatusCode())
+	require.Equal(t, "request-id", apiErr.RequestId())
+	require.Equal(t, "short and stout", apiErr.Message)
+	require.JSONEq(t, `{"error":"short and stout"}`, string(apiErr.Body))
+
+	_, err = mc.GetTransfer(BgCtx(), "transfer-id", "account-id")
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, http.StatusTeapot, apiErr.StatusCode())
+	require.Equal(t, "short and stout", apiErr.Message)
+}
+
+func TestCompletedOrError_Started(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		WriteJson(w, http.StatusAccepted, `{}`)
+	})
+
+	resp, err := mc.CallHttp(BgCtx(), moov.Endpoint(http.MethodGet, "/ping"), moov.AcceptJson())
+	require.NoError(t, err)
+
+	item, err := moov.CompletedObjectOrError[string](resp)
+	require.ErrorIs(t, err, moov.ErrCallStarted)
+	require.Nil(t, item)
+
+	_, err = moov.CompletedListOrError[string](resp)
+	require.ErrorIs(t, err, moov.ErrCallStarted)
+}
+
+func TestAPIError_RateLimit(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		WriteJson(w, http.StatusTooManyRequests, `{"error":"slow down"}`)
+	})
+
+	err := mc.Ping(BgCtx())
+	require.ErrorIs(t, err, moov.ErrRateLimit)
+
+	var apiErr *moov.APIError
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, http.StatusTooManyRequests, apiErr.StatusCode())
+
+	require.NotErrorIs(t, moov.ErrDefault(http.StatusTeapot), moov.ErrRateLimit)
+}
+
+func TestErrDefault(t *testing.T) {
+	var apiErr *moov.APIError
+	require.ErrorAs(t, moov.ErrDefault(http.StatusTeapot), &apiErr)
+	require.Equal(t, http.StatusTeapot, apiErr.StatusCode())
+	require.Empty(t, apiErr.Body)
+}
+
+func TestDo(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/accounts/account-id/widgets", r.URL.Path)
+		require.Equal(t, "blue", r.URL.Query().Get("color"))
+		require.NotEmpty(t, r.Header.Get("Authorization"))
+
+		WriteJson(w, http.StatusOK, `{"widgetID":"widget-id","size":3}`)
+	})
+
+	type widget struct {
+		WidgetID string `json:"widgetID"`
+		Size     int    `json:"size"`
+	}
+
+	resp, err := mc.Do(BgCtx(),
+		moov.Endpoint(http.MethodPost, "/accounts/%s/widgets", "account-id"),
+		moov.AcceptJson(),
+		moov.QueryParam("color", "blue"),
+		moov.JsonBody(widget{Size: 3}))
+	require.NoError(t, err)
+	require.Equal(t, moov.StatusCompleted, resp.Status())
+
+	var w widget
+	require.NoError(t, resp.Unmarshal(&w))
+	require.Equal(t, widget{WidgetID: "widget-id", Size: 3}, w)
+}
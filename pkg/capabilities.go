@@ -1,6 +1,10 @@
 package moov
 
-import "time"
+import (
+	"context"
+	"net/http"
+	"time"
+)
 
 const (
 	CAPABILITY_TRANSFERS     = "transfers"
@@ -16,21 +20,71 @@ const (
 // Capabilities a list of CAPABILITY_*
 var Capabilities []string
 
-type Captability struct {
-	Capability   string `json:"capability"`
-	AccountID    string `json:"accountID"`
-	Status       string `json:"status,omitempty"`
-	Requirements struct {
-		CurrentlyDue []string `json:"currentlyDue,omitempty"`
-		Errors       []struct {
-			Requirement string `json:"requirement,omitempty"`
-			ErrorCode   string `json:"errorCode,omitempty"`
-		}
+// CapabilityName is the name of a capability, one of CAPABILITY_TRANSFERS, CAPABILITY_SEND_FUNDS,
+// CAPABILITY_COLLECT_FUNDS, CAPABILITY_WALLET or CAPABILITY_CARD_ISSUING
+type CapabilityName string
+
+type Capability struct {
+	Capability     CapabilityName         `json:"capability"`
+	AccountID      string                 `json:"accountID"`
+	Status         string                 `json:"status,omitempty"`
+	Requirements   CapabilityRequirements `json:"requirements,omitempty"`
+	DisabledReason string                 `json:"disabledReason,omitempty"`
+	CreatedOn      time.Time              `json:"createdOn,omitempty"`
+	UpdatedOn      time.Time              `json:"updatedOn,omitempty"`
+	DisabledOn     time.Time              `json:"disabledOn,omitempty"`
+}
+
+// Captability is the previous, misspelled, name of Capability
+//
+// Deprecated: use Capability
+type Captability = Capability
+
+// CapabilityRequirements lists the information still needed before a capability can be enabled
+type CapabilityRequirements struct {
+	CurrentlyDue []string           `json:"currentlyDue,omitempty"`
+	Errors       []RequirementError `json:"errors,omitempty"`
+}
+
+type RequirementError struct {
+	Requirement string `json:"requirement,omitempty"`
+	ErrorCode   string `json:"errorCode,omitempty"`
+}
+
+// RequestCapabilities requests capabilities for an account, returning all of the account's capabilities
+// https://docs.moov.io/api/moov-accounts/capabilities/post/
+func (c Client) RequestCapabilities(ctx context.Context, accountID string, caps []CapabilityName) ([]Capability, error) {
+	resp, err := c.CallHttp(ctx,
+		Endpoint(http.MethodPost, pathCapabilities, accountID),
+		AcceptJson(),
+		JsonBody(map[string][]CapabilityName{"capabilities": caps}))
+	if err != nil {
+		return nil, err
 	}
-	DisabledReason string    `json:"disabledReason,omitempty"`
-	CreatedOn      time.Time `json:"createdOn,omitempty"`
-	UpdatedOn      time.Time `json:"updatedOn,omitempty"`
-	DisabledOn     time.Time `json:"disabledOn,omitempty"`
+
+	return CompletedListOrError[Capability](resp)
+}
+
+// ListCapabilities retrieves the capabilities requested for an account along with their requirements
+// https://docs.moov.io/api/moov-accounts/capabilities/list/
+func (c Client) ListCapabilities(ctx context.Context, accountID string) ([]Capability, error) {
+	resp, err := c.CallHttp(ctx,
+		Endpoint(http.MethodGet, pathCapabilities, accountID),
+		AcceptJson())
+	if err != nil {
+		return nil, err
+	}
+
+	return CompletedListOrError[Capability](resp)
 }
 
-// @todo need to add in requesting capabilities...
+// DisableCapability disables a capability of the account
+// https://docs.moov.io/api/moov-accounts/capabilities/delete/
+func (c Client) DisableCapability(ctx context.Context, accountID string, capability CapabilityName) error {
+	resp, err := c.CallHttp(ctx, Endpoint(http.MethodDelete, pathCapability, accountID, capability))
+	if err != nil {
+		return err
+	}
+
+	return CompletedNilOrError(resp)
+}
@@ -0,0 +1,77 @@
+package moov_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	moov "github.com/moovfinancial/moov-go/pkg"
+	"github.com/stretchr/testify/require"
+)
+
+const capabilitiesResponse = `[
+	{
+		"capability": "transfers",
+		"accountID": "account-id",
+		"status": "pending",
+		"requirements": {
+			"currentlyDue": ["individual.ssn", "individual.birthdate"],
+			"errors": [{"requirement": "individual.address", "errorCode": "invalid-value"}]
+		},
+		"createdOn": "2024-01-02T03:04:05Z"
+	},
+	{
+		"capability": "wallet",
+		"accountID": "account-id",
+		"status": "disabled",
+		"disabledReason": "requested by account"
+	}
+]`
+
+func TestRequestCapabilities(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/accounts/account-id/capabilities", r.URL.Path)
+
+		body := map[string][]string{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.Equal(t, map[string][]string{"capabilities": {"transfers", "wallet"}}, body)
+
+		WriteJson(w, http.StatusOK, capabilitiesResponse)
+	})
+
+	caps, err := mc.RequestCapabilities(BgCtx(), "account-id", []moov.CapabilityName{moov.CAPABILITY_TRANSFERS, moov.CAPABILITY_WALLET})
+	require.NoError(t, err)
+	require.Len(t, caps, 2)
+
+	require.Equal(t, moov.CapabilityName(moov.CAPABILITY_TRANSFERS), caps[0].Capability)
+	require.Equal(t, moov.CAPABILITY_PENDING, caps[0].Status)
+	require.Equal(t, []string{"individual.ssn", "individual.birthdate"}, caps[0].Requirements.CurrentlyDue)
+	require.Equal(t, []moov.RequirementError{{Requirement: "individual.address", ErrorCode: "invalid-value"}}, caps[0].Requirements.Errors)
+
+	require.Equal(t, moov.CAPABILITY_DISABLED, caps[1].Status)
+	require.Equal(t, "requested by account", caps[1].DisabledReason)
+}
+
+func TestListCapabilities(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		require.Equal(t, "/accounts/account-id/capabilities", r.URL.Path)
+		WriteJson(w, http.StatusOK, capabilitiesResponse)
+	})
+
+	caps, err := mc.ListCapabilities(BgCtx(), "account-id")
+	require.NoError(t, err)
+	require.Len(t, caps, 2)
+	require.Len(t, caps[0].Requirements.CurrentlyDue, 2)
+}
+
+func TestDisableCapability(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodDelete, r.Method)
+		require.Equal(t, "/accounts/account-id/capabilities/wallet", r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	require.NoError(t, mc.DisableCapability(BgCtx(), "account-id", moov.CAPABILITY_WALLET))
+}
@@ -2,6 +2,7 @@ package moov
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"net/http"
 	"time"
@@ -10,6 +11,7 @@ import (
 var (
 	ErrNoCardUpdateFilters = errors.New("no card update filters provided")
 	ErrUpdateCardConflict  = errors.New("attempting to update an existing disabled card")
+	ErrInvalidBIN          = errors.New("card BIN must be 6 to 8 digits")
 )
 
 type Card struct {
@@ -31,6 +33,50 @@ type Card struct {
 	DomesticPushToCard string             `json:"domesticPushToCard,omitempty"`
 }
 
+// PushToCardEligible reports if funds can be pushed to the card, ie: for instant payouts to a debit card
+func (c Card) PushToCardEligible() bool {
+	return c.DomesticPushToCard == "standard" || c.DomesticPushToCard == "fast-funds"
+}
+
+// BINInfo is what's known about a card from its bank identification number
+type BINInfo struct {
+	Bin           string `json:"bin,omitempty"`
+	Issuer        string `json:"issuer,omitempty"`
+	IssuerCountry string `json:"issuerCountry,omitempty"`
+	// Funding type, ie: debit, credit or prepaid
+	CardType string `json:"cardType,omitempty"`
+	// Card network, ie: Visa or Mastercard
+	Brand string `json:"brand,omitempty"`
+}
+
+// BINInfo returns the BIN derived details of a linked card, which Moov sets when the card is created as there's no
+// separate BIN lookup.
+func (c Card) BINInfo() (*BINInfo, error) {
+	if err := validateBIN(c.Bin); err != nil {
+		return nil, err
+	}
+
+	return &BINInfo{
+		Bin:           c.Bin,
+		Issuer:        c.Issuer,
+		IssuerCountry: c.IssuerCountry,
+		CardType:      c.CardType,
+		Brand:         c.Brand,
+	}, nil
+}
+
+func validateBIN(bin string) error {
+	if len(bin) < 6 || len(bin) > 8 {
+		return ErrInvalidBIN
+	}
+	for _, r := range bin {
+		if r < '0' || r > '9' {
+			return ErrInvalidBIN
+		}
+	}
+	return nil
+}
+
 type Expiration struct {
 	Month string `json:"month,omitempty"`
 	Year  string `json:"year,omitempty"`
@@ -47,6 +93,17 @@ type CardAccountUpdater struct {
 	UpdateType string    `json:"updateType,omitempty"`
 }
 
+// MarshalJSON leaves out the updated time when the card hasn't been updated
+func (u CardAccountUpdater) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		UpdatedOn  *time.Time `json:"updatedOn,omitempty"`
+		UpdateType string     `json:"updateType,omitempty"`
+	}{
+		UpdatedOn:  timeOrNil(u.UpdatedOn),
+		UpdateType: u.UpdateType,
+	})
+}
+
 type CardDetails struct {
 	Status                   string            `json:"status,omitempty"`
 	FailureCode              string            `json:"failureCode,omitempty"`
@@ -65,6 +122,25 @@ type CardStatusUpdates struct {
 	Completed time.Time `json:"completed,omitempty"`
 }
 
+// MarshalJSON leaves out the status updates that haven't happened
+func (u CardStatusUpdates) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Initiated *time.Time `json:"initiated,omitempty"`
+		Confirmed *time.Time `json:"confirmed,omitempty"`
+		Settled   *time.Time `json:"settled,omitempty"`
+		Failed    *time.Time `json:"failed,omitempty"`
+		Canceled  *time.Time `json:"canceled,omitempty"`
+		Completed *time.Time `json:"completed,omitempty"`
+	}{
+		Initiated: timeOrNil(u.Initiated),
+		Confirmed: timeOrNil(u.Confirmed),
+		Settled:   timeOrNil(u.Settled),
+		Failed:    timeOrNil(u.Failed),
+		Canceled:  timeOrNil(u.Canceled),
+		Completed: timeOrNil(u.Completed),
+	})
+}
+
 type CreateCard struct {
 	CardNumber        string     `json:"cardNumber,omitempty"`
 	CardCvv           string     `json:"cardCvv,omitempty"`
@@ -78,7 +154,7 @@ type CreateCard struct {
 // CreateCard creates a new card for the given customer linked to their account
 // https://docs.moov.io/api/#tag/Cards/operation/card
 func (c Client) CreateCard(ctx context.Context, accountID string, card CreateCard) (*Card, error) {
-	resp, err := c.CallHttp(ctx, Endpoint(http.MethodPost, pathCards, accountID), AcceptJson(), JsonBody(card), WaitFor("payment-method"))
+	resp, err := c.CallHttp(ctx, Endpoint(http.MethodPost, pathCards, accountID), AcceptJson(), EncryptedJsonBody(card), WaitFor("payment-method"))
 	if err != nil {
 		return nil, err
 	}
@@ -90,6 +166,8 @@ func (c Client) CreateCard(ctx context.Context, accountID string, card CreateCar
 		return nil, ErrNoAccount
 	case StatusStateConflict:
 		return nil, ErrDuplicateLinkCard
+	case StatusFailedValidation:
+		return nil, ErrCardDataInvalid
 	default:
 		return nil, resp.Error()
 	}
@@ -109,7 +187,7 @@ func (c Client) ListCards(ctx context.Context, accountID string) ([]Card, error)
 // GetCard retrieves a card for the given customer Moov account
 // https://docs.moov.io/api/#tag/Cards/operation/getCard
 func (c Client) GetCard(ctx context.Context, accountID string, cardID string) (*Card, error) {
-	resp, err := c.CallHttp(ctx, Endpoint(http.MethodGet, "/accounts/%s/cards/%s", accountID, cardID), AcceptJson())
+	resp, err := c.CallHttp(ctx, Endpoint(http.MethodGet, pathCard, accountID, cardID), AcceptJson())
 	if err != nil {
 		return nil, err
 	}
@@ -180,7 +258,7 @@ func (c Client) UpdateCard(ctx context.Context, accountID string, cardID string,
 		return nil, err
 	}
 
-	resp, err := c.CallHttp(ctx, Endpoint("/accounts/%s/cards/%s", accountID, cardID), AcceptJson(), JsonBody(payload))
+	resp, err := c.CallHttp(ctx, Endpoint(http.MethodPatch, pathCard, accountID, cardID), AcceptJson(), EncryptedJsonBody(payload))
 	if err != nil {
 		return nil, err
 	}
@@ -200,7 +278,7 @@ func (c Client) UpdateCard(ctx context.Context, accountID string, cardID string,
 // DisableCard disables a card associated with a Moov account
 // https://docs.moov.io/api/#tag/Cards/operation/deleteCard
 func (c Client) DisableCard(ctx context.Context, accountID string, cardID string) error {
-	resp, err := c.CallHttp(ctx, Endpoint(http.MethodDelete, "/accounts/%s/cards/%s", accountID, cardID))
+	resp, err := c.CallHttp(ctx, Endpoint(http.MethodDelete, pathCard, accountID, cardID))
 	if err != nil {
 		return err
 	}
@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"net/http"
 	"testing"
 
 	moov "github.com/moovfinancial/moov-go/pkg"
@@ -64,6 +65,80 @@ func TestCardMarshal(t *testing.T) {
 	assert.Equal(t, "ec7e1848-dc80-4ab0-8827-dd7fc0737b43", card.CardID)
 }
 
+func TestCardBINInfo(t *testing.T) {
+	card := moov.Card{Bin: "411111", Brand: "Visa", CardType: "debit", Issuer: "GRINGOTTS BANK", IssuerCountry: "US"}
+
+	info, err := card.BINInfo()
+	require.NoError(t, err)
+	require.Equal(t, &moov.BINInfo{Bin: "411111", Issuer: "GRINGOTTS BANK", IssuerCountry: "US", CardType: "debit", Brand: "Visa"}, info)
+
+	for _, bin := range []string{"", "41111", "411111111", "4111a1"} {
+		_, err := moov.Card{Bin: bin}.BINInfo()
+		require.ErrorIs(t, err, moov.ErrInvalidBIN, bin)
+	}
+}
+
+func TestCreateCard_Tokenized(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/accounts/account-id/cards", r.URL.Path)
+		require.Equal(t, "payment-method", r.Header.Get("X-Wait-For"))
+
+		card := moov.CreateCard{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&card))
+		require.Equal(t, "4111111111111111", card.CardNumber)
+		require.Equal(t, "123", card.CardCvv)
+		require.Equal(t, "80301", card.BillingAddress.PostalCode)
+
+		WriteJson(w, http.StatusOK, `{"cardID": "card-id", "brand": "Visa", "lastFourCardNumber": "1111", "cardVerification": {"cvv": "match"}}`)
+	})
+
+	card, err := mc.CreateCard(BgCtx(), "account-id", moov.CreateCard{
+		CardNumber:     "4111111111111111",
+		CardCvv:        "123",
+		Expiration:     moov.Expiration{Month: "01", Year: "30"},
+		HolderName:     "Jules Jackson",
+		BillingAddress: moov.Address{PostalCode: "80301"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "card-id", card.CardID)
+	require.Equal(t, "1111", card.LastFourCardNumber)
+	require.Equal(t, "match", card.CardVerification.Cvv)
+}
+
+func TestCreateCard_Errors(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		card := moov.CreateCard{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&card))
+
+		switch card.CardNumber {
+		case "4000000000000002":
+			WriteJson(w, http.StatusUnprocessableEntity, `{"error": "card declined"}`)
+		default:
+			WriteJson(w, http.StatusConflict, `{"error": "card already exists"}`)
+		}
+	})
+
+	_, err := mc.CreateCard(BgCtx(), "account-id", moov.CreateCard{CardNumber: "4000000000000002"})
+	require.ErrorIs(t, err, moov.ErrCardDataInvalid)
+
+	_, err = mc.CreateCard(BgCtx(), "account-id", moov.CreateCard{CardNumber: "4111111111111111"})
+	require.ErrorIs(t, err, moov.ErrDuplicateLinkCard)
+}
+
+func TestUpdateCard_Patch(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPatch, r.Method)
+		require.Equal(t, "/accounts/account-id/cards/card-id", r.URL.Path)
+
+		WriteJson(w, http.StatusOK, `{"cardID": "card-id", "expiration": {"month": "02", "year": "31"}}`)
+	})
+
+	card, err := mc.UpdateCard(BgCtx(), "account-id", "card-id", moov.WithCardExpiration(moov.Expiration{Month: "02", Year: "31"}))
+	require.NoError(t, err)
+	require.Equal(t, "31", card.Expiration.Year)
+}
+
 type CardTestSuite struct {
 	suite.Suite
 	accountID    string
@@ -1,28 +1,66 @@
 package moov
 
 import (
+	"crypto/rsa"
 	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
+	"net/url"
+	"strings"
+	"time"
 )
 
 const (
-	baseURL              = "https://api.moov.io"
-	pathBankAccounts     = "/accounts/%s/bank-accounts"
-	pathMicroDeposits    = "/accounts/%s/bank-accounts/%s/microdeposits"
-	pathCards            = "/accounts/%s/cards"
-	pathApplePay         = "/accounts/%s/apple-pay"
-	pathApplePayDomains  = "/accounts/%s/apple-pay/domains"
-	pathApplePaySessions = "/accounts/%s/apple-pay/sessions"
-	pathApplePayTokens   = "/accounts/%s/apple-pay/tokens"
-	pathPaymentMethods   = "/accounts/%s/payment-methods"
-	pathWallets          = "/accounts/%s/wallets"
-	pathWalletTrans      = "/accounts/%s/wallets/%s/transactions"
-	pathTransactions     = "/accounts/%s/transactions"
-	pathTransfers        = "/transfers"
-	pathTransferOptions  = "/transfer-options"
-	pathDisputes         = "/disputes"
-	pathDisputeID        = "/disputes/%s"
+	pathBankAccounts        = "/accounts/%s/bank-accounts"
+	pathBankAccount         = "/accounts/%s/bank-accounts/%s"
+	pathMicroDeposits       = "/accounts/%s/bank-accounts/%s/microdeposits"
+	pathCards               = "/accounts/%s/cards"
+	pathCard                = "/accounts/%s/cards/%s"
+	pathCapabilities        = "/accounts/%s/capabilities"
+	pathCapability          = "/accounts/%s/capabilities/%s"
+	pathRepresentatives     = "/accounts/%s/representatives"
+	pathRepresentative      = "/accounts/%s/representatives/%s"
+	pathApplePay            = "/accounts/%s/apple-pay"
+	pathApplePayDomains     = "/accounts/%s/apple-pay/domains"
+	pathApplePaySessions    = "/accounts/%s/apple-pay/sessions"
+	pathApplePayTokens      = "/accounts/%s/apple-pay/tokens"
+	pathPaymentMethods      = "/accounts/%s/payment-methods"
+	pathPaymentMethod       = "/accounts/%s/payment-methods/%s"
+	pathWallets             = "/accounts/%s/wallets"
+	pathWallet              = "/accounts/%s/wallets/%s"
+	pathWalletTrans         = "/accounts/%s/wallets/%s/transactions"
+	pathWalletTran          = "/accounts/%s/wallets/%s/transactions/%s"
+	pathTransactions        = "/accounts/%s/transactions"
+	pathTransfers           = "/transfers"
+	pathTransfer            = "/transfers/%s"
+	pathTransferRefunds     = "/transfers/%s/refunds"
+	pathTransferRefund      = "/transfers/%s/refunds/%s"
+	pathTransferReversal    = "/transfers/%s/reversals"
+	pathTransferCancels     = "/accounts/%s/transfers/%s/cancellations"
+	pathTransferOptions     = "/transfer-options"
+	pathDisputes            = "/disputes"
+	pathDisputeID           = "/disputes/%s"
+	pathDisputeMessages     = "/disputes/%s/messages"
+	pathDisputeAccept       = "/accounts/%s/disputes/%s/accept"
+	pathEvidence            = "/accounts/%s/disputes/%s/evidence"
+	pathEvidenceData        = "/accounts/%s/disputes/%s/evidence/%s/data"
+	pathEvidenceText        = "/accounts/%s/disputes/%s/evidence-text"
+	pathEvidenceFile        = "/accounts/%s/disputes/%s/evidence-file"
+	pathEvidenceSubmit      = "/accounts/%s/disputes/%s/evidence/submit"
+	pathInstitutions        = "/institutions"
+	pathFiles               = "/accounts/%s/files"
+	pathSweepConfigs        = "/accounts/%s/sweep-configs"
+	pathSweepConfig         = "/accounts/%s/sweep-configs/%s"
+	pathSweeps              = "/accounts/%s/wallets/%s/sweeps"
+	pathFile                = "/accounts/%s/files/%s"
+	pathSchedules           = "/accounts/%s/schedules"
+	pathSchedule            = "/accounts/%s/schedules/%s"
+	pathOccurrence          = "/accounts/%s/schedules/%s/occurrences/%s"
+	pathFeePlans            = "/fee-plans"
+	pathFeePlanAgreements   = "/accounts/%s/fee-plan-agreements"
+	pathUnderwriting        = "/accounts/%s/underwriting"
+	pathTermsOfServiceToken = "/tos-token"
 )
 
 var (
@@ -42,15 +80,53 @@ var (
 	ErrRateLimit                = errors.New("request was refused due to rate limiting")
 	ErrXIdempotencyKey          = errors.New("attempted to create a transfer using a duplicate X-Idempotency-Key header")
 	ErrURL                      = errors.New("invalid URL")
+	ErrCallStarted              = errors.New("the request was accepted but hasn't completed yet")
 )
 
+// ErrDefault returns an *APIError for an unexpected status code, use errors.As to inspect it.
 func ErrDefault(code int) error {
-	return fmt.Errorf("empty response for unauthorized or any other returned http status code (%d)", code)
+	return newAPIError(code, "", nil)
 }
 
 type Client struct {
 	Credentials Credentials
 	HttpClient  *http.Client
+
+	// Currencies transfers are restricted to, all currencies are allowed when empty
+	allowedCurrencies map[string]bool
+
+	// How failed calls are retried, calls aren't retried by default
+	retry retryPolicy
+
+	// accountID sent with transfer reads when the call doesn't specify one
+	transferAccountID string
+
+	// Whether synchronous transfer calls answered asynchronously return an error instead of the async result
+	asyncAsError bool
+
+	// Observers of the requests and responses made by CallHttp
+	hooks hooks
+
+	// Access tokens calls are authenticated with instead of the API keys, nil when using the API keys
+	tokens *tokenSource
+
+	// URL calls are made to instead of the credentials' host, empty to use the host
+	baseURL string
+
+	// Count sent with list calls that don't set one, Moov's default is used when 0
+	defaultPageSize int
+
+	// Longest a single request can take, requests are only bounded by their context when 0
+	timeout time.Duration
+
+	// Where calls are logged, nothing is logged when nil
+	logger *slog.Logger
+
+	// Told about every request made, nothing is recorded when nil
+	metrics MetricsRecorder
+
+	// Key bodies sent with EncryptedJsonBody are encrypted to, they're sent as plain JSON when nil
+	encryptionKey *rsa.PublicKey
 }
 
 func NewClient(configurables ...ClientConfigurable) (*Client, error) {
@@ -75,6 +151,16 @@ func NewClient(configurables ...ClientConfigurable) (*Client, error) {
 	return client, nil
 }
 
+// NewClientWithCredentials creates a client authenticating with the given API keys instead of the ones in the
+// environment, ie: for servers working with several sets of keys. Both keys are required.
+func NewClientWithCredentials(publicKey string, secretKey string, configurables ...ClientConfigurable) (*Client, error) {
+	credentials := CredentialsFromEnv()
+	credentials.PublicKey = publicKey
+	credentials.SecretKey = secretKey
+
+	return NewClient(append([]ClientConfigurable{WithCredentials(credentials)}, configurables...)...)
+}
+
 type ClientConfigurable func(c *Client) error
 
 func WithCredentials(credentials Credentials) ClientConfigurable {
@@ -84,9 +170,92 @@ func WithCredentials(credentials Credentials) ClientConfigurable {
 	}
 }
 
+// WithHttpClient sets the http client all calls are made with, to control timeouts, proxies, TLS or the transport.
+// The default client is used when nil.
 func WithHttpClient(client *http.Client) ClientConfigurable {
 	return func(c *Client) error {
+		if client == nil {
+			client = DefaultHttpClient()
+		}
 		c.HttpClient = client
 		return nil
 	}
 }
+
+// WithBaseURL sends every call to the given URL instead of the credentials' host, ie: a local mock of Moov in tests.
+// It takes precedence over the host no matter the order the options are given in.
+func WithBaseURL(baseURL string) ClientConfigurable {
+	return func(c *Client) error {
+		u, err := url.Parse(baseURL)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrURL, err)
+		}
+		if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%w: %q needs an http or https scheme and a host", ErrURL, baseURL)
+		}
+
+		c.baseURL = baseURL
+		return nil
+	}
+}
+
+// WithDefaultPageSize sets the count sent with list calls, like ListTransfers or ListDisputes, when the call doesn't
+// set one itself so pages are a predictable size. By default Moov's page size is used.
+func WithDefaultPageSize(n int) ClientConfigurable {
+	return func(c *Client) error {
+		if n < 0 {
+			return errors.New("default page size must not be negative")
+		}
+		c.defaultPageSize = n
+		return nil
+	}
+}
+
+// WithTimeout bounds every request made by the client to the duration without the caller having to set a deadline on
+// each context. A sooner deadline on the caller's context still applies, and each retry gets the full duration.
+func WithTimeout(timeout time.Duration) ClientConfigurable {
+	return func(c *Client) error {
+		if timeout < 0 {
+			return errors.New("timeout must not be negative")
+		}
+		c.timeout = timeout
+		return nil
+	}
+}
+
+// WithAllowedCurrencies restricts the currencies transfers can be created in to guard against accidental
+// cross-currency transfers. By default all currencies are allowed.
+func WithAllowedCurrencies(codes ...string) ClientConfigurable {
+	return func(c *Client) error {
+		c.allowedCurrencies = make(map[string]bool, len(codes))
+		for _, code := range codes {
+			c.allowedCurrencies[strings.ToUpper(code)] = true
+		}
+		return nil
+	}
+}
+
+// WithTransferAccountContext sets the accountID that transfer and refund reads are made on behalf of, which a
+// facilitator otherwise has to pass on every call. An accountID passed to a call takes precedence.
+func WithTransferAccountContext(accountID string) ClientConfigurable {
+	return func(c *Client) error {
+		c.transferAccountID = accountID
+		return nil
+	}
+}
+
+// WithAsyncAsError makes CreateTransfer and RefundTransfer return an UnexpectedAsyncError when a synchronous response
+// was requested but the transfer is still being processed. By default the asynchronous result is returned instead.
+func WithAsyncAsError() ClientConfigurable {
+	return func(c *Client) error {
+		c.asyncAsError = true
+		return nil
+	}
+}
+
+func (c *Client) currencyAllowed(currency string) bool {
+	if len(c.allowedCurrencies) == 0 {
+		return true
+	}
+	return c.allowedCurrencies[strings.ToUpper(currency)]
+}
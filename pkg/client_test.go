@@ -2,8 +2,13 @@ package moov_test
 
 import (
 	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	moov "github.com/moovfinancial/moov-go/pkg"
 	"github.com/stretchr/testify/require"
@@ -31,6 +36,43 @@ func NewTestClient(t require.TestingT, c ...moov.ClientConfigurable) *moov.Clien
 	return mc
 }
 
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (fn roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return fn(req)
+}
+
+// NewMockClient creates a client whose requests are served in-memory by the handler instead of the Moov API
+func NewMockClient(t require.TestingT, handler http.HandlerFunc, c ...moov.ClientConfigurable) *moov.Client {
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		rec := httptest.NewRecorder()
+		handler(rec, req)
+
+		// like a real transport, fail requests whose context ended while being served
+		if err := req.Context().Err(); err != nil {
+			return nil, err
+		}
+		return rec.Result(), nil
+	})
+
+	configurables := []moov.ClientConfigurable{
+		moov.WithCredentials(moov.Credentials{PublicKey: "public", SecretKey: "secret", Host: "api.moov.io"}),
+		moov.WithHttpClient(&http.Client{Transport: transport}),
+	}
+
+	mc, err := moov.NewClient(append(configurables, c...)...)
+	require.NoError(t, err)
+
+	return mc
+}
+
+// WriteJson writes a json response body with the given status code from a mock handler
+func WriteJson(w http.ResponseWriter, statusCode int, body string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_, _ = w.Write([]byte(body))
+}
+
 func Test_Client(t *testing.T) {
 	mc := NewTestClient(t)
 
@@ -42,3 +84,214 @@ func Test_Client_InvalidCredentials(t *testing.T) {
 	_, err := moov.NewClient(moov.WithCredentials(moov.Credentials{}))
 	require.Equal(t, moov.ErrAuthCredentialsNotSet, err)
 }
+
+func Test_NewClientWithCredentials(t *testing.T) {
+	auth := ""
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		auth = req.Header.Get("Authorization")
+		rec := httptest.NewRecorder()
+		rec.WriteHeader(http.StatusNoContent)
+		return rec.Result(), nil
+	})
+
+	mc, err := moov.NewClientWithCredentials("tenant-public", "tenant-secret", moov.WithHttpClient(&http.Client{Transport: transport}))
+	require.NoError(t, err)
+	require.Equal(t, "tenant-public", mc.Credentials.PublicKey)
+	require.Equal(t, "tenant-secret", mc.Credentials.SecretKey)
+	require.NotEmpty(t, mc.Credentials.Host)
+
+	require.NoError(t, mc.Ping(BgCtx()))
+	require.Equal(t, "Basic "+base64.StdEncoding.EncodeToString([]byte("tenant-public:tenant-secret")), auth)
+
+	for _, keys := range [][2]string{{"", "tenant-secret"}, {"tenant-public", ""}} {
+		_, err := moov.NewClientWithCredentials(keys[0], keys[1])
+		require.ErrorIs(t, err, moov.ErrAuthCredentialsNotSet)
+	}
+}
+
+func Test_Client_WithHttpClient(t *testing.T) {
+	paths := []string{}
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		if r.URL.Path == "/disputes" {
+			WriteJson(w, http.StatusOK, `[]`)
+			return
+		}
+		WriteJson(w, http.StatusOK, `{}`)
+	}))
+	defer srv.Close()
+
+	// the server's certificate is only trusted by its own client
+	mc, err := moov.NewClient(
+		moov.WithCredentials(moov.Credentials{PublicKey: "public", SecretKey: "secret", Host: srv.URL}),
+		moov.WithHttpClient(srv.Client()))
+	require.NoError(t, err)
+
+	_, err = mc.ListDisputes(BgCtx())
+	require.NoError(t, err)
+
+	_, err = mc.GetTransfer(BgCtx(), "transfer-id", "account-id")
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"/disputes", "/transfers/transfer-id"}, paths)
+}
+
+func Test_Client_WithBaseURL(t *testing.T) {
+	paths := []string{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		switch r.URL.Path {
+		case "/ping":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			WriteJson(w, http.StatusOK, `{}`)
+		}
+	}))
+	defer srv.Close()
+
+	// the base URL wins over the host of credentials given after it
+	mc, err := moov.NewClient(
+		moov.WithBaseURL(srv.URL),
+		moov.WithCredentials(moov.Credentials{PublicKey: "public", SecretKey: "secret", Host: "api.moov.io"}))
+	require.NoError(t, err)
+
+	require.NoError(t, mc.Ping(BgCtx()))
+
+	_, err = mc.GetTransfer(BgCtx(), "transfer-id", "account-id")
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"/ping", "/transfers/transfer-id"}, paths)
+}
+
+func Test_Client_WithBaseURL_Invalid(t *testing.T) {
+	for _, baseURL := range []string{"api.moov.io", "ftp://api.moov.io", "https://", "http://[::1"} {
+		_, err := moov.NewClient(
+			moov.WithCredentials(moov.Credentials{PublicKey: "public", SecretKey: "secret"}),
+			moov.WithBaseURL(baseURL))
+		require.ErrorIs(t, err, moov.ErrURL, baseURL)
+	}
+}
+
+func Test_Client_WithTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("slow") != "" || strings.HasPrefix(r.URL.Path, "/transfers/") {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(5 * time.Second):
+			}
+		}
+		WriteJson(w, http.StatusOK, `[]`)
+	}))
+	defer srv.Close()
+
+	mc, err := moov.NewClient(
+		moov.WithCredentials(moov.Credentials{PublicKey: "public", SecretKey: "secret"}),
+		moov.WithBaseURL(srv.URL),
+		moov.WithTimeout(200*time.Millisecond))
+	require.NoError(t, err)
+
+	// fast requests aren't affected
+	_, err = mc.ListDisputes(BgCtx())
+	require.NoError(t, err)
+
+	start := time.Now()
+	_, err = mc.ListDisputes(BgCtx(), moov.QueryParam("slow", "true"))
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Less(t, time.Since(start), 2*time.Second)
+
+	// legacy calls get the timeout too
+	start = time.Now()
+	_, err = mc.UpdateTransferMetaData(BgCtx(), "transfer-id", "", map[string]string{"key": "value"})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Less(t, time.Since(start), 2*time.Second)
+
+	// a sooner deadline on the caller's context still applies
+	ctx, cancel := context.WithTimeout(BgCtx(), 10*time.Millisecond)
+	defer cancel()
+
+	start = time.Now()
+	_, err = mc.ListDisputes(ctx, moov.QueryParam("slow", "true"))
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Less(t, time.Since(start), 150*time.Millisecond)
+}
+
+func Test_Client_WithDefaultPageSize(t *testing.T) {
+	queries := map[string]string{}
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		queries[r.URL.Path] = r.URL.RawQuery
+		if strings.HasSuffix(r.URL.Path, "/wallet-id") {
+			WriteJson(w, http.StatusOK, `{}`)
+			return
+		}
+		WriteJson(w, http.StatusOK, `[]`)
+	}, moov.WithDefaultPageSize(50))
+
+	_, err := mc.ListTransfers(BgCtx(), moov.SearchQueryPayload{})
+	require.NoError(t, err)
+	_, err = mc.ListDisputes(BgCtx())
+	require.NoError(t, err)
+	_, err = mc.ListSchedules(BgCtx(), "account-id")
+	require.NoError(t, err)
+	_, err = mc.GetWallet(BgCtx(), "account-id", "wallet-id")
+	require.NoError(t, err)
+
+	require.Equal(t, map[string]string{
+		"/transfers":                             "count=50",
+		"/disputes":                              "count=50",
+		"/accounts/account-id/schedules":         "count=50",
+		"/accounts/account-id/wallets/wallet-id": "",
+	}, queries)
+
+	// a count set on the call wins
+	_, err = mc.ListTransfers(BgCtx(), moov.SearchQueryPayload{Count: 10})
+	require.NoError(t, err)
+	_, err = mc.ListDisputes(BgCtx(), moov.WithDisputeCount(5))
+	require.NoError(t, err)
+
+	require.Equal(t, "count=10", queries["/transfers"])
+	require.Equal(t, "count=5", queries["/disputes"])
+}
+
+func Test_Client_HostPathPrefix(t *testing.T) {
+	hosts := []string{
+		"https://gw.internal/moov/v1",
+		"https://gw.internal/moov/v1/",
+	}
+
+	for _, host := range hosts {
+		t.Run(host, func(t *testing.T) {
+			urls := []string{}
+			mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+				urls = append(urls, r.URL.String())
+				if strings.HasSuffix(r.URL.Path, "/disputes") {
+					WriteJson(w, http.StatusOK, `[]`)
+					return
+				}
+				WriteJson(w, http.StatusOK, `{}`)
+			}, moov.WithCredentials(moov.Credentials{PublicKey: "public", SecretKey: "secret", Host: host}))
+
+			_, err := mc.ListDisputes(BgCtx(), moov.WithDisputeCount(10))
+			require.NoError(t, err)
+
+			_, err = mc.GetTransfer(BgCtx(), "transfer-id", "account-id")
+			require.NoError(t, err)
+
+			_, err = mc.GetWallet(BgCtx(), "account-id", "wallet-id")
+			require.NoError(t, err)
+
+			require.Equal(t, []string{
+				"https://gw.internal/moov/v1/disputes?count=10",
+				"https://gw.internal/moov/v1/transfers/transfer-id?accountID=account-id",
+				"https://gw.internal/moov/v1/accounts/account-id/wallets/wallet-id",
+			}, urls)
+		})
+	}
+
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "api.moov.io", r.URL.Host)
+		require.Equal(t, "/transfers/transfer-id", r.URL.Path)
+		WriteJson(w, http.StatusOK, `{}`)
+	})
+	_, err := mc.GetTransfer(BgCtx(), "transfer-id", "")
+	require.NoError(t, err)
+}
@@ -0,0 +1,40 @@
+package moov
+
+import (
+	"context"
+	"sync"
+)
+
+// defaultConcurrency is the number of requests helpers that fan out will have in flight at once
+const defaultConcurrency = 5
+
+// forEachConcurrent calls fn for every index in [0, n) with at most limit calls running at a time.
+// No new calls are started once the context is done, in which case the context's error is returned.
+func forEachConcurrent(ctx context.Context, n int, limit int, fn func(ctx context.Context, i int)) error {
+	if limit <= 0 {
+		limit = defaultConcurrency
+	}
+
+	sem := make(chan struct{}, limit)
+	wg := sync.WaitGroup{}
+
+	for i := 0; i < n && ctx.Err() == nil; i++ {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return ctx.Err()
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			fn(ctx, i)
+		}(i)
+	}
+
+	wg.Wait()
+	return ctx.Err()
+}
@@ -28,7 +28,8 @@ func CredentialsFromEnv() Credentials {
 type Credentials struct {
 	PublicKey string `yaml:"public_key,omitempty"`
 	SecretKey string `yaml:"secret_key,omitempty"`
-	Host      string `yaml:"host,omitempty"`
+	// Host of the Moov API, ie: api.moov.io. Can include a scheme and path prefix, ie: https://gw.internal/moov/v1
+	Host string `yaml:"host,omitempty"`
 }
 
 func (c *Credentials) Validate() error {
@@ -0,0 +1,132 @@
+package moov
+
+import "strings"
+
+// DisputeNetworkReasonCode is the reason code the card network gave for a dispute, ie: Visa's 10.4 or Mastercard's 4837
+type DisputeNetworkReasonCode string
+
+// Visa reason codes
+const (
+	DISPUTE_REASON_VISA_EMV_COUNTERFEIT      DisputeNetworkReasonCode = "10.1"
+	DISPUTE_REASON_VISA_EMV_NON_COUNTERFEIT  DisputeNetworkReasonCode = "10.2"
+	DISPUTE_REASON_VISA_FRAUD_CARD_PRESENT   DisputeNetworkReasonCode = "10.3"
+	DISPUTE_REASON_VISA_FRAUD_CARD_ABSENT    DisputeNetworkReasonCode = "10.4"
+	DISPUTE_REASON_VISA_FRAUD_MONITORING     DisputeNetworkReasonCode = "10.5"
+	DISPUTE_REASON_VISA_CARD_RECOVERY        DisputeNetworkReasonCode = "11.1"
+	DISPUTE_REASON_VISA_DECLINED_AUTH        DisputeNetworkReasonCode = "11.2"
+	DISPUTE_REASON_VISA_NO_AUTH              DisputeNetworkReasonCode = "11.3"
+	DISPUTE_REASON_VISA_LATE_PRESENTMENT     DisputeNetworkReasonCode = "12.1"
+	DISPUTE_REASON_VISA_INCORRECT_CODE       DisputeNetworkReasonCode = "12.2"
+	DISPUTE_REASON_VISA_INCORRECT_CURRENCY   DisputeNetworkReasonCode = "12.3"
+	DISPUTE_REASON_VISA_INCORRECT_ACCOUNT    DisputeNetworkReasonCode = "12.4"
+	DISPUTE_REASON_VISA_INCORRECT_AMOUNT     DisputeNetworkReasonCode = "12.5"
+	DISPUTE_REASON_VISA_DUPLICATE_PROCESSING DisputeNetworkReasonCode = "12.6.1"
+	DISPUTE_REASON_VISA_PAID_BY_OTHER_MEANS  DisputeNetworkReasonCode = "12.6.2"
+	DISPUTE_REASON_VISA_INVALID_DATA         DisputeNetworkReasonCode = "12.7"
+	DISPUTE_REASON_VISA_NOT_RECEIVED         DisputeNetworkReasonCode = "13.1"
+	DISPUTE_REASON_VISA_CANCELED_RECURRING   DisputeNetworkReasonCode = "13.2"
+	DISPUTE_REASON_VISA_NOT_AS_DESCRIBED     DisputeNetworkReasonCode = "13.3"
+	DISPUTE_REASON_VISA_COUNTERFEIT_GOODS    DisputeNetworkReasonCode = "13.4"
+	DISPUTE_REASON_VISA_MISREPRESENTATION    DisputeNetworkReasonCode = "13.5"
+	DISPUTE_REASON_VISA_CREDIT_NOT_PROCESSED DisputeNetworkReasonCode = "13.6"
+	DISPUTE_REASON_VISA_CANCELED_MERCHANDISE DisputeNetworkReasonCode = "13.7"
+	DISPUTE_REASON_VISA_ORIGINAL_CREDIT      DisputeNetworkReasonCode = "13.8"
+	DISPUTE_REASON_VISA_NON_RECEIPT_OF_CASH  DisputeNetworkReasonCode = "13.9"
+)
+
+// Mastercard reason codes
+const (
+	DISPUTE_REASON_MASTERCARD_WARNING_BULLETIN     DisputeNetworkReasonCode = "4807"
+	DISPUTE_REASON_MASTERCARD_AUTHORIZATION        DisputeNetworkReasonCode = "4808"
+	DISPUTE_REASON_MASTERCARD_ACCOUNT_NOT_ON_FILE  DisputeNetworkReasonCode = "4812"
+	DISPUTE_REASON_MASTERCARD_INCORRECT_AMOUNT     DisputeNetworkReasonCode = "4831"
+	DISPUTE_REASON_MASTERCARD_POINT_OF_INTERACTION DisputeNetworkReasonCode = "4834"
+	DISPUTE_REASON_MASTERCARD_NO_AUTHORIZATION     DisputeNetworkReasonCode = "4837"
+	DISPUTE_REASON_MASTERCARD_CANCELED_RECURRING   DisputeNetworkReasonCode = "4841"
+	DISPUTE_REASON_MASTERCARD_LATE_PRESENTMENT     DisputeNetworkReasonCode = "4842"
+	DISPUTE_REASON_MASTERCARD_CURRENCY_ERROR       DisputeNetworkReasonCode = "4846"
+	DISPUTE_REASON_MASTERCARD_FRAUD_CARD_ABSENT    DisputeNetworkReasonCode = "4849"
+	DISPUTE_REASON_MASTERCARD_CARDHOLDER_DISPUTE   DisputeNetworkReasonCode = "4853"
+	DISPUTE_REASON_MASTERCARD_NOT_PROVIDED         DisputeNetworkReasonCode = "4855"
+	DISPUTE_REASON_MASTERCARD_NOT_RENDERED         DisputeNetworkReasonCode = "4859"
+	DISPUTE_REASON_MASTERCARD_CREDIT_NOT_PROCESSED DisputeNetworkReasonCode = "4860"
+	DISPUTE_REASON_MASTERCARD_NOT_RECOGNIZED       DisputeNetworkReasonCode = "4863"
+	DISPUTE_REASON_MASTERCARD_CHIP_LIABILITY_SHIFT DisputeNetworkReasonCode = "4870"
+	DISPUTE_REASON_MASTERCARD_CHIP_PIN_LIABILITY   DisputeNetworkReasonCode = "4871"
+)
+
+type DisputeReasonCategory int
+
+const (
+	DisputeReasonFraud DisputeReasonCategory = iota
+	DisputeReasonAuthorization
+	DisputeReasonProcessingError
+	DisputeReasonConsumerDispute
+
+	// DisputeReasonUnknown is returned for reason codes this client doesn't recognize
+	DisputeReasonUnknown DisputeReasonCategory = -1
+)
+
+var DisputeReasonCategoryStrings = map[DisputeReasonCategory]string{
+	DisputeReasonFraud:           "fraud",
+	DisputeReasonAuthorization:   "authorization",
+	DisputeReasonProcessingError: "processing-error",
+	DisputeReasonConsumerDispute: "consumer-dispute",
+}
+
+// String returns the name of the category or "unknown" for values outside of the enum.
+func (c DisputeReasonCategory) String() string {
+	if str, ok := DisputeReasonCategoryStrings[c]; ok {
+		return str
+	}
+	return "unknown"
+}
+
+// visaReasonCategories groups Visa's reason codes by the number before the dot
+var visaReasonCategories = map[string]DisputeReasonCategory{
+	"10": DisputeReasonFraud,
+	"11": DisputeReasonAuthorization,
+	"12": DisputeReasonProcessingError,
+	"13": DisputeReasonConsumerDispute,
+}
+
+var mastercardReasonCategories = map[DisputeNetworkReasonCode]DisputeReasonCategory{
+	DISPUTE_REASON_MASTERCARD_WARNING_BULLETIN:     DisputeReasonAuthorization,
+	DISPUTE_REASON_MASTERCARD_AUTHORIZATION:        DisputeReasonAuthorization,
+	DISPUTE_REASON_MASTERCARD_ACCOUNT_NOT_ON_FILE:  DisputeReasonAuthorization,
+	DISPUTE_REASON_MASTERCARD_INCORRECT_AMOUNT:     DisputeReasonProcessingError,
+	DISPUTE_REASON_MASTERCARD_POINT_OF_INTERACTION: DisputeReasonProcessingError,
+	DISPUTE_REASON_MASTERCARD_NO_AUTHORIZATION:     DisputeReasonFraud,
+	DISPUTE_REASON_MASTERCARD_CANCELED_RECURRING:   DisputeReasonConsumerDispute,
+	DISPUTE_REASON_MASTERCARD_LATE_PRESENTMENT:     DisputeReasonProcessingError,
+	DISPUTE_REASON_MASTERCARD_CURRENCY_ERROR:       DisputeReasonProcessingError,
+	DISPUTE_REASON_MASTERCARD_FRAUD_CARD_ABSENT:    DisputeReasonFraud,
+	DISPUTE_REASON_MASTERCARD_CARDHOLDER_DISPUTE:   DisputeReasonConsumerDispute,
+	DISPUTE_REASON_MASTERCARD_NOT_PROVIDED:         DisputeReasonConsumerDispute,
+	DISPUTE_REASON_MASTERCARD_NOT_RENDERED:         DisputeReasonConsumerDispute,
+	DISPUTE_REASON_MASTERCARD_CREDIT_NOT_PROCESSED: DisputeReasonConsumerDispute,
+	DISPUTE_REASON_MASTERCARD_NOT_RECOGNIZED:       DisputeReasonFraud,
+	DISPUTE_REASON_MASTERCARD_CHIP_LIABILITY_SHIFT: DisputeReasonFraud,
+	DISPUTE_REASON_MASTERCARD_CHIP_PIN_LIABILITY:   DisputeReasonFraud,
+}
+
+// Category groups the reason code into fraud, authorization, processing error or consumer dispute, which decides the
+// kind of evidence that can win the dispute. Visa codes are grouped by the number before the dot so codes Visa adds
+// later are still categorized. DisputeReasonUnknown is returned for codes that aren't recognized.
+func (code DisputeNetworkReasonCode) Category() DisputeReasonCategory {
+	if category, ok := mastercardReasonCategories[code]; ok {
+		return category
+	}
+
+	group, _, found := strings.Cut(string(code), ".")
+	if category, ok := visaReasonCategories[group]; ok && found {
+		return category
+	}
+
+	return DisputeReasonUnknown
+}
+
+// TypedReasonCode returns the network's reason code for the dispute as a DisputeNetworkReasonCode
+func (d Dispute) TypedReasonCode() DisputeNetworkReasonCode {
+	return DisputeNetworkReasonCode(strings.TrimSpace(d.NetworkReasonCode))
+}
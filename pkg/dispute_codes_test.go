@@ -0,0 +1,53 @@
+package moov_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	moov "github.com/moovfinancial/moov-go/pkg"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDisputeNetworkReasonCode_Category(t *testing.T) {
+	cases := []struct {
+		code     moov.DisputeNetworkReasonCode
+		expected moov.DisputeReasonCategory
+	}{
+		{moov.DISPUTE_REASON_VISA_FRAUD_CARD_ABSENT, moov.DisputeReasonFraud},
+		{moov.DISPUTE_REASON_VISA_EMV_COUNTERFEIT, moov.DisputeReasonFraud},
+		{moov.DISPUTE_REASON_VISA_NO_AUTH, moov.DisputeReasonAuthorization},
+		{moov.DISPUTE_REASON_VISA_DUPLICATE_PROCESSING, moov.DisputeReasonProcessingError},
+		{moov.DISPUTE_REASON_VISA_INCORRECT_AMOUNT, moov.DisputeReasonProcessingError},
+		{moov.DISPUTE_REASON_VISA_NOT_RECEIVED, moov.DisputeReasonConsumerDispute},
+		{moov.DISPUTE_REASON_VISA_CREDIT_NOT_PROCESSED, moov.DisputeReasonConsumerDispute},
+		{moov.DISPUTE_REASON_MASTERCARD_NO_AUTHORIZATION, moov.DisputeReasonFraud},
+		{moov.DISPUTE_REASON_MASTERCARD_AUTHORIZATION, moov.DisputeReasonAuthorization},
+		{moov.DISPUTE_REASON_MASTERCARD_CARDHOLDER_DISPUTE, moov.DisputeReasonConsumerDispute},
+
+		// Visa codes not listed are grouped by their prefix
+		{"10.9", moov.DisputeReasonFraud},
+
+		{"14.1", moov.DisputeReasonUnknown},
+		{"10", moov.DisputeReasonUnknown},
+		{"", moov.DisputeReasonUnknown},
+	}
+
+	for _, c := range cases {
+		t.Run(string(c.code), func(t *testing.T) {
+			require.Equal(t, c.expected, c.code.Category())
+		})
+	}
+
+	require.Equal(t, "fraud", moov.DisputeReasonFraud.String())
+	require.Equal(t, "unknown", moov.DisputeReasonUnknown.String())
+}
+
+func TestDispute_TypedReasonCode(t *testing.T) {
+	dispute := moov.Dispute{}
+	require.NoError(t, json.Unmarshal([]byte(`{"networkReasonCode": "10.4", "networkReasonDescription": "Other Fraud - Card Absent Environment"}`), &dispute))
+
+	require.Equal(t, moov.DISPUTE_REASON_VISA_FRAUD_CARD_ABSENT, dispute.TypedReasonCode())
+	require.Equal(t, moov.DisputeReasonFraud, dispute.TypedReasonCode().Category())
+
+	require.Equal(t, moov.DisputeReasonUnknown, moov.Dispute{}.TypedReasonCode().Category())
+}
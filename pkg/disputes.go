@@ -2,11 +2,21 @@ package moov
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"mime/multipart"
 	"net/http"
+	"sort"
 	"time"
 )
 
+var (
+	ErrDisputeDeadlinePassed = errors.New("the dispute's respondBy deadline has passed")
+	ErrDisputeClosed         = errors.New("the dispute can no longer be responded to")
+)
+
 type Dispute struct {
 	DisputeID                string              `json:"disputeID,omitempty"`
 	CreatedOn                time.Time           `json:"createdOn,omitempty"`
@@ -18,82 +28,173 @@ type Dispute struct {
 	Transfer                 SynchronousTransfer `json:"transfer,omitempty"`
 }
 
-type DisputeListFilter callArg
+// MarshalJSON leaves out unset created and respond by times
+func (d Dispute) MarshalJSON() ([]byte, error) {
+	// Alias is an alias type of Dispute to avoid recursion.
+	type Alias Dispute
+
+	type AliasWithTimes struct {
+		Alias
+		CreatedOn *time.Time `json:"createdOn,omitempty"`
+		RespondBy *time.Time `json:"respondBy,omitempty"`
+	}
+
+	return json.Marshal(AliasWithTimes{
+		Alias:     Alias(d),
+		CreatedOn: timeOrNil(d.CreatedOn),
+		RespondBy: timeOrNil(d.RespondBy),
+	})
+}
+
+// CanRespond reports if a response can still be submitted for the dispute, it has to need a response and its
+// respondBy deadline can't have passed. Disputes without a deadline can be responded to while they need a response.
+func (d Dispute) CanRespond() bool {
+	if d.Status != "response-needed" {
+		return false
+	}
+	return d.RespondBy.IsZero() || time.Now().Before(d.RespondBy)
+}
+
+const (
+	DISPUTE_MESSAGE_INBOUND  = "inbound"
+	DISPUTE_MESSAGE_OUTBOUND = "outbound"
+)
+
+// DisputeMessage is a message or status change exchanged with the card network over the course of a dispute
+type DisputeMessage struct {
+	MessageID string `json:"messageID,omitempty"`
+	// DISPUTE_MESSAGE_INBOUND for messages received from the network, DISPUTE_MESSAGE_OUTBOUND for ones sent to it
+	Direction string    `json:"direction,omitempty"`
+	Status    string    `json:"status,omitempty"`
+	Message   string    `json:"message,omitempty"`
+	CreatedOn time.Time `json:"createdOn,omitempty"`
+}
+
+// DisputeListFilter narrows down the disputes returned by ListDisputesFiltered, only fields that are set are sent
+type DisputeListFilter struct {
+	Count                int
+	Skip                 int
+	RespondStartDateTime time.Time
+	RespondEndDateTime   time.Time
+	Status               string
+	MerchantAccountID    string
+	CardholderAccountID  string
+	StartDateTime        time.Time
+	EndDateTime          time.Time
+	OrderBy              string
+}
+
+func (f DisputeListFilter) args() []callArg {
+	args := []callArg{}
+
+	if f.Count != 0 {
+		args = append(args, WithDisputeCount(f.Count))
+	}
+	if f.Skip != 0 {
+		args = append(args, WithDisputeSkip(f.Skip))
+	}
+	if !f.RespondStartDateTime.IsZero() {
+		args = append(args, WithDisputeResponseStartDate(f.RespondStartDateTime))
+	}
+	if !f.RespondEndDateTime.IsZero() {
+		args = append(args, WithDisputeResponseEndDate(f.RespondEndDateTime))
+	}
+	if f.Status != "" {
+		args = append(args, WithDisputeStatus(f.Status))
+	}
+	if f.MerchantAccountID != "" {
+		args = append(args, WithDisputeMerchantAccountID(f.MerchantAccountID))
+	}
+	if f.CardholderAccountID != "" {
+		args = append(args, WithDisputeCardHolderAccountID(f.CardholderAccountID))
+	}
+	if !f.StartDateTime.IsZero() {
+		args = append(args, WithDisputeStartDate(f.StartDateTime))
+	}
+	if !f.EndDateTime.IsZero() {
+		args = append(args, WithDisputeEndDate(f.EndDateTime))
+	}
+	if f.OrderBy != "" {
+		args = append(args, WithDisputeOrderBy(f.OrderBy))
+	}
+
+	return args
+}
 
 func WithDisputeCount(c int) callArg {
 	return callBuilderFn(func(call *callBuilder) error {
-		call.params["count"] = fmt.Sprintf("%d", c)
+		call.params.Set("count", fmt.Sprintf("%d", c))
 		return nil
 	})
 }
 
 func WithDisputeSkip(c int) callArg {
 	return callBuilderFn(func(call *callBuilder) error {
-		call.params["skip"] = fmt.Sprintf("%d", c)
+		call.params.Set("skip", fmt.Sprintf("%d", c))
 		return nil
 	})
 }
 
 func WithDisputeResponseStartDate(t time.Time) callArg {
 	return callBuilderFn(func(call *callBuilder) error {
-		call.params["respondStartDateTime"] = t.Format(time.RFC3339)
+		call.params.Set("respondStartDateTime", t.Format(time.RFC3339))
 		return nil
 	})
 }
 
 func WithDisputeResponseEndDate(t time.Time) callArg {
 	return callBuilderFn(func(call *callBuilder) error {
-		call.params["respondEndDateTime"] = t.Format(time.RFC3339)
+		call.params.Set("respondEndDateTime", t.Format(time.RFC3339))
 		return nil
 	})
 }
 
 func WithDisputeStatus(s string) callArg {
 	return callBuilderFn(func(call *callBuilder) error {
-		call.params["status"] = s
+		call.params.Set("status", s)
 		return nil
 	})
 }
 
 func WithDisputeMerchantAccountID(id string) callArg {
 	return callBuilderFn(func(call *callBuilder) error {
-		call.params["merchantAccountID"] = id
+		call.params.Set("merchantAccountID", id)
 		return nil
 	})
 }
 
 func WithDisputeCardHolderAccountID(id string) callArg {
 	return callBuilderFn(func(call *callBuilder) error {
-		call.params["cardholderAccountID"] = id
+		call.params.Set("cardholderAccountID", id)
 		return nil
 	})
 }
 
 func WithDisputeStartDate(t time.Time) callArg {
 	return callBuilderFn(func(call *callBuilder) error {
-		call.params["startDateTime"] = t.Format(time.RFC3339)
+		call.params.Set("startDateTime", t.Format(time.RFC3339))
 		return nil
 	})
 }
 
 func WithDisputeEndDate(t time.Time) callArg {
 	return callBuilderFn(func(call *callBuilder) error {
-		call.params["endDateTime"] = t.Format(time.RFC3339)
+		call.params.Set("endDateTime", t.Format(time.RFC3339))
 		return nil
 	})
 }
 
 func WithDisputeOrderBy(orderBy string) callArg {
 	return callBuilderFn(func(call *callBuilder) error {
-		call.params["orderBy"] = orderBy
+		call.params.Set("orderBy", orderBy)
 		return nil
 	})
 }
 
 // ListDisputes lists of Disputes that are associated with a Moov account
 // https://docs.moov.io/api/money-movement/disputes/list/
-func (c Client) ListDisputes(ctx context.Context, filters ...DisputeListFilter) ([]Dispute, error) {
-	args := prependArgs(filters, AcceptJson())
+func (c Client) ListDisputes(ctx context.Context, filters ...callArg) ([]Dispute, error) {
+	args := prependArgs(filters, AcceptJson(), paged())
 	resp, err := c.CallHttp(ctx, Endpoint(http.MethodGet, pathDisputes), args...)
 	if err != nil {
 		return nil, err
@@ -102,6 +203,76 @@ func (c Client) ListDisputes(ctx context.Context, filters ...DisputeListFilter)
 	return CompletedListOrError[Dispute](resp)
 }
 
+// ListDisputesFiltered lists the Disputes matching the filter
+// https://docs.moov.io/api/money-movement/disputes/list/
+func (c Client) ListDisputesFiltered(ctx context.Context, filter DisputeListFilter) ([]Dispute, error) {
+	return c.ListDisputes(ctx, filter.args()...)
+}
+
+// disputePageSize is the count the iterator pages with when neither the filter nor the client set one, Moov's default
+const disputePageSize = 200
+
+// DisputeIterator steps through the pages of disputes matching a filter, advancing the skip offset after each page
+// until a short page is returned.
+//
+//	it := client.DisputesIterator(ctx, moov.DisputeListFilter{Status: "response-needed"})
+//	for it.HasMore() {
+//		disputes, err := it.Next()
+//		...
+//	}
+type DisputeIterator struct {
+	ctx    context.Context
+	client Client
+	filter DisputeListFilter
+	done   bool
+}
+
+// DisputesIterator returns an iterator over the disputes matching the filter starting at its Skip offset. Pages are
+// the filter's Count in size, or the client's default page size when it doesn't set one.
+func (c Client) DisputesIterator(ctx context.Context, filter DisputeListFilter) *DisputeIterator {
+	if filter.Count <= 0 {
+		filter.Count = c.defaultPageSize
+	}
+	if filter.Count <= 0 {
+		filter.Count = disputePageSize
+	}
+
+	return &DisputeIterator{
+		ctx:    ctx,
+		client: c,
+		filter: filter,
+	}
+}
+
+// HasMore reports if Next might return more disputes. It's false once a short page was returned, Next failed or the
+// context ended.
+func (it *DisputeIterator) HasMore() bool {
+	return !it.done && it.ctx.Err() == nil
+}
+
+// Next returns the next page of disputes, nil once there are no more. Iteration stops on any error.
+func (it *DisputeIterator) Next() ([]Dispute, error) {
+	if it.done {
+		return nil, nil
+	}
+	if err := it.ctx.Err(); err != nil {
+		it.done = true
+		return nil, err
+	}
+
+	disputes, err := it.client.ListDisputesFiltered(it.ctx, it.filter)
+	if err != nil {
+		it.done = true
+		return nil, err
+	}
+
+	it.filter.Skip += len(disputes)
+	if len(disputes) < it.filter.Count {
+		it.done = true
+	}
+	return disputes, nil
+}
+
 // GetDispute retrieves a dispute for the given dispute id
 // https://docs.moov.io/api/money-movement/disputes/get/
 func (c Client) GetDispute(ctx context.Context, disputeID string) (*Dispute, error) {
@@ -112,3 +283,169 @@ func (c Client) GetDispute(ctx context.Context, disputeID string) (*Dispute, err
 
 	return CompletedObjectOrError[Dispute](resp)
 }
+
+// ListDisputeMessages lists the network messages of a dispute ordered from oldest to newest
+func (c Client) ListDisputeMessages(ctx context.Context, disputeID string) ([]DisputeMessage, error) {
+	resp, err := c.CallHttp(ctx, Endpoint(http.MethodGet, pathDisputeMessages, disputeID), AcceptJson())
+	if err != nil {
+		return nil, err
+	}
+
+	messages, err := CompletedListOrError[DisputeMessage](resp)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(messages, func(i, j int) bool {
+		return messages[i].CreatedOn.Before(messages[j].CreatedOn)
+	})
+	return messages, nil
+}
+
+const (
+	EVIDENCE_RECEIPT                = "receipt"
+	EVIDENCE_PROOF_OF_DELIVERY      = "proof-of-delivery"
+	EVIDENCE_CANCELATION_POLICY     = "cancelation-policy"
+	EVIDENCE_TERMS_OF_SERVICE       = "terms-of-service"
+	EVIDENCE_CUSTOMER_COMMUNICATION = "customer-communication"
+	EVIDENCE_GENERIC                = "generic-evidence"
+	EVIDENCE_COVER_LETTER           = "cover-letter"
+	EVIDENCE_OTHER                  = "other"
+)
+
+// AcceptDispute concedes the dispute instead of contesting it and returns the dispute with its updated status.
+// ErrDisputeDeadlinePassed is returned if the respondBy deadline has passed and ErrDisputeClosed for disputes that
+// can't be accepted anymore for other reasons, ie: they were already accepted or were won or lost.
+// https://docs.moov.io/api/money-movement/disputes/
+func (c Client) AcceptDispute(ctx context.Context, accountID string, disputeID string) (*Dispute, error) {
+	resp, err := c.CallHttp(ctx, Endpoint(http.MethodPost, pathDisputeAccept, accountID, disputeID), AcceptJson())
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.Status() {
+	case StatusCompleted:
+		return UnmarshalObjectResponse[Dispute](resp)
+	case StatusStateConflict:
+		// look at the dispute to explain why it can't be accepted
+		dispute, err := c.GetDispute(ctx, disputeID)
+		if err == nil && dispute.Status == "response-needed" && !dispute.RespondBy.IsZero() && time.Now().After(dispute.RespondBy) {
+			return nil, fmt.Errorf("%w: %w", ErrDisputeDeadlinePassed, resp.Error())
+		}
+		return nil, fmt.Errorf("%w: %w", ErrDisputeClosed, resp.Error())
+	default:
+		return nil, resp.Error()
+	}
+}
+
+// DisputeEvidence is evidence supporting the response to a dispute, either Text or a File
+type DisputeEvidence struct {
+	// One of EVIDENCE_*
+	EvidenceType string
+	Text         string
+
+	// File is uploaded as Filename when set, taking precedence over Text
+	File     io.Reader
+	Filename string
+}
+
+type DisputeEvidenceResponse struct {
+	EvidenceID   string    `json:"evidenceID,omitempty"`
+	DisputeID    string    `json:"disputeID,omitempty"`
+	EvidenceType string    `json:"evidenceType,omitempty"`
+	Text         string    `json:"text,omitempty"`
+	MimeType     string    `json:"mimeType,omitempty"`
+	Filename     string    `json:"filename,omitempty"`
+	Size         int       `json:"size,omitempty"`
+	CreatedOn    time.Time `json:"createdOn,omitempty"`
+	UpdatedOn    time.Time `json:"updatedOn,omitempty"`
+}
+
+// UploadDisputeEvidence adds text or a file as evidence for the dispute. Evidence isn't sent to the card network until
+// the response is submitted with SubmitDisputeResponse.
+// https://docs.moov.io/api/money-movement/disputes/
+func (c Client) UploadDisputeEvidence(ctx context.Context, accountID string, disputeID string, evidence DisputeEvidence) (*DisputeEvidenceResponse, error) {
+	var (
+		resp CallResponse
+		err  error
+	)
+
+	if evidence.File != nil {
+		resp, err = c.CallHttp(ctx,
+			Endpoint(http.MethodPost, pathEvidenceFile, accountID, disputeID),
+			AcceptJson(),
+			multipartBody(func(w *multipart.Writer) error {
+				if err := w.WriteField("evidenceType", evidence.EvidenceType); err != nil {
+					return err
+				}
+
+				file, err := w.CreateFormFile("file", evidence.Filename)
+				if err != nil {
+					return err
+				}
+
+				_, err = io.Copy(file, evidence.File)
+				return err
+			}))
+	} else {
+		resp, err = c.CallHttp(ctx,
+			Endpoint(http.MethodPost, pathEvidenceText, accountID, disputeID),
+			AcceptJson(),
+			JsonBody(map[string]string{
+				"evidenceType": evidence.EvidenceType,
+				"text":         evidence.Text,
+			}))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return CompletedObjectOrError[DisputeEvidenceResponse](resp)
+}
+
+// ListDisputeEvidence lists the text and file evidence uploaded for the dispute
+// https://docs.moov.io/api/money-movement/disputes/
+func (c Client) ListDisputeEvidence(ctx context.Context, accountID string, disputeID string) ([]DisputeEvidenceResponse, error) {
+	resp, err := c.CallHttp(ctx, Endpoint(http.MethodGet, pathEvidence, accountID, disputeID), AcceptJson())
+	if err != nil {
+		return nil, err
+	}
+
+	return CompletedListOrError[DisputeEvidenceResponse](resp)
+}
+
+// GetDisputeEvidenceFile downloads an evidence file uploaded for the dispute, returning its contents along with their
+// content type. The file is streamed as it's read rather than held in memory, the caller has to close it.
+// https://docs.moov.io/api/money-movement/disputes/
+func (c Client) GetDisputeEvidenceFile(ctx context.Context, accountID string, disputeID string, evidenceID string) (io.ReadCloser, string, error) {
+	resp, err := c.CallHttp(ctx, Endpoint(http.MethodGet, pathEvidenceData, accountID, disputeID, evidenceID), streamed())
+	if err != nil {
+		return nil, "", err
+	}
+
+	body := responseStream(resp)
+	if resp.Status() != StatusCompleted || body == nil {
+		if body != nil {
+			body.Close()
+		}
+		return nil, "", resp.Error()
+	}
+
+	return body, resp.Header("Content-Type"), nil
+}
+
+// SubmitDisputeResponse submits the uploaded evidence to the card network, after which no more evidence can be added
+// https://docs.moov.io/api/money-movement/disputes/
+func (c Client) SubmitDisputeResponse(ctx context.Context, accountID string, disputeID string) error {
+	resp, err := c.CallHttp(ctx, Endpoint(http.MethodPost, pathEvidenceSubmit, accountID, disputeID), AcceptJson())
+	if err != nil {
+		return err
+	}
+
+	switch resp.Status() {
+	case StatusCompleted, StatusStarted:
+		return nil
+	default:
+		return resp.Error()
+	}
+}
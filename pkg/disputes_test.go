@@ -4,7 +4,13 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	moov "github.com/moovfinancial/moov-go/pkg"
@@ -39,6 +45,303 @@ func TestDisputesMarshal(t *testing.T) {
 	assert.Equal(t, "ec7e1848-dc80-4ab0-8827-dd7fc0737b43", dispute.DisputeID)
 }
 
+func TestDisputeCanRespond(t *testing.T) {
+	cases := []struct {
+		name      string
+		status    string
+		respondBy time.Time
+		expected  bool
+	}{
+		{"needed before deadline", "response-needed", time.Now().Add(time.Hour), true},
+		{"needed after deadline", "response-needed", time.Now().Add(-time.Hour), false},
+		{"needed without deadline", "response-needed", time.Time{}, true},
+		{"under review", "under-review", time.Now().Add(time.Hour), false},
+		{"accepted without deadline", "accepted", time.Time{}, false},
+		{"won", "won", time.Time{}, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dispute := moov.Dispute{Status: tc.status, RespondBy: tc.respondBy}
+			require.Equal(t, tc.expected, dispute.CanRespond())
+		})
+	}
+
+	// null respondBy coming from the API
+	dispute := moov.Dispute{}
+	require.NoError(t, json.Unmarshal([]byte(`{"status": "response-needed", "respondBy": null}`), &dispute))
+	require.True(t, dispute.CanRespond())
+}
+
+func TestListDisputesFiltered(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/disputes", r.URL.Path)
+		require.Equal(t, "count=50&respondEndDateTime=2024-01-02T03%3A04%3A05Z&status=response-needed", r.URL.RawQuery)
+		WriteJson(w, http.StatusOK, `[{"disputeID": "ec7e1848-dc80-4ab0-8827-dd7fc0737b43", "status": "response-needed"}]`)
+	})
+
+	disputes, err := mc.ListDisputesFiltered(BgCtx(), moov.DisputeListFilter{
+		Count:              50,
+		Status:             "response-needed",
+		RespondEndDateTime: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	require.Len(t, disputes, 1)
+}
+
+func TestDisputesIterator(t *testing.T) {
+	skips := []string{}
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "2", r.URL.Query().Get("count"))
+		require.Equal(t, "response-needed", r.URL.Query().Get("status"))
+
+		skip := r.URL.Query().Get("skip")
+		skips = append(skips, skip)
+
+		switch skip {
+		case "":
+			WriteJson(w, http.StatusOK, `[{"disputeID": "1"}, {"disputeID": "2"}]`)
+		case "2":
+			WriteJson(w, http.StatusOK, `[{"disputeID": "3"}, {"disputeID": "4"}]`)
+		default:
+			WriteJson(w, http.StatusOK, `[{"disputeID": "5"}]`)
+		}
+	})
+
+	it := mc.DisputesIterator(BgCtx(), moov.DisputeListFilter{Count: 2, Status: "response-needed"})
+
+	ids := []string{}
+	for it.HasMore() {
+		disputes, err := it.Next()
+		require.NoError(t, err)
+		for _, d := range disputes {
+			ids = append(ids, d.DisputeID)
+		}
+	}
+
+	require.Equal(t, []string{"1", "2", "3", "4", "5"}, ids)
+	require.Equal(t, []string{"", "2", "4"}, skips)
+
+	disputes, err := it.Next()
+	require.NoError(t, err)
+	require.Nil(t, disputes)
+}
+
+func TestDisputesIterator_ContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(BgCtx())
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		WriteJson(w, http.StatusOK, `[{"disputeID": "1"}, {"disputeID": "2"}]`)
+	})
+
+	it := mc.DisputesIterator(ctx, moov.DisputeListFilter{Count: 2})
+	disputes, err := it.Next()
+	require.NoError(t, err)
+	require.Len(t, disputes, 2)
+	require.True(t, it.HasMore())
+
+	cancel()
+	require.False(t, it.HasMore())
+
+	_, err = it.Next()
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestAcceptDispute(t *testing.T) {
+	cases := []struct {
+		name      string
+		status    string
+		respondBy time.Time
+		expected  error
+	}{
+		{"already closed", "lost", time.Now().Add(time.Hour), moov.ErrDisputeClosed},
+		{"past deadline", "response-needed", time.Now().Add(-time.Hour), moov.ErrDisputeDeadlinePassed},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/accounts/account-id/disputes/dispute-id/accept":
+					WriteJson(w, http.StatusConflict, `{"error": "dispute can't be accepted"}`)
+				case "/disputes/dispute-id":
+					WriteJson(w, http.StatusOK, fmt.Sprintf(`{"disputeID": "dispute-id", "status": %q, "respondBy": %q}`,
+						tc.status, tc.respondBy.Format(time.RFC3339)))
+				}
+			})
+
+			_, err := mc.AcceptDispute(BgCtx(), "account-id", "dispute-id")
+			require.ErrorIs(t, err, tc.expected)
+
+			var httpErr moov.HttpCallError
+			require.ErrorAs(t, err, &httpErr)
+			require.Equal(t, moov.StatusStateConflict, httpErr.Status())
+		})
+	}
+
+	t.Run("accepted", func(t *testing.T) {
+		mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, http.MethodPost, r.Method)
+			require.Equal(t, "/accounts/account-id/disputes/dispute-id/accept", r.URL.Path)
+			WriteJson(w, http.StatusOK, `{"disputeID": "dispute-id", "status": "accepted"}`)
+		})
+
+		dispute, err := mc.AcceptDispute(BgCtx(), "account-id", "dispute-id")
+		require.NoError(t, err)
+		require.Equal(t, "accepted", dispute.Status)
+	})
+}
+
+func TestUploadDisputeEvidence_Text(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/accounts/account-id/disputes/dispute-id/evidence-text", r.URL.Path)
+
+		body := map[string]string{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.Equal(t, map[string]string{"evidenceType": "customer-communication", "text": "Customer confirmed delivery"}, body)
+
+		WriteJson(w, http.StatusOK, `{"evidenceID": "evidence-id", "disputeID": "dispute-id", "evidenceType": "customer-communication", "text": "Customer confirmed delivery"}`)
+	})
+
+	evidence, err := mc.UploadDisputeEvidence(BgCtx(), "account-id", "dispute-id", moov.DisputeEvidence{
+		EvidenceType: moov.EVIDENCE_CUSTOMER_COMMUNICATION,
+		Text:         "Customer confirmed delivery",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "evidence-id", evidence.EvidenceID)
+	require.Equal(t, "Customer confirmed delivery", evidence.Text)
+}
+
+func TestUploadDisputeEvidence_File(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/accounts/account-id/disputes/dispute-id/evidence-file", r.URL.Path)
+
+		mediaType, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		require.NoError(t, err)
+		require.Equal(t, "multipart/form-data", mediaType)
+		require.NotEmpty(t, params["boundary"])
+
+		require.NoError(t, r.ParseMultipartForm(1<<20))
+		require.Equal(t, "receipt", r.FormValue("evidenceType"))
+
+		file, header, err := r.FormFile("file")
+		require.NoError(t, err)
+		require.Equal(t, "receipt.pdf", header.Filename)
+
+		content, err := io.ReadAll(file)
+		require.NoError(t, err)
+		require.Equal(t, "%PDF-1.4", string(content))
+
+		WriteJson(w, http.StatusOK, `{"evidenceID": "evidence-id", "evidenceType": "receipt", "filename": "receipt.pdf", "size": 8}`)
+	})
+
+	evidence, err := mc.UploadDisputeEvidence(BgCtx(), "account-id", "dispute-id", moov.DisputeEvidence{
+		EvidenceType: moov.EVIDENCE_RECEIPT,
+		File:         strings.NewReader("%PDF-1.4"),
+		Filename:     "receipt.pdf",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "receipt.pdf", evidence.Filename)
+	require.Equal(t, 8, evidence.Size)
+}
+
+func TestListDisputeEvidence(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		require.Equal(t, "/accounts/account-id/disputes/dispute-id/evidence", r.URL.Path)
+		WriteJson(w, http.StatusOK, `[
+			{"evidenceID": "text-id", "evidenceType": "cover-letter", "text": "we shipped it"},
+			{"evidenceID": "file-id", "evidenceType": "receipt", "mimeType": "application/pdf", "filename": "receipt.pdf", "size": 8}
+		]`)
+	})
+
+	evidence, err := mc.ListDisputeEvidence(BgCtx(), "account-id", "dispute-id")
+	require.NoError(t, err)
+	require.Len(t, evidence, 2)
+	require.Equal(t, "we shipped it", evidence[0].Text)
+	require.Equal(t, "receipt.pdf", evidence[1].Filename)
+}
+
+func TestGetDisputeEvidenceFile(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/accounts/account-id/disputes/dispute-id/evidence/file-id/data", r.URL.Path)
+		w.Header().Set("Content-Type", "application/pdf")
+		_, _ = w.Write([]byte("%PDF-1.4"))
+	})
+
+	file, contentType, err := mc.GetDisputeEvidenceFile(BgCtx(), "account-id", "dispute-id", "file-id")
+	require.NoError(t, err)
+	defer file.Close()
+
+	require.Equal(t, "application/pdf", contentType)
+	content, err := io.ReadAll(file)
+	require.NoError(t, err)
+	require.Equal(t, "%PDF-1.4", string(content))
+}
+
+func TestGetDisputeEvidenceFile_NotFound(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		WriteJson(w, http.StatusNotFound, `{"error": "evidence not found"}`)
+	})
+
+	file, contentType, err := mc.GetDisputeEvidenceFile(BgCtx(), "account-id", "dispute-id", "file-id")
+	require.Nil(t, file)
+	require.Empty(t, contentType)
+
+	var apiErr *moov.APIError
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, moov.StatusNotFound, apiErr.Status())
+	require.Equal(t, "evidence not found", apiErr.Message)
+}
+
+func TestSubmitDisputeResponse(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/accounts/account-id/disputes/dispute-id/evidence/submit", r.URL.Path)
+		WriteJson(w, http.StatusOK, `{}`)
+	})
+
+	require.NoError(t, mc.SubmitDisputeResponse(BgCtx(), "account-id", "dispute-id"))
+}
+
+func TestDisputeMessagesMarshal(t *testing.T) {
+	input := []byte(`[
+		{
+			"messageID": "b2a0e6e5-7e2c-4a5a-8a0b-0b3b1b0e0c11",
+			"direction": "outbound",
+			"status": "under-review",
+			"message": "Evidence submitted",
+			"createdOn": "2024-01-03T10:00:00Z"
+		},
+		{
+			"messageID": "6a1f1f0e-0e54-4c5f-9b8e-4a9e5c6f0d22",
+			"direction": "inbound",
+			"status": "response-needed",
+			"message": "Cardholder claims the goods were not received",
+			"createdOn": "2024-01-02T10:00:00Z"
+		}
+	]`)
+
+	dec := json.NewDecoder(bytes.NewReader(input))
+	dec.DisallowUnknownFields()
+
+	messages := []moov.DisputeMessage{}
+	require.NoError(t, dec.Decode(&messages))
+	require.Len(t, messages, 2)
+	require.Equal(t, moov.DISPUTE_MESSAGE_OUTBOUND, messages[0].Direction)
+	require.Equal(t, "Evidence submitted", messages[0].Message)
+
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/disputes/dispute-id/messages", r.URL.Path)
+		WriteJson(w, http.StatusOK, string(input))
+	})
+
+	messages, err := mc.ListDisputeMessages(BgCtx(), "dispute-id")
+	require.NoError(t, err)
+	require.Equal(t, "6a1f1f0e-0e54-4c5f-9b8e-4a9e5c6f0d22", messages[0].MessageID)
+	require.Equal(t, moov.DISPUTE_MESSAGE_INBOUND, messages[0].Direction)
+	require.True(t, messages[0].CreatedOn.Before(messages[1].CreatedOn))
+}
+
 func Test_Disputes(t *testing.T) {
 	mc := NewTestClient(t)
 
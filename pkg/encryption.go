@@ -0,0 +1,109 @@
+package moov
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// jweHeader is the protected header of the JWEs the client sends, the content key is encrypted to Moov's public key
+// with RSA-OAEP-256 and the payload with AES-256-GCM
+var jweHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"RSA-OAEP-256","enc":"A256GCM","cty":"application/json"}`))
+
+// WithEncryption encrypts the card and bank account numbers sent to Moov to publicKey as a JWE, on top of TLS, so
+// they can't be read by anything between the client and Moov that terminates TLS. Data is sent as plain JSON by
+// default.
+func WithEncryption(publicKey *rsa.PublicKey) ClientConfigurable {
+	return func(c *Client) error {
+		if publicKey == nil {
+			return errors.New("encryption public key must be set")
+		}
+		c.encryptionKey = publicKey
+		return nil
+	}
+}
+
+// EncryptedJsonBody sends the body as a compact JWE encrypted to the key the client was configured with through
+// WithEncryption, or as JsonBody when encryption isn't configured.
+func EncryptedJsonBody(body any) callArg {
+	return callBuilderFn(func(call *callBuilder) error {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+
+		call.headers["Content-Type"] = "application/json"
+		call.body = bytes.NewBuffer(payload)
+		call.encrypted = true
+
+		return nil
+	})
+}
+
+// encryptBody replaces the JSON body of a call made with EncryptedJsonBody with its JWE when the client encrypts
+func (c *Client) encryptBody(call *callBuilder) error {
+	if !call.encrypted || c.encryptionKey == nil {
+		return nil
+	}
+
+	payload := &bytes.Buffer{}
+	if _, err := payload.ReadFrom(call.body); err != nil {
+		return err
+	}
+
+	jwe, err := encryptJWE(c.encryptionKey, payload.Bytes())
+	if err != nil {
+		return err
+	}
+
+	call.headers["Content-Type"] = "application/jose"
+	call.body = strings.NewReader(jwe)
+
+	return nil
+}
+
+// encryptJWE returns the compact serialization of the payload encrypted to the public key
+func encryptJWE(publicKey *rsa.PublicKey, payload []byte) (string, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+
+	encryptedKey, err := rsa.EncryptOAEP(sha256.New(), rand.Reader, publicKey, key, nil)
+	if err != nil {
+		return "", err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return "", err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", err
+	}
+
+	iv := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(iv); err != nil {
+		return "", err
+	}
+
+	// the protected header is authenticated as the additional data and the tag is sent apart from the ciphertext
+	sealed := gcm.Seal(nil, iv, payload, []byte(jweHeader))
+	ciphertext, tag := sealed[:len(payload)], sealed[len(payload):]
+
+	return strings.Join([]string{
+		jweHeader,
+		base64.RawURLEncoding.EncodeToString(encryptedKey),
+		base64.RawURLEncoding.EncodeToString(iv),
+		base64.RawURLEncoding.EncodeToString(ciphertext),
+		base64.RawURLEncoding.EncodeToString(tag),
+	}, "."), nil
+}
@@ -0,0 +1,125 @@
+package moov_test
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	moov "github.com/moovfinancial/moov-go/pkg"
+	"github.com/stretchr/testify/require"
+)
+
+// decryptJWE decrypts a compact RSA-OAEP-256/A256GCM JWE with the private key
+func decryptJWE(t *testing.T, key *rsa.PrivateKey, jwe string) []byte {
+	parts := strings.Split(jwe, ".")
+	require.Len(t, parts, 5)
+
+	decoded := make([][]byte, len(parts))
+	for i, part := range parts {
+		var err error
+		decoded[i], err = base64.RawURLEncoding.DecodeString(part)
+		require.NoError(t, err)
+	}
+
+	header := map[string]string{}
+	require.NoError(t, json.Unmarshal(decoded[0], &header))
+	require.Equal(t, "RSA-OAEP-256", header["alg"])
+	require.Equal(t, "A256GCM", header["enc"])
+
+	contentKey, err := rsa.DecryptOAEP(sha256.New(), nil, key, decoded[1], nil)
+	require.NoError(t, err)
+
+	block, err := aes.NewCipher(contentKey)
+	require.NoError(t, err)
+	gcm, err := cipher.NewGCM(block)
+	require.NoError(t, err)
+
+	payload, err := gcm.Open(nil, decoded[2], append(decoded[3], decoded[4]...), []byte(parts[0]))
+	require.NoError(t, err)
+
+	return payload
+}
+
+func TestWithEncryption(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	payloads := map[string][]byte{}
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "application/jose", r.Header.Get("Content-Type"))
+
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		payloads[r.URL.Path] = decryptJWE(t, key, string(body))
+
+		switch r.URL.Path {
+		case "/accounts/account-id/cards", "/accounts/account-id/cards/card-id":
+			WriteJson(w, http.StatusOK, `{"cardID": "card-id"}`)
+		default:
+			WriteJson(w, http.StatusOK, `{"bankAccountID": "bank-account-id"}`)
+		}
+	}, moov.WithEncryption(&key.PublicKey))
+
+	card := moov.CreateCard{
+		CardNumber: "4111111111111111",
+		CardCvv:    "123",
+		Expiration: moov.Expiration{Month: "01", Year: "30"},
+		HolderName: "Jules Jackson",
+	}
+	_, err = mc.CreateCard(BgCtx(), "account-id", card)
+	require.NoError(t, err)
+
+	expected, err := json.Marshal(card)
+	require.NoError(t, err)
+	require.JSONEq(t, string(expected), string(payloads["/accounts/account-id/cards"]))
+
+	bankAccount := moov.BankAccount{
+		HolderName:      "Jules Jackson",
+		HolderType:      moov.HolderTypeIndividual,
+		BankAccountType: moov.BankAccountTypeChecking,
+		RoutingNumber:   "273976369",
+		AccountNumber:   "123456789",
+	}
+	_, err = mc.CreateBankAccount(BgCtx(), "account-id", bankAccount)
+	require.NoError(t, err)
+
+	expected, err = json.Marshal(bankAccount)
+	require.NoError(t, err)
+	require.JSONEq(t, string(expected), string(payloads["/accounts/account-id/bank-accounts"]))
+
+	_, err = mc.UpdateCard(BgCtx(), "account-id", "card-id", moov.WithCardCVV("456"))
+	require.NoError(t, err)
+	patch := map[string]any{}
+	require.NoError(t, json.Unmarshal(payloads["/accounts/account-id/cards/card-id"], &patch))
+	require.Equal(t, "456", patch["cardCvv"])
+}
+
+func TestWithEncryption_NotConfigured(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		card := moov.CreateCard{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&card))
+		require.Equal(t, "4111111111111111", card.CardNumber)
+
+		WriteJson(w, http.StatusOK, `{"cardID": "card-id"}`)
+	})
+
+	_, err := mc.CreateCard(BgCtx(), "account-id", moov.CreateCard{CardNumber: "4111111111111111"})
+	require.NoError(t, err)
+}
+
+func TestWithEncryption_NilKey(t *testing.T) {
+	_, err := moov.NewClient(
+		moov.WithCredentials(moov.Credentials{PublicKey: "public", SecretKey: "secret", Host: "api.moov.io"}),
+		moov.WithEncryption(nil))
+	require.ErrorContains(t, err, "encryption public key")
+}
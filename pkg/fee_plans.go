@@ -0,0 +1,169 @@
+package moov
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+var (
+	ErrNoFeePlan          = errors.New("no fee plan with the specified planID was found")
+	ErrNoFeePlanAgreement = errors.New("the account has no active fee plan")
+)
+
+const (
+	FEE_CATEGORY_ACH            = "ach"
+	FEE_CATEGORY_CARD_ACQUIRING = "card-acquiring"
+	FEE_CATEGORY_CARD_PUSH      = "card-push"
+	FEE_CATEGORY_RTP            = "rtp"
+	FEE_CATEGORY_PLATFORM       = "platform"
+
+	FEE_MODEL_FIXED    = "fixed"
+	FEE_MODEL_VARIABLE = "variable"
+	FEE_MODEL_BLENDED  = "blended"
+
+	CARD_ACQUIRING_MODEL_COST_PLUS = "cost-plus"
+	CARD_ACQUIRING_MODEL_FLAT_RATE = "flat-rate"
+
+	FEE_PLAN_AGREEMENT_STATUS_ACTIVE     = "active"
+	FEE_PLAN_AGREEMENT_STATUS_TERMINATED = "terminated"
+)
+
+// FeePlan is the pricing a facilitator can put its accounts on
+type FeePlan struct {
+	PlanID      string `json:"planID,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	// One of CARD_ACQUIRING_MODEL_*
+	CardAcquiringModel string        `json:"cardAcquiringModel,omitempty"`
+	BillableFees       []BillableFee `json:"billableFees,omitempty"`
+	// Least the account is billed each month for the fees of the plan
+	MinimumCommitment AmountDecimal `json:"minimumCommitment,omitempty"`
+	// Fixed fee the account is billed each month
+	MonthlyPlatformFee AmountDecimal `json:"monthlyPlatformFee,omitempty"`
+	CreatedAt          time.Time     `json:"createdAt,omitempty"`
+}
+
+// FeesFor returns the plan's fees in the FEE_CATEGORY_*, ie: all the fees charged on ACH transfers
+func (p FeePlan) FeesFor(category string) []BillableFee {
+	fees := []BillableFee{}
+	for _, fee := range p.BillableFees {
+		if fee.FeeCategory == category {
+			fees = append(fees, fee)
+		}
+	}
+	return fees
+}
+
+// BillableFee is what's charged for one kind of event, ie: an ACH debit or a card payment
+type BillableFee struct {
+	BillableFeeID string `json:"billableFeeID,omitempty"`
+	BillableEvent string `json:"billableEvent,omitempty"`
+	FeeName       string `json:"feeName,omitempty"`
+	// One of FEE_MODEL_*
+	FeeModel string `json:"feeModel,omitempty"`
+	// One of FEE_CATEGORY_*
+	FeeCategory   string         `json:"feeCategory,omitempty"`
+	FeeProperties FeeProperties  `json:"feeProperties,omitempty"`
+	FeeConditions map[string]any `json:"feeConditions,omitempty"`
+}
+
+// FeeProperties is how a fee is computed, a fixed amount per transaction and/or a rate of the transaction amount
+// bounded by a minimum and maximum
+type FeeProperties struct {
+	FixedAmount AmountDecimal `json:"fixedAmount,omitempty"`
+	// Percentage of the transaction amount, ie: "2.9" for 2.9%
+	VariableRate      string        `json:"variableRate,omitempty"`
+	MinPerTransaction AmountDecimal `json:"minPerTransaction,omitempty"`
+	MaxPerTransaction AmountDecimal `json:"maxPerTransaction,omitempty"`
+}
+
+// VariableRateBasisPoints returns the variable rate rounded to the nearest basis point, ie: 290 for 2.9%, or 0 when
+// the fee has no variable rate.
+func (p FeeProperties) VariableRateBasisPoints() (int, error) {
+	if p.VariableRate == "" {
+		return 0, nil
+	}
+
+	bps, err := parseDecimal(p.VariableRate, 2)
+	if err != nil {
+		return 0, err
+	}
+	return int(bps), nil
+}
+
+// FeePlanAgreement is an account being on a fee plan, with the plan's pricing as it was when the account was put on it
+type FeePlanAgreement struct {
+	AgreementID string    `json:"agreementID,omitempty"`
+	PlanID      string    `json:"planID,omitempty"`
+	AccountID   string    `json:"accountID,omitempty"`
+	Name        string    `json:"name,omitempty"`
+	Description string    `json:"description,omitempty"`
+	AcceptedOn  time.Time `json:"acceptedOn,omitempty"`
+	// One of FEE_PLAN_AGREEMENT_STATUS_*
+	Status             string        `json:"status,omitempty"`
+	CardAcquiringModel string        `json:"cardAcquiringModel,omitempty"`
+	BillableFees       []BillableFee `json:"billableFees,omitempty"`
+	// Least the account is billed each month for the fees of the plan
+	MinimumCommitment AmountDecimal `json:"minimumCommitment,omitempty"`
+	// Fixed fee the account is billed each month
+	MonthlyPlatformFee AmountDecimal `json:"monthlyPlatformFee,omitempty"`
+}
+
+// ListFeePlans lists the fee plans accounts can be put on
+// https://docs.moov.io/api/moov-accounts/billing/list-fee-plans/
+func (c Client) ListFeePlans(ctx context.Context) ([]FeePlan, error) {
+	resp, err := c.CallHttp(ctx, Endpoint(http.MethodGet, pathFeePlans), AcceptJson())
+	if err != nil {
+		return nil, err
+	}
+
+	return CompletedListOrError[FeePlan](resp)
+}
+
+// GetAccountFeePlan retrieves the account's active fee plan agreement, ErrNoFeePlanAgreement is returned when the
+// account isn't on a plan
+// https://docs.moov.io/api/moov-accounts/billing/list-fee-plan-agreements/
+func (c Client) GetAccountFeePlan(ctx context.Context, accountID string) (*FeePlanAgreement, error) {
+	resp, err := c.CallHttp(ctx,
+		Endpoint(http.MethodGet, pathFeePlanAgreements, accountID),
+		AcceptJson(),
+		QueryParam("status", FEE_PLAN_AGREEMENT_STATUS_ACTIVE))
+	if err != nil {
+		return nil, err
+	}
+
+	agreements, err := CompletedListOrError[FeePlanAgreement](resp)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, agreement := range agreements {
+		if agreement.Status == FEE_PLAN_AGREEMENT_STATUS_ACTIVE {
+			return &agreement, nil
+		}
+	}
+	return nil, ErrNoFeePlanAgreement
+}
+
+// AssignFeePlan puts the account on the fee plan, replacing the plan it was on
+// https://docs.moov.io/api/moov-accounts/billing/create-fee-plan-agreements/
+func (c Client) AssignFeePlan(ctx context.Context, accountID string, feePlanID string) (*FeePlanAgreement, error) {
+	resp, err := c.CallHttp(ctx,
+		Endpoint(http.MethodPost, pathFeePlanAgreements, accountID),
+		AcceptJson(),
+		JsonBody(map[string]string{"planID": feePlanID}))
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.Status() {
+	case StatusCompleted:
+		return UnmarshalObjectResponse[FeePlanAgreement](resp)
+	case StatusNotFound:
+		return nil, ErrNoFeePlan
+	default:
+		return nil, resp.Error()
+	}
+}
@@ -0,0 +1,124 @@
+package moov_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	moov "github.com/moovfinancial/moov-go/pkg"
+	"github.com/stretchr/testify/require"
+)
+
+const feePlanJson = `{
+	"planID": "plan-id",
+	"name": "Standard",
+	"cardAcquiringModel": "flat-rate",
+	"billableFees": [
+		{
+			"billableFeeID": "card-fee-id",
+			"billableEvent": "card-payment",
+			"feeName": "Card payment",
+			"feeModel": "blended",
+			"feeCategory": "card-acquiring",
+			"feeProperties": {
+				"fixedAmount": {"currency": "USD", "valueDecimal": "30"},
+				"variableRate": "2.9",
+				"minPerTransaction": {"currency": "USD", "valueDecimal": "50"}
+			}
+		},
+		{
+			"billableFeeID": "ach-fee-id",
+			"billableEvent": "ach-debit",
+			"feeName": "ACH debit",
+			"feeModel": "variable",
+			"feeCategory": "ach",
+			"feeProperties": {
+				"variableRate": "0.8",
+				"maxPerTransaction": {"currency": "USD", "valueDecimal": "500"}
+			}
+		}
+	],
+	"minimumCommitment": {"currency": "USD", "valueDecimal": "2500"},
+	"monthlyPlatformFee": {"currency": "USD", "valueDecimal": "1000"},
+	"createdAt": "2024-05-01T14:15:22Z"
+}`
+
+func TestListFeePlans(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		require.Equal(t, "/fee-plans", r.URL.Path)
+
+		WriteJson(w, http.StatusOK, `[`+feePlanJson+`]`)
+	})
+
+	plans, err := mc.ListFeePlans(BgCtx())
+	require.NoError(t, err)
+	require.Len(t, plans, 1)
+
+	plan := plans[0]
+	require.Equal(t, "plan-id", plan.PlanID)
+	require.Equal(t, moov.CARD_ACQUIRING_MODEL_FLAT_RATE, plan.CardAcquiringModel)
+	require.Equal(t, "2500", plan.MinimumCommitment.ValueDecimal)
+
+	card := plan.FeesFor(moov.FEE_CATEGORY_CARD_ACQUIRING)
+	require.Len(t, card, 1)
+	require.Equal(t, "30", card[0].FeeProperties.FixedAmount.ValueDecimal)
+	require.Equal(t, "50", card[0].FeeProperties.MinPerTransaction.ValueDecimal)
+
+	bps, err := card[0].FeeProperties.VariableRateBasisPoints()
+	require.NoError(t, err)
+	require.Equal(t, 290, bps)
+
+	ach := plan.FeesFor(moov.FEE_CATEGORY_ACH)
+	require.Len(t, ach, 1)
+	require.Equal(t, moov.FEE_MODEL_VARIABLE, ach[0].FeeModel)
+	require.Equal(t, "500", ach[0].FeeProperties.MaxPerTransaction.ValueDecimal)
+
+	bps, err = ach[0].FeeProperties.VariableRateBasisPoints()
+	require.NoError(t, err)
+	require.Equal(t, 80, bps)
+
+	require.Empty(t, plan.FeesFor(moov.FEE_CATEGORY_RTP))
+}
+
+func TestAssignFeePlan(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/accounts/account-id/fee-plan-agreements", r.URL.Path)
+
+		switch r.Method {
+		case http.MethodPost:
+			body := map[string]string{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+			if body["planID"] != "plan-id" {
+				WriteJson(w, http.StatusNotFound, `{"error": "fee plan not found"}`)
+				return
+			}
+			WriteJson(w, http.StatusOK, `{"agreementID": "agreement-id", "planID": "plan-id", "accountID": "account-id", "status": "active"}`)
+		case http.MethodGet:
+			require.Equal(t, "active", r.URL.Query().Get("status"))
+			WriteJson(w, http.StatusOK, `[{"agreementID": "agreement-id", "planID": "plan-id", "accountID": "account-id", "status": "active"}]`)
+		}
+	})
+
+	agreement, err := mc.AssignFeePlan(BgCtx(), "account-id", "plan-id")
+	require.NoError(t, err)
+	require.Equal(t, "agreement-id", agreement.AgreementID)
+	require.Equal(t, moov.FEE_PLAN_AGREEMENT_STATUS_ACTIVE, agreement.Status)
+
+	_, err = mc.AssignFeePlan(BgCtx(), "account-id", "missing-plan-id")
+	require.ErrorIs(t, err, moov.ErrNoFeePlan)
+
+	agreement, err = mc.GetAccountFeePlan(BgCtx(), "account-id")
+	require.NoError(t, err)
+	require.Equal(t, "plan-id", agreement.PlanID)
+}
+
+func TestGetAccountFeePlan_None(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		WriteJson(w, http.StatusOK, `[]`)
+	})
+
+	_, err := mc.GetAccountFeePlan(BgCtx(), "account-id")
+	require.ErrorIs(t, err, moov.ErrNoFeePlanAgreement)
+}
@@ -0,0 +1,107 @@
+package moov
+
+import (
+	"context"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"time"
+)
+
+var (
+	ErrNoFile = errors.New("a file and filename are required to upload a file")
+)
+
+const (
+	FILE_PURPOSE_BUSINESS_VERIFICATION       = "business_verification"
+	FILE_PURPOSE_REPRESENTATIVE_VERIFICATION = "representative_verification"
+	FILE_PURPOSE_INDIVIDUAL_VERIFICATION     = "individual_verification"
+	FILE_PURPOSE_MERCHANT_UNDERWRITING       = "merchant_underwriting"
+	FILE_PURPOSE_ACCOUNT_REQUIREMENT         = "account_requirement"
+	FILE_PURPOSE_IDENTITY_VERIFICATION       = "identity_verification"
+)
+
+// FileUpload is a document uploaded for an account, like a bank statement or EIN letter for underwriting
+type FileUpload struct {
+	// One of FILE_PURPOSE_*
+	Purpose string
+
+	// File is read as the upload is sent, so it isn't held in memory
+	File     io.Reader
+	Filename string
+
+	// Optional JSON metadata stored with the file
+	Metadata string
+}
+
+type File struct {
+	FileID         string    `json:"fileID,omitempty"`
+	FileName       string    `json:"fileName,omitempty"`
+	AccountID      string    `json:"accountID,omitempty"`
+	FilePurpose    string    `json:"filePurpose,omitempty"`
+	FileStatus     string    `json:"fileStatus,omitempty"`
+	Metadata       string    `json:"metadata,omitempty"`
+	DecisionReason string    `json:"decisionReason,omitempty"`
+	FileSizeBytes  int       `json:"fileSizeBytes,omitempty"`
+	CreatedOn      time.Time `json:"createdOn,omitempty"`
+	UpdatedOn      time.Time `json:"updatedOn,omitempty"`
+}
+
+// UploadFile uploads a document for the account. The file is streamed to Moov rather than buffered, so the call isn't
+// retried and a failed upload has to be made again with a fresh reader.
+// https://docs.moov.io/api/moov-accounts/files/upload/
+func (c Client) UploadFile(ctx context.Context, accountID string, file FileUpload) (*File, error) {
+	if file.File == nil || file.Filename == "" {
+		return nil, ErrNoFile
+	}
+
+	resp, err := c.CallHttp(ctx,
+		Endpoint(http.MethodPost, pathFiles, accountID),
+		AcceptJson(),
+		multipartStream(func(w *multipart.Writer) error {
+			if err := w.WriteField("filePurpose", file.Purpose); err != nil {
+				return err
+			}
+			if file.Metadata != "" {
+				if err := w.WriteField("metadata", file.Metadata); err != nil {
+					return err
+				}
+			}
+
+			part, err := w.CreateFormFile("file", file.Filename)
+			if err != nil {
+				return err
+			}
+
+			_, err = io.Copy(part, file.File)
+			return err
+		}))
+	if err != nil {
+		return nil, err
+	}
+
+	return CompletedObjectOrError[File](resp)
+}
+
+// ListFiles lists the files uploaded for the account
+// https://docs.moov.io/api/moov-accounts/files/list/
+func (c Client) ListFiles(ctx context.Context, accountID string) ([]File, error) {
+	resp, err := c.CallHttp(ctx, Endpoint(http.MethodGet, pathFiles, accountID), AcceptJson())
+	if err != nil {
+		return nil, err
+	}
+
+	return CompletedListOrError[File](resp)
+}
+
+// GetFile retrieves a file uploaded for the account
+// https://docs.moov.io/api/moov-accounts/files/get/
+func (c Client) GetFile(ctx context.Context, accountID string, fileID string) (*File, error) {
+	resp, err := c.CallHttp(ctx, Endpoint(http.MethodGet, pathFile, accountID, fileID), AcceptJson())
+	if err != nil {
+		return nil, err
+	}
+
+	return CompletedObjectOrError[File](resp)
+}
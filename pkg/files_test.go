@@ -0,0 +1,133 @@
+package moov_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	moov "github.com/moovfinancial/moov-go/pkg"
+	"github.com/stretchr/testify/require"
+)
+
+// gatedReader returns first, then waits for the gate to open before returning rest. A client that buffers the whole
+// file before sending it never opens the gate.
+type gatedReader struct {
+	first, rest []byte
+	gate        chan struct{}
+	reads       int
+}
+
+func (r *gatedReader) Read(p []byte) (int, error) {
+	r.reads++
+	switch r.reads {
+	case 1:
+		return copy(p, r.first), nil
+	case 2:
+		select {
+		case <-r.gate:
+		case <-time.After(2 * time.Second):
+			return 0, errors.New("file was buffered instead of streamed")
+		}
+		return copy(p, r.rest), nil
+	default:
+		return 0, io.EOF
+	}
+}
+
+func TestUploadFile(t *testing.T) {
+	file := &gatedReader{
+		first: []byte("%PDF-1.4 first chunk "),
+		rest:  []byte("rest of the statement"),
+		gate:  make(chan struct{}),
+	}
+
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/accounts/account-id/files", r.URL.Path)
+
+		mr, err := r.MultipartReader()
+		require.NoError(t, err)
+
+		fields := map[string]string{}
+		for {
+			part, err := mr.NextPart()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+
+			if part.FormName() != "file" {
+				value, err := io.ReadAll(part)
+				require.NoError(t, err)
+				fields[part.FormName()] = string(value)
+				continue
+			}
+
+			require.Equal(t, "statement.pdf", part.FileName())
+
+			// the first chunk arrives before the rest of the file has been read
+			first := make([]byte, len(file.first))
+			_, err = io.ReadFull(part, first)
+			require.NoError(t, err)
+			close(file.gate)
+
+			rest, err := io.ReadAll(part)
+			require.NoError(t, err)
+			fields["file"] = string(first) + string(rest)
+		}
+
+		require.Equal(t, map[string]string{
+			"filePurpose": moov.FILE_PURPOSE_BUSINESS_VERIFICATION,
+			"metadata":    `{"period":"2024-01"}`,
+			"file":        "%PDF-1.4 first chunk rest of the statement",
+		}, fields)
+
+		WriteJson(w, http.StatusOK, `{"fileID":"file-id","fileName":"statement.pdf","accountID":"account-id","filePurpose":"business_verification","fileStatus":"pending"}`)
+	})
+
+	uploaded, err := mc.UploadFile(BgCtx(), "account-id", moov.FileUpload{
+		Purpose:  moov.FILE_PURPOSE_BUSINESS_VERIFICATION,
+		File:     file,
+		Filename: "statement.pdf",
+		Metadata: `{"period":"2024-01"}`,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "file-id", uploaded.FileID)
+	require.Equal(t, "pending", uploaded.FileStatus)
+}
+
+func TestUploadFile_NoFile(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("upload without a file should not be sent")
+	})
+
+	_, err := mc.UploadFile(BgCtx(), "account-id", moov.FileUpload{Purpose: moov.FILE_PURPOSE_BUSINESS_VERIFICATION})
+	require.ErrorIs(t, err, moov.ErrNoFile)
+
+	_, err = mc.UploadFile(BgCtx(), "account-id", moov.FileUpload{File: bytes.NewReader([]byte("statement"))})
+	require.ErrorIs(t, err, moov.ErrNoFile)
+}
+
+func TestListFiles(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/accounts/account-id/files":
+			WriteJson(w, http.StatusOK, `[{"fileID":"file-1"},{"fileID":"file-2"}]`)
+		case "/accounts/account-id/files/file-2":
+			WriteJson(w, http.StatusOK, `{"fileID":"file-2","fileStatus":"approved"}`)
+		default:
+			WriteJson(w, http.StatusNotFound, `{}`)
+		}
+	})
+
+	files, err := mc.ListFiles(BgCtx(), "account-id")
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+
+	file, err := mc.GetFile(BgCtx(), "account-id", "file-2")
+	require.NoError(t, err)
+	require.Equal(t, "approved", file.FileStatus)
+}
@@ -0,0 +1,103 @@
+package moov
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"time"
+)
+
+type hooks struct {
+	request  []func(*http.Request)
+	response []func(*http.Response, time.Duration)
+	errors   []func(*http.Request, error, time.Duration)
+
+	// Whether hooks see the request's credentials and sensitive bodies
+	unredacted bool
+}
+
+// WithRequestHook calls fn with every request CallHttp sends, including retries. The Authorization header is removed
+// from the request fn sees unless WithHookRedaction(false) is set, as is the body of calls sending credentials or card
+// and bank account numbers.
+func WithRequestHook(fn func(*http.Request)) ClientConfigurable {
+	return func(c *Client) error {
+		c.hooks.request = append(c.hooks.request, fn)
+		return nil
+	}
+}
+
+// WithResponseHook calls fn with every response CallHttp receives along with how long the request took. The body has
+// already been read by the client, fn gets its own copy to read. Requests that fail without a response are passed to
+// the hooks set with WithErrorHook instead.
+func WithResponseHook(fn func(*http.Response, time.Duration)) ClientConfigurable {
+	return func(c *Client) error {
+		c.hooks.response = append(c.hooks.response, fn)
+		return nil
+	}
+}
+
+// WithErrorHook calls fn with every request CallHttp sends that fails without a response, ie: a network error or a
+// timeout, along with the error and how long the request took. The request is redacted like it is for request hooks.
+func WithErrorHook(fn func(*http.Request, error, time.Duration)) ClientConfigurable {
+	return func(c *Client) error {
+		c.hooks.errors = append(c.hooks.errors, fn)
+		return nil
+	}
+}
+
+// WithHookRedaction sets whether credentials are removed from the requests passed to hooks, which they are by default.
+// Only turn it off when the hooks are trusted with the API keys.
+func WithHookRedaction(enabled bool) ClientConfigurable {
+	return func(c *Client) error {
+		c.hooks.unredacted = !enabled
+		return nil
+	}
+}
+
+// observed is the copy of the request hooks are given, with credentials removed unless redaction is turned off. The
+// body is withheld when sensitive is set, ie: token calls sending the secret key or card numbers.
+func (h hooks) observed(req *http.Request, sensitive bool) *http.Request {
+	if len(h.request) == 0 && len(h.response) == 0 && len(h.errors) == 0 {
+		return nil
+	}
+
+	// hooks get their own copy of the body so reading it doesn't drain the one being sent
+	observed := req.Clone(req.Context())
+	observed.Body = http.NoBody
+	if sensitive && !h.unredacted {
+		observed.ContentLength = 0
+		observed.GetBody = nil
+	} else if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			observed.Body = body
+		}
+	}
+
+	if !h.unredacted {
+		observed.Header.Del("Authorization")
+	}
+	return observed
+}
+
+func (h hooks) beforeRequest(req *http.Request) {
+	for _, fn := range h.request {
+		fn(req)
+	}
+}
+
+// afterResponse passes hooks a copy of the response whose Request is the observed request
+func (h hooks) afterResponse(req *http.Request, resp *http.Response, body []byte, elapsed time.Duration) {
+	for _, fn := range h.response {
+		observed := *resp
+		observed.Header = resp.Header.Clone()
+		observed.Body = io.NopCloser(bytes.NewReader(body))
+		observed.Request = req
+		fn(&observed, elapsed)
+	}
+}
+
+func (h hooks) afterError(req *http.Request, err error, elapsed time.Duration) {
+	for _, fn := range h.errors {
+		fn(req, err, elapsed)
+	}
+}
@@ -0,0 +1,112 @@
+package moov_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	moov "github.com/moovfinancial/moov-go/pkg"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHooks(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		require.NotEmpty(t, r.Header.Get("Authorization"))
+		WriteJson(w, http.StatusOK, `{"disputeID": "dispute-id"}`)
+	}
+
+	t.Run("redacted", func(t *testing.T) {
+		requests := []*http.Request{}
+		responses := []*http.Response{}
+		elapsed := time.Duration(-1)
+
+		mc := NewMockClient(t, handler,
+			moov.WithRequestHook(func(r *http.Request) {
+				requests = append(requests, r)
+			}),
+			moov.WithResponseHook(func(r *http.Response, d time.Duration) {
+				responses = append(responses, r)
+				elapsed = d
+			}))
+
+		_, err := mc.GetDispute(BgCtx(), "dispute-id")
+		require.NoError(t, err)
+
+		require.Len(t, requests, 1)
+		require.Equal(t, http.MethodGet, requests[0].Method)
+		require.Equal(t, "/disputes/dispute-id", requests[0].URL.Path)
+		require.Empty(t, requests[0].Header.Get("Authorization"))
+
+		require.Len(t, responses, 1)
+		require.Equal(t, http.StatusOK, responses[0].StatusCode)
+		require.Equal(t, "/disputes/dispute-id", responses[0].Request.URL.Path)
+		require.GreaterOrEqual(t, elapsed, time.Duration(0))
+
+		body, err := io.ReadAll(responses[0].Body)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"disputeID": "dispute-id"}`, string(body))
+	})
+
+	t.Run("unredacted", func(t *testing.T) {
+		auth := ""
+		mc := NewMockClient(t, handler,
+			moov.WithHookRedaction(false),
+			moov.WithRequestHook(func(r *http.Request) {
+				auth = r.Header.Get("Authorization")
+			}))
+
+		_, err := mc.GetDispute(BgCtx(), "dispute-id")
+		require.NoError(t, err)
+		require.NotEmpty(t, auth)
+	})
+
+	t.Run("sensitive bodies withheld", func(t *testing.T) {
+		bodies := map[string]string{}
+		mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/oauth2/token":
+				WriteJson(w, http.StatusOK, `{"access_token": "token"}`)
+			default:
+				WriteJson(w, http.StatusOK, `{"cardID": "card-id"}`)
+			}
+		}, moov.WithRequestHook(func(r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			bodies[r.URL.Path] = string(body)
+		}))
+
+		_, err := mc.PingAccessToken(BgCtx())
+		require.NoError(t, err)
+
+		_, err = mc.CreateCard(BgCtx(), "account-id", moov.CreateCard{CardNumber: "4111111111111111", CardCvv: "123"})
+		require.NoError(t, err)
+
+		require.Len(t, bodies, 2)
+		for path, body := range bodies {
+			require.Empty(t, body, path)
+		}
+	})
+
+	t.Run("transport error", func(t *testing.T) {
+		called := false
+		mc := NewMockClient(t, handler,
+			moov.WithHttpClient(&http.Client{Transport: roundTripFunc(func(*http.Request) (*http.Response, error) {
+				return nil, errors.New("connection refused")
+			})}),
+			moov.WithResponseHook(func(*http.Response, time.Duration) {
+				t.Fatal("response hook called without a response")
+			}),
+			moov.WithErrorHook(func(r *http.Request, err error, _ time.Duration) {
+				called = true
+				require.Equal(t, "/disputes/dispute-id", r.URL.Path)
+				require.Empty(t, r.Header.Get("Authorization"))
+				require.ErrorContains(t, err, "connection refused")
+			}))
+
+		_, err := mc.GetDispute(BgCtx(), "dispute-id")
+		require.Error(t, err)
+		require.True(t, called)
+	})
+}
@@ -7,7 +7,9 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 )
 
 func DefaultHttpClient() *http.Client {
@@ -16,14 +18,51 @@ func DefaultHttpClient() *http.Client {
 	}
 }
 
+// endpointURL joins the path onto the client's base URL or host. The host may include a scheme and a path prefix for
+// when Moov is reached through a gateway, ie: https://gw.internal/moov/v1, and https is used when it has no scheme.
+func (c *Client) endpointURL(path string) string {
+	host := c.Credentials.Host
+	if c.baseURL != "" {
+		host = c.baseURL
+	}
+	if !strings.Contains(host, "://") {
+		host = "https://" + host
+	}
+
+	return strings.TrimRight(host, "/") + "/" + strings.TrimLeft(path, "/")
+}
+
+// requestContext bounds the context of a request by the client's timeout, the context's own deadline is kept when
+// it's sooner
+func (c *Client) requestContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, c.timeout)
+}
+
+// cancelOnClose releases the request's context once its response body is closed, as the body is read under it
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	defer b.cancel()
+	return b.ReadCloser.Close()
+}
+
 // GetHTTPResponse performs an HTTP request and returns the response body or an error.
-func (c *Client) GetHTTPResponse(method string, url string, data any, header map[string]string) ([]byte, int, error) {
+func (c *Client) GetHTTPResponse(ctx context.Context, method string, url string, data any, header map[string]string) ([]byte, int, error) {
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
+
 	reqBody, err := httpRequestBody(data)
 	if err != nil {
 		return nil, 0, err
 	}
 
-	req, err := http.NewRequestWithContext(context.Background(), method, url, reqBody)
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -37,7 +76,7 @@ func (c *Client) GetHTTPResponse(method string, url string, data any, header map
 
 	resp, err := c.HttpClient.Do(req)
 	if err != nil {
-		return nil, resp.StatusCode, err
+		return nil, 0, err
 	}
 	defer resp.Body.Close()
 
@@ -59,22 +98,77 @@ func httpRequestBody(data any) (io.Reader, error) {
 	return nil, nil
 }
 
+// Do makes an authenticated call to any Moov endpoint, for endpoints the client doesn't have a method for yet. It
+// uses the client's credentials, host, retries and hooks like every other call, for example:
+//
+//	resp, err := client.Do(ctx, moov.Endpoint(http.MethodGet, "/accounts/%s/files", accountID), moov.AcceptJson())
+//
+// The response body can be decoded into any type with CallResponse.Unmarshal, and CallResponse.Error returns an
+// *APIError for unsuccessful responses.
+func (c Client) Do(ctx context.Context, endpoint EndpointArg, args ...callArg) (CallResponse, error) {
+	return c.CallHttp(ctx, endpoint, args...)
+}
+
 func (c *Client) CallHttp(ctx context.Context, endpoint EndpointArg, args ...callArg) (CallResponse, error) {
 	call, err := newCall(endpoint, args...)
 	if err != nil {
 		return nil, err
 	}
 
-	url := fmt.Sprintf("https://%s%s", c.Credentials.Host, call.path)
+	if c.tokens != nil && call.token == nil && !call.basicAuth {
+		token, err := c.tokens.get(ctx, c)
+		if err != nil {
+			return nil, err
+		}
+		call.token = &token
+	}
 
-	req, err := http.NewRequestWithContext(ctx, call.method, url, call.body)
+	if err := c.encryptBody(call); err != nil {
+		return nil, err
+	}
+
+	if call.paged && c.defaultPageSize > 0 && !call.params.Has("count") {
+		call.params.Set("count", strconv.Itoa(c.defaultPageSize))
+	}
+
+	url := c.endpointURL(call.path)
+	body, replayable := replayableBody(call.body)
+
+	start := time.Now()
+	for attempt := 1; ; attempt++ {
+		resp, err := c.callHttp(ctx, call, url, body())
+		if !retryable(ctx, resp, err) {
+			return resp, err
+		}
+
+		wait, ok := c.retry.wait(attempt, time.Since(start))
+		ok = ok && replayable && idempotent(call)
+		c.logRetryable(ctx, call, attempt, resp, err, ok)
+		if !ok {
+			return resp, err
+		}
+
+		if sleep(ctx, wait) != nil {
+			return resp, err
+		}
+	}
+}
+
+// callHttp makes a single attempt of the call
+func (c *Client) callHttp(ctx context.Context, call *callBuilder, url string, body io.Reader) (CallResponse, error) {
+	ctx, cancel := c.requestContext(ctx)
+
+	req, err := http.NewRequestWithContext(ctx, call.method, url, body)
 	if err != nil {
+		cancel()
 		return nil, err
 	}
 
 	qry := req.URL.Query()
-	for k, v := range call.params {
-		qry.Add(k, v)
+	for k, vs := range call.params {
+		for _, v := range vs {
+			qry.Add(k, v)
+		}
 	}
 	req.URL.RawQuery = qry.Encode()
 
@@ -88,17 +182,37 @@ func (c *Client) CallHttp(ctx context.Context, endpoint EndpointArg, args ...cal
 		req.SetBasicAuth(c.Credentials.PublicKey, c.Credentials.SecretKey)
 	}
 
+	observed := c.hooks.observed(req, call.basicAuth || call.encrypted)
+	c.hooks.beforeRequest(observed)
+	start := time.Now()
+
 	resp, err := c.HttpClient.Do(req)
+	c.logRequest(ctx, req, resp, err, time.Since(start))
 	if err != nil {
+		c.hooks.afterError(observed, err, time.Since(start))
+		cancel()
 		return nil, err
 	}
+	if c.metrics != nil {
+		c.metrics.ObserveRequest(call.endpoint, statusFromCode(resp.StatusCode), time.Since(start))
+	}
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+
+	if call.streamed && statusFromCode(resp.StatusCode) == StatusCompleted {
+		c.hooks.afterResponse(observed, resp, nil, time.Since(start))
+		return &httpCallResponse{
+			resp:   resp,
+			stream: resp.Body,
+		}, nil
+	}
 	defer resp.Body.Close()
 
-	body, _ := io.ReadAll(resp.Body)
+	respBody, _ := io.ReadAll(resp.Body)
+	c.hooks.afterResponse(observed, resp, respBody, time.Since(start))
 
 	return &httpCallResponse{
 		resp: resp,
-		body: body,
+		body: respBody,
 	}, nil
 }
 
@@ -107,14 +221,31 @@ var _ CallResponse = &httpCallResponse{}
 type httpCallResponse struct {
 	resp *http.Response
 	body []byte
+
+	// Unread body of a streamed response
+	stream io.ReadCloser
+}
+
+// responseStream returns the unread body of a successful streamed call, nil for any other response
+func responseStream(resp CallResponse) io.ReadCloser {
+	if r, ok := resp.(*httpCallResponse); ok {
+		return r.stream
+	}
+	return nil
 }
 
 func (r *httpCallResponse) Status() CallStatus {
-	switch r.resp.StatusCode {
+	return statusFromCode(r.resp.StatusCode)
+}
+
+func statusFromCode(code int) CallStatus {
+	switch code {
 	case http.StatusOK, http.StatusNoContent:
 		return StatusCompleted
-	case http.StatusCreated:
+	case http.StatusCreated, http.StatusAccepted:
 		return StatusStarted
+	case http.StatusNotModified:
+		return StatusNotModified
 
 	case http.StatusBadRequest:
 		return StatusBadRequest
@@ -138,16 +269,20 @@ func (r *httpCallResponse) Status() CallStatus {
 	}
 }
 
+func (r *httpCallResponse) Header(name string) string {
+	return r.resp.Header.Get(name)
+}
+
 func (r *httpCallResponse) Unmarshal(item any) error {
 	ct := strings.ToLower(r.resp.Header.Get("content-type"))
 
-	if _, ok := item.(*string); ok {
-		item = string(r.body)
+	if s, ok := item.(*string); ok {
+		*s = string(r.body)
 		return nil
 	}
 
-	if _, ok := item.([]byte); ok {
-		item = r.body
+	if b, ok := item.(*[]byte); ok {
+		*b = append((*b)[:0], r.body...)
 		return nil
 	}
 
@@ -164,15 +299,11 @@ func (r *httpCallResponse) Error() error {
 	case StatusCompleted, StatusStarted:
 		return nil
 	default:
-		return &httpCallError{
-			status:     r.Status(),
-			requestId:  r.resp.Header.Get("X-Request-ID"),
-			statusCode: r.resp.StatusCode,
-		}
+		return newAPIError(r.resp.StatusCode, r.resp.Header.Get("X-Request-ID"), r.body)
 	}
 }
 
-var _ HttpCallError = &httpCallError{}
+var _ HttpCallError = &APIError{}
 
 type HttpCallError interface {
 	error
@@ -181,24 +312,63 @@ type HttpCallError interface {
 	StatusCode() int
 }
 
-type httpCallError struct {
+// APIError is the error returned for any unsuccessful response from Moov. Use errors.As to get at the status code
+// and body of responses the client doesn't have a dedicated error for. Rate limited responses match ErrRateLimit.
+type APIError struct {
+	// Raw body of the response
+	Body []byte
+
+	// Message Moov gave for the error, empty when the body didn't include one
+	Message string
+
 	status     CallStatus
 	requestId  string
 	statusCode int
 }
 
-func (he *httpCallError) Status() CallStatus {
-	return he.status
+func newAPIError(statusCode int, requestId string, body []byte) *APIError {
+	e := &APIError{
+		Body:       body,
+		status:     statusFromCode(statusCode),
+		requestId:  requestId,
+		statusCode: statusCode,
+	}
+
+	msg := struct {
+		Error   string `json:"error"`
+		Message string `json:"message"`
+	}{}
+	if json.Unmarshal(body, &msg) == nil {
+		e.Message = msg.Error
+		if e.Message == "" {
+			e.Message = msg.Message
+		}
+	}
+
+	return e
+}
+
+func (e *APIError) Status() CallStatus {
+	return e.status
 }
 
-func (he *httpCallError) RequestId() string {
-	return he.requestId
+func (e *APIError) RequestId() string {
+	return e.requestId
 }
 
-func (he *httpCallError) StatusCode() int {
-	return he.statusCode
+func (e *APIError) StatusCode() int {
+	return e.statusCode
 }
 
-func (he *httpCallError) Error() string {
-	return fmt.Sprintf("error from moov - status: %s http.request_id: %s http.status_code: %d", he.status.Name, he.requestId, he.statusCode)
+// Is lets errors.Is match rate limited responses with ErrRateLimit, like the calls that return it directly
+func (e *APIError) Is(target error) bool {
+	return target == ErrRateLimit && e.status == StatusRateLimited
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("error from moov - status: %s http.request_id: %s http.status_code: %d", e.status.Name, e.requestId, e.statusCode)
+	if e.Message != "" {
+		msg += " message: " + e.Message
+	}
+	return msg
 }
@@ -0,0 +1,114 @@
+package moov
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+var (
+	ErrInvalidRoutingNumber = errors.New("routing number must be 9 digits with a valid check digit")
+	ErrNoInstitution        = errors.New("no institution with the specified routing number was found")
+)
+
+// Rail is a network money moves over
+type Rail string
+
+const (
+	RAIL_ACH  Rail = "ach"
+	RAIL_RTP  Rail = "rtp"
+	RAIL_WIRE Rail = "wire"
+	RAIL_CARD Rail = "card"
+	// Moov's own ledger, for transfers in and out of wallets
+	RAIL_MOOV Rail = "moov"
+)
+
+// Institution is the bank a routing number belongs to and the rails it can receive payments on
+type Institution struct {
+	RoutingNumber string  `json:"routingNumber,omitempty"`
+	Name          string  `json:"name,omitempty"`
+	Address       Address `json:"address,omitempty"`
+	// RAIL_* the institution participates in
+	Rails []Rail `json:"rails,omitempty"`
+}
+
+type institutionParticipant struct {
+	Name          string  `json:"name"`
+	RoutingNumber string  `json:"routingNumber"`
+	Address       Address `json:"address"`
+}
+
+type institutionSearch struct {
+	ACH  []institutionParticipant `json:"ach"`
+	RTP  []institutionParticipant `json:"rtp"`
+	Wire []institutionParticipant `json:"wire"`
+}
+
+// LookupInstitution finds the bank for a routing number. The routing number's check digit is validated before
+// calling Moov, returning ErrInvalidRoutingNumber when it's wrong.
+// https://docs.moov.io/api/enrichment/form-shortening/institutions/get/
+func (c Client) LookupInstitution(ctx context.Context, routingNumber string) (*Institution, error) {
+	if err := ValidateRoutingNumber(routingNumber); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.CallHttp(ctx,
+		Endpoint(http.MethodGet, pathInstitutions),
+		AcceptJson(),
+		QueryParam("routingNumber", routingNumber))
+	if err != nil {
+		return nil, err
+	}
+
+	search, err := CompletedObjectOrError[institutionSearch](resp)
+	if err != nil {
+		return nil, err
+	}
+
+	institution := &Institution{RoutingNumber: routingNumber}
+	for _, rail := range []struct {
+		name         Rail
+		participants []institutionParticipant
+	}{
+		{RAIL_ACH, search.ACH},
+		{RAIL_RTP, search.RTP},
+		{RAIL_WIRE, search.Wire},
+	} {
+		if len(rail.participants) == 0 {
+			continue
+		}
+
+		institution.Rails = append(institution.Rails, rail.name)
+		if institution.Name == "" {
+			institution.Name = rail.participants[0].Name
+			institution.Address = rail.participants[0].Address
+		}
+	}
+
+	if len(institution.Rails) == 0 {
+		return nil, ErrNoInstitution
+	}
+	return institution, nil
+}
+
+// ValidateRoutingNumber checks the routing number is 9 digits and its ABA check digit is correct, returning
+// ErrInvalidRoutingNumber when it isn't.
+func ValidateRoutingNumber(rn string) error {
+	if len(rn) != 9 {
+		return ErrInvalidRoutingNumber
+	}
+
+	weights := [3]int{3, 7, 1}
+	sum := 0
+	for i, r := range rn {
+		if r < '0' || r > '9' {
+			return ErrInvalidRoutingNumber
+		}
+		sum += int(r-'0') * weights[i%3]
+	}
+
+	if sum%10 != 0 {
+		return ErrInvalidRoutingNumber
+	}
+	return nil
+}
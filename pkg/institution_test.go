@@ -0,0 +1,86 @@
+package moov_test
+
+import (
+	"net/http"
+	"testing"
+
+	moov "github.com/moovfinancial/moov-go/pkg"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookupInstitution(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/institutions", r.URL.Path)
+
+		switch r.URL.Query().Get("routingNumber") {
+		case "273976369":
+			WriteJson(w, http.StatusOK, `{
+				"ach": [{"name": "VERIDIAN CREDIT UNION", "routingNumber": "273976369", "address": {"city": "WATERLOO", "stateOrProvince": "IA"}}],
+				"rtp": [],
+				"wire": [{"name": "VERIDIAN CU", "routingNumber": "273976369"}]
+			}`)
+		default:
+			WriteJson(w, http.StatusOK, `{"ach": [], "rtp": [], "wire": []}`)
+		}
+	})
+
+	institution, err := mc.LookupInstitution(BgCtx(), "273976369")
+	require.NoError(t, err)
+	require.Equal(t, "VERIDIAN CREDIT UNION", institution.Name)
+	require.Equal(t, "WATERLOO", institution.Address.City)
+	require.Equal(t, []moov.Rail{moov.RAIL_ACH, moov.RAIL_WIRE}, institution.Rails)
+
+	_, err = mc.LookupInstitution(BgCtx(), "021000021")
+	require.ErrorIs(t, err, moov.ErrNoInstitution)
+}
+
+func TestLookupInstitution_InvalidCheckDigit(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Fatalf("unexpected request to %s", r.URL.Path)
+	})
+
+	_, err := mc.LookupInstitution(BgCtx(), "273976368")
+	require.ErrorIs(t, err, moov.ErrInvalidRoutingNumber)
+}
+
+func TestValidateRoutingNumber(t *testing.T) {
+	tests := []struct {
+		routingNumber string
+		valid         bool
+	}{
+		{"273976369", true},
+		{"021000021", true},
+		{"011000015", true},
+		{"122105278", true},
+		{"273976368", false},
+		{"021000022", false},
+		{"12345678", false},
+		{"1234567890", false},
+		{"27397636a", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.routingNumber, func(t *testing.T) {
+			err := moov.ValidateRoutingNumber(tt.routingNumber)
+			if tt.valid {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, moov.ErrInvalidRoutingNumber)
+			}
+		})
+	}
+}
+
+func TestCreateBankAccount_InvalidRoutingNumber(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Fatalf("unexpected request to %s", r.URL.Path)
+	})
+
+	_, err := mc.CreateBankAccount(BgCtx(), "account-id", moov.BankAccount{
+		HolderName:    "Jules Jackson",
+		RoutingNumber: "273976368",
+		AccountNumber: "123456789",
+	})
+	require.ErrorIs(t, err, moov.ErrInvalidRoutingNumber)
+}
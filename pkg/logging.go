@@ -0,0 +1,84 @@
+package moov
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// loggedRedactedHeaders are request headers whose values are left out of logs as they hold credentials
+var loggedRedactedHeaders = map[string]bool{
+	"Authorization": true,
+	"Cookie":        true,
+}
+
+// WithLogger logs the calls the client makes to logger. Every request is logged at debug level with its method, path,
+// status and duration, and failures that can be retried are logged at warn level. Credentials are redacted from the
+// logged headers. Nothing is logged by default.
+func WithLogger(logger *slog.Logger) ClientConfigurable {
+	return func(c *Client) error {
+		c.logger = logger
+		return nil
+	}
+}
+
+// logRequest logs an attempt of a call at debug level, resp is nil when the request failed with err
+func (c *Client) logRequest(ctx context.Context, req *http.Request, resp *http.Response, err error, elapsed time.Duration) {
+	if c.logger == nil || !c.logger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("path", req.URL.Path),
+		slog.Duration("duration", elapsed),
+		slog.Any("headers", loggedHeaders(req.Header)),
+	}
+	if resp != nil {
+		attrs = append(attrs,
+			slog.Int("status_code", resp.StatusCode),
+			slog.String("status", statusFromCode(resp.StatusCode).Name),
+			slog.String("request_id", resp.Header.Get("X-Request-ID")))
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+
+	c.logger.LogAttrs(ctx, slog.LevelDebug, "moov request", attrs...)
+}
+
+// logRetryable logs an attempt of a call that failed in a way that can be retried at warn level
+func (c *Client) logRetryable(ctx context.Context, call *callBuilder, attempt int, resp CallResponse, err error, retrying bool) {
+	if c.logger == nil {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", call.method),
+		slog.String("path", call.path),
+		slog.Int("attempt", attempt),
+		slog.Bool("retrying", retrying),
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	} else {
+		attrs = append(attrs, slog.String("status", resp.Status().Name))
+	}
+
+	c.logger.LogAttrs(ctx, slog.LevelWarn, "moov request failed", attrs...)
+}
+
+// loggedHeaders returns the headers as a group of attributes with credentials redacted
+func loggedHeaders(header http.Header) slog.Value {
+	attrs := make([]slog.Attr, 0, len(header))
+	for name, values := range header {
+		value := strings.Join(values, ", ")
+		if loggedRedactedHeaders[http.CanonicalHeaderKey(name)] {
+			value = "REDACTED"
+		}
+		attrs = append(attrs, slog.String(name, value))
+	}
+	return slog.GroupValue(attrs...)
+}
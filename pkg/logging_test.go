@@ -0,0 +1,98 @@
+package moov_test
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"sync"
+	"testing"
+
+	moov "github.com/moovfinancial/moov-go/pkg"
+	"github.com/stretchr/testify/require"
+)
+
+// captureHandler is a slog.Handler keeping the records it's given
+type captureHandler struct {
+	mu      sync.Mutex
+	records []slog.Record
+}
+
+func (h *captureHandler) Enabled(context.Context, slog.Level) bool { return true }
+func (h *captureHandler) WithAttrs([]slog.Attr) slog.Handler       { return h }
+func (h *captureHandler) WithGroup(string) slog.Handler            { return h }
+
+func (h *captureHandler) Handle(_ context.Context, r slog.Record) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.records = append(h.records, r.Clone())
+	return nil
+}
+
+func recordAttrs(r slog.Record) map[string]slog.Value {
+	attrs := map[string]slog.Value{}
+	r.Attrs(func(a slog.Attr) bool {
+		attrs[a.Key] = a.Value.Resolve()
+		return true
+	})
+	return attrs
+}
+
+func TestWithLogger(t *testing.T) {
+	handler := &captureHandler{}
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-ID", "request-id")
+		WriteJson(w, http.StatusOK, `{"disputeID": "dispute-id"}`)
+	}, moov.WithLogger(slog.New(handler)))
+
+	_, err := mc.GetDispute(BgCtx(), "dispute-id")
+	require.NoError(t, err)
+
+	require.Len(t, handler.records, 1)
+	record := handler.records[0]
+	require.Equal(t, slog.LevelDebug, record.Level)
+	require.Equal(t, "moov request", record.Message)
+
+	attrs := recordAttrs(record)
+	require.Equal(t, http.MethodGet, attrs["method"].String())
+	require.Equal(t, "/disputes/dispute-id", attrs["path"].String())
+	require.Equal(t, int64(http.StatusOK), attrs["status_code"].Int64())
+	require.Equal(t, "completed", attrs["status"].String())
+	require.Equal(t, "request-id", attrs["request_id"].String())
+	require.GreaterOrEqual(t, attrs["duration"].Duration().Nanoseconds(), int64(0))
+
+	// credentials are redacted from the logged headers
+	headers := map[string]string{}
+	for _, a := range attrs["headers"].Group() {
+		headers[a.Key] = a.Value.String()
+	}
+	require.Equal(t, "REDACTED", headers["Authorization"])
+	require.Equal(t, "application/json", headers["Accept"])
+}
+
+func TestWithLogger_Retryable(t *testing.T) {
+	calls := 0
+	handler := &captureHandler{}
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			WriteJson(w, http.StatusServiceUnavailable, `{}`)
+			return
+		}
+		WriteJson(w, http.StatusOK, `{"disputeID": "dispute-id"}`)
+	}, moov.WithLogger(slog.New(handler)), moov.WithRetry(2, 0))
+
+	_, err := mc.GetDispute(BgCtx(), "dispute-id")
+	require.NoError(t, err)
+
+	levels := []slog.Level{}
+	for _, r := range handler.records {
+		levels = append(levels, r.Level)
+	}
+	require.Equal(t, []slog.Level{slog.LevelDebug, slog.LevelWarn, slog.LevelDebug}, levels)
+
+	attrs := recordAttrs(handler.records[1])
+	require.Equal(t, "moov request failed", handler.records[1].Message)
+	require.Equal(t, int64(1), attrs["attempt"].Int64())
+	require.True(t, attrs["retrying"].Bool())
+	require.Equal(t, "server_error", attrs["status"].String())
+}
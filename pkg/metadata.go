@@ -0,0 +1,61 @@
+package moov
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Limits Moov places on the metadata of accounts and transfers
+const (
+	maxMetadataKeys        = 20
+	maxMetadataKeyLength   = 40
+	maxMetadataValueLength = 500
+)
+
+var ErrInvalidMetadata = errors.New("metadata exceeds the limits allowed by Moov")
+
+// validateMetadata checks metadata against Moov's limits before it's sent so oversized metadata fails without a round trip
+func validateMetadata(metadata map[string]string) error {
+	if len(metadata) > maxMetadataKeys {
+		return fmt.Errorf("%w: %d keys, at most %d are allowed", ErrInvalidMetadata, len(metadata), maxMetadataKeys)
+	}
+
+	for key, value := range metadata {
+		if key == "" {
+			return fmt.Errorf("%w: keys can't be empty", ErrInvalidMetadata)
+		}
+		if len(key) > maxMetadataKeyLength {
+			return fmt.Errorf("%w: key %q is longer than %d characters", ErrInvalidMetadata, key, maxMetadataKeyLength)
+		}
+		if len(value) > maxMetadataValueLength {
+			return fmt.Errorf("%w: value of %q is longer than %d characters", ErrInvalidMetadata, key, maxMetadataValueLength)
+		}
+	}
+
+	return nil
+}
+
+// MetadataPatch changes some keys of existing metadata while leaving the others as they are
+type MetadataPatch struct {
+	// Keys to add or overwrite
+	Set map[string]string
+	// Keys to remove
+	Remove []string
+}
+
+// apply returns a copy of the metadata with the patch applied
+func (p MetadataPatch) apply(metadata map[string]string) map[string]string {
+	patched := make(map[string]string, len(metadata)+len(p.Set))
+	for k, v := range metadata {
+		patched[k] = v
+	}
+
+	for _, k := range p.Remove {
+		delete(patched, k)
+	}
+	for k, v := range p.Set {
+		patched[k] = v
+	}
+
+	return patched
+}
@@ -0,0 +1,19 @@
+package moov
+
+import "time"
+
+// MetricsRecorder is told about every request the client makes, ie: to count requests and track latency per endpoint
+// in Prometheus.
+type MetricsRecorder interface {
+	// ObserveRequest is called once for every attempt of a call that got a response. The endpoint is the method and
+	// path template of the call with IDs left out so it can be used as a label, ie: "GET /transfers/{id}".
+	ObserveRequest(endpoint string, status CallStatus, duration time.Duration)
+}
+
+// WithMetrics reports the requests the client makes to the recorder
+func WithMetrics(recorder MetricsRecorder) ClientConfigurable {
+	return func(c *Client) error {
+		c.metrics = recorder
+		return nil
+	}
+}
@@ -0,0 +1,64 @@
+package moov_test
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	moov "github.com/moovfinancial/moov-go/pkg"
+	"github.com/stretchr/testify/require"
+)
+
+type observation struct {
+	endpoint string
+	status   moov.CallStatus
+	duration time.Duration
+}
+
+type fakeRecorder struct {
+	mu           sync.Mutex
+	observations []observation
+}
+
+func (r *fakeRecorder) ObserveRequest(endpoint string, status moov.CallStatus, duration time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.observations = append(r.observations, observation{endpoint, status, duration})
+}
+
+func TestWithMetrics(t *testing.T) {
+	calls := 0
+	recorder := &fakeRecorder{}
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.URL.Path == "/disputes" && calls == 2 {
+			WriteJson(w, http.StatusTooManyRequests, `{}`)
+			return
+		}
+		if r.URL.Path == "/disputes" {
+			WriteJson(w, http.StatusOK, `[]`)
+			return
+		}
+		WriteJson(w, http.StatusOK, `{"transferID": "transfer-id"}`)
+	}, moov.WithMetrics(recorder), moov.WithRetry(2, 0))
+
+	_, err := mc.GetTransfer(BgCtx(), "transfer-id", "")
+	require.NoError(t, err)
+
+	// the rate limited attempt and its retry are both observed
+	_, err = mc.ListDisputes(BgCtx())
+	require.NoError(t, err)
+
+	require.Len(t, recorder.observations, 3)
+	require.Equal(t, "GET /transfers/{id}", recorder.observations[0].endpoint)
+	require.Equal(t, moov.StatusCompleted, recorder.observations[0].status)
+	require.Equal(t, "GET /disputes", recorder.observations[1].endpoint)
+	require.Equal(t, moov.StatusRateLimited, recorder.observations[1].status)
+	require.Equal(t, "GET /disputes", recorder.observations[2].endpoint)
+	require.Equal(t, moov.StatusCompleted, recorder.observations[2].status)
+
+	for _, o := range recorder.observations {
+		require.GreaterOrEqual(t, o.duration, time.Duration(0))
+	}
+}
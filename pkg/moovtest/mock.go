@@ -0,0 +1,144 @@
+package moovtest
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+
+	moov "github.com/moovfinancial/moov-go/pkg"
+)
+
+var ErrNoStub = errors.New("no stubbed response matches the request")
+
+// MockTransport is an http.RoundTripper that answers requests with stubbed responses instead of calling Moov, and
+// records the requests made through it so tests can assert on them.
+//
+//	mt := moovtest.NewMockTransport()
+//	mt.On(http.MethodGet, "/transfers/*").Return(http.StatusOK, `{"transferID": "ec7e1848"}`)
+//	mc, err := moovtest.NewTestClient(mt)
+type MockTransport struct {
+	mu       sync.Mutex
+	stubs    []*Stub
+	requests []RecordedRequest
+}
+
+// Stub is a response returned for requests matching a method and path pattern
+type Stub struct {
+	transport *MockTransport
+	method    string
+	pattern   string
+
+	statusCode int
+	header     http.Header
+	body       string
+}
+
+func NewMockTransport() *MockTransport {
+	return &MockTransport{}
+}
+
+// On stubs requests with the method and a path matching pathPattern, which uses path.Match syntax so
+// "/transfers/*" matches any transfer. The first stub matching a request answers it.
+func (m *MockTransport) On(method string, pathPattern string) *Stub {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s := &Stub{
+		transport:  m,
+		method:     method,
+		pattern:    pathPattern,
+		statusCode: http.StatusOK,
+		header:     http.Header{},
+	}
+	m.stubs = append(m.stubs, s)
+	return s
+}
+
+// Return sets the status code and json body of the stubbed response, and returns the transport to stub more requests
+func (s *Stub) Return(statusCode int, body string) *MockTransport {
+	s.transport.mu.Lock()
+	defer s.transport.mu.Unlock()
+
+	s.statusCode = statusCode
+	s.body = body
+	if s.header.Get("Content-Type") == "" {
+		s.header.Set("Content-Type", "application/json")
+	}
+	return s.transport
+}
+
+// WithHeader adds a header onto the stubbed response, ie: X-Total-Count for list calls
+func (s *Stub) WithHeader(name string, value string) *Stub {
+	s.transport.mu.Lock()
+	defer s.transport.mu.Unlock()
+
+	s.header.Add(name, value)
+	return s
+}
+
+func (s *Stub) matches(req *http.Request) bool {
+	if s.method != req.Method {
+		return false
+	}
+	ok, err := path.Match(s.pattern, req.URL.Path)
+	return err == nil && ok
+}
+
+// Requests returns the requests made through the transport in the order they were made
+func (m *MockTransport) Requests() []RecordedRequest {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]RecordedRequest(nil), m.requests...)
+}
+
+func (m *MockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests = append(m.requests, RecordedRequest{
+		Method: req.Method,
+		URL:    req.URL.String(),
+		Header: req.Header.Clone(),
+		Body:   string(body),
+	})
+
+	for _, s := range m.stubs {
+		if !s.matches(req) {
+			continue
+		}
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", s.statusCode, http.StatusText(s.statusCode)),
+			StatusCode:    s.statusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        s.header.Clone(),
+			Body:          io.NopCloser(strings.NewReader(s.body)),
+			ContentLength: int64(len(s.body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s %s", ErrNoStub, req.Method, req.URL)
+}
+
+// NewTestClient creates a Moov client with placeholder credentials whose calls are answered by the transport
+func NewTestClient(mt *MockTransport, configurables ...moov.ClientConfigurable) (*moov.Client, error) {
+	configurables = append([]moov.ClientConfigurable{
+		moov.WithCredentials(moov.Credentials{PublicKey: "public", SecretKey: "secret", Host: "api.moov.io"}),
+		moov.WithHttpClient(&http.Client{Transport: mt}),
+	}, configurables...)
+
+	return moov.NewClient(configurables...)
+}
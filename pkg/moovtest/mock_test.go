@@ -0,0 +1,62 @@
+package moovtest_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	moov "github.com/moovfinancial/moov-go/pkg"
+	"github.com/moovfinancial/moov-go/pkg/moovtest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMockTransport_GetTransfer(t *testing.T) {
+	mt := moovtest.NewMockTransport()
+	mt.On(http.MethodGet, "/transfers/*").Return(http.StatusOK, transferJson)
+
+	mc, err := moovtest.NewTestClient(mt)
+	require.NoError(t, err)
+
+	transfer, err := mc.GetTransfer(context.Background(), "ec7e1848-dc80-4ab0-8827-dd7fc0737b43", "")
+	require.NoError(t, err)
+	require.Equal(t, "ec7e1848-dc80-4ab0-8827-dd7fc0737b43", transfer.TransferID)
+	require.Equal(t, 1204, transfer.Amount.Value)
+
+	requests := mt.Requests()
+	require.Len(t, requests, 1)
+	require.Equal(t, http.MethodGet, requests[0].Method)
+	require.Contains(t, requests[0].URL, "https://api.moov.io/transfers/ec7e1848-dc80-4ab0-8827-dd7fc0737b43")
+}
+
+func TestMockTransport_ListTransfersRateLimited(t *testing.T) {
+	mt := moovtest.NewMockTransport().
+		On(http.MethodGet, "/transfers").Return(http.StatusTooManyRequests, `{"error": "slow down"}`)
+
+	mc, err := moovtest.NewTestClient(mt)
+	require.NoError(t, err)
+
+	_, err = mc.ListTransfers(context.Background(), moov.SearchQueryPayload{Count: 5})
+
+	var apiErr *moov.APIError
+	require.True(t, errors.As(err, &apiErr))
+	require.Equal(t, http.StatusTooManyRequests, apiErr.StatusCode())
+	require.Equal(t, moov.StatusRateLimited, apiErr.Status())
+	require.Equal(t, "slow down", apiErr.Message)
+
+	requests := mt.Requests()
+	require.Len(t, requests, 1)
+	require.Contains(t, requests[0].URL, "count=5")
+}
+
+func TestMockTransport_NoStub(t *testing.T) {
+	mt := moovtest.NewMockTransport()
+	mt.On(http.MethodGet, "/transfers/*").Return(http.StatusOK, transferJson)
+
+	mc, err := moovtest.NewTestClient(mt)
+	require.NoError(t, err)
+
+	_, err = mc.ListDisputes(context.Background())
+	require.ErrorIs(t, err, moovtest.ErrNoStub)
+	require.Len(t, mt.Requests(), 1)
+}
@@ -0,0 +1,296 @@
+// Package moovtest contains helpers for testing code that uses the Moov client without calling the live API every run.
+package moovtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+)
+
+const redacted = "REDACTED"
+
+// DefaultRedactedHeaders are headers left out of cassettes as they hold credentials
+var DefaultRedactedHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+
+// DefaultRedactedFields are json fields whose values are replaced in cassettes as they hold personal or account data.
+// Nested values keep their structure with strings replaced and numbers zeroed so the fixtures still unmarshal.
+var DefaultRedactedFields = []string{
+	"accountNumber", "cardNumber", "cardCvv", "cvv",
+	"ssn", "itin", "governmentID", "birthDate",
+	"email", "phone", "name", "displayName", "legalName", "holderName",
+	"addressLine1", "addressLine2",
+	"client_secret", "access_token", "refresh_token",
+}
+
+var ErrNoInteraction = errors.New("no recorded interaction matches the request")
+
+// Interaction is a request and the response it received
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+type RecordedResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+type cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Recorder is an http.RoundTripper that records the requests made through it and the real responses to a cassette
+// file the first time it's used, and replays the responses from the cassette on later runs. Credentials and personal
+// data are redacted from the cassette.
+//
+//	rec, err := moovtest.NewRecorder("testdata/get_transfer.json", nil)
+//	defer rec.Save()
+//	mc, err := moov.NewClient(moov.WithHttpClient(&http.Client{Transport: rec}))
+type Recorder struct {
+	// Headers and json fields to redact before interactions are saved
+	RedactHeaders []string
+	RedactFields  []string
+
+	path      string
+	transport http.RoundTripper
+	recording bool
+
+	mu           sync.Mutex
+	interactions []Interaction
+	replayed     []bool
+}
+
+// NewRecorder creates a Recorder that replays the cassette at path if it exists, otherwise it records the interactions
+// made through transport, http.DefaultTransport when nil, until Save is called.
+func NewRecorder(path string, transport http.RoundTripper) (*Recorder, error) {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	r := &Recorder{
+		RedactHeaders: DefaultRedactedHeaders,
+		RedactFields:  DefaultRedactedFields,
+		path:          path,
+		transport:     transport,
+	}
+
+	data, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		r.recording = true
+		return r, nil
+	case err != nil:
+		return nil, err
+	}
+
+	c := cassette{}
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("reading cassette %s: %w", path, err)
+	}
+
+	r.interactions = c.Interactions
+	r.replayed = make([]bool, len(c.Interactions))
+	return r, nil
+}
+
+// Recording reports if the recorder is recording new interactions rather than replaying a cassette
+func (r *Recorder) Recording() bool {
+	return r.recording
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	if r.recording {
+		return r.record(req)
+	}
+	return r.replay(req)
+}
+
+func (r *Recorder) record(req *http.Request) (*http.Response, error) {
+	req, reqBody, err := requestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := readBody(&resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.interactions = append(r.interactions, Interaction{
+		Request: RecordedRequest{
+			Method: req.Method,
+			URL:    req.URL.String(),
+			Header: r.redactHeader(req.Header),
+			Body:   r.redactBody(reqBody),
+		},
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     r.redactHeader(resp.Header),
+			Body:       r.redactBody(respBody),
+		},
+	})
+
+	return resp, nil
+}
+
+// replay returns the response of the first interaction for the same method and url that hasn't been replayed yet
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.interactions {
+		if r.replayed[i] || interaction.Request.Method != req.Method || interaction.Request.URL != req.URL.String() {
+			continue
+		}
+		r.replayed[i] = true
+
+		recorded := interaction.Response
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+			StatusCode:    recorded.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        recorded.Header.Clone(),
+			Body:          io.NopCloser(strings.NewReader(recorded.Body)),
+			ContentLength: int64(len(recorded.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s %s", ErrNoInteraction, req.Method, req.URL)
+}
+
+// Save writes the recorded interactions to the cassette. Does nothing when replaying.
+func (r *Recorder) Save() error {
+	if !r.recording {
+		return nil
+	}
+
+	r.mu.Lock()
+	data, err := json.MarshalIndent(cassette{Interactions: r.interactions}, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(r.path, data, 0o600)
+}
+
+func (r *Recorder) redactHeader(header http.Header) http.Header {
+	header = header.Clone()
+	for _, name := range r.RedactHeaders {
+		header.Del(name)
+	}
+	return header
+}
+
+// redactBody replaces the values of sensitive fields in json bodies, other bodies are stored as they are
+func (r *Recorder) redactBody(body []byte) string {
+	var v any
+	if len(body) == 0 || json.Unmarshal(body, &v) != nil {
+		return string(body)
+	}
+
+	fields := make(map[string]bool, len(r.RedactFields))
+	for _, f := range r.RedactFields {
+		fields[strings.ToLower(f)] = true
+	}
+
+	data, err := json.Marshal(redactValue(v, fields, false))
+	if err != nil {
+		return string(body)
+	}
+	return string(data)
+}
+
+func redactValue(v any, fields map[string]bool, sensitive bool) any {
+	switch val := v.(type) {
+	case map[string]any:
+		for k, item := range val {
+			val[k] = redactValue(item, fields, sensitive || fields[strings.ToLower(k)])
+		}
+		return val
+	case []any:
+		for i, item := range val {
+			val[i] = redactValue(item, fields, sensitive)
+		}
+		return val
+	case string:
+		if sensitive {
+			return redacted
+		}
+	case float64:
+		if sensitive {
+			return 0
+		}
+	}
+	return v
+}
+
+// requestBody returns the body of the request without changing the caller's request. The body is read from GetBody
+// when the request has it, otherwise it's read once and the request is sent on as a clone with a copy of the body.
+func requestBody(req *http.Request) (*http.Request, []byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return req, nil, nil
+	}
+
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, nil, err
+		}
+		defer body.Close()
+
+		data, err := io.ReadAll(body)
+		return req, data, err
+	}
+
+	data, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clone := req.Clone(req.Context())
+	clone.Body = io.NopCloser(bytes.NewReader(data))
+	return clone, data, nil
+}
+
+// readBody reads the body and replaces it with a copy so it can still be read by the caller
+func readBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+
+	data, err := io.ReadAll(*body)
+	(*body).Close()
+	if err != nil {
+		return nil, err
+	}
+
+	*body = io.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
@@ -0,0 +1,132 @@
+package moovtest_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	moov "github.com/moovfinancial/moov-go/pkg"
+	"github.com/moovfinancial/moov-go/pkg/moovtest"
+	"github.com/stretchr/testify/require"
+)
+
+const transferJson = `{
+	"transferID": "ec7e1848-dc80-4ab0-8827-dd7fc0737b43",
+	"status": "completed",
+	"amount": {"currency": "USD", "value": 1204},
+	"source": {
+		"paymentMethodID": "9506dbf6-4208-44c3-ad8a-e4431660e1f2",
+		"account": {"accountID": "3dfff852-927d-47e8-822c-2fffc57ff6b9", "email": "amanda@classbooker.dev", "displayName": "Amanda Yang"},
+		"bankAccount": {"bankAccountID": "5b4cd5a2-1e7c-4d1b-9b3d-ef9a6b0e4cd6", "holderName": "Amanda Yang", "routingNumber": "273976369", "lastFourAccountNumber": "6789"}
+	}
+}`
+
+func newClient(t *testing.T, rec *moovtest.Recorder, host string) *moov.Client {
+	mc, err := moov.NewClient(
+		moov.WithCredentials(moov.Credentials{PublicKey: "public", SecretKey: "secret", Host: host}),
+		moov.WithHttpClient(&http.Client{Transport: rec}),
+	)
+	require.NoError(t, err)
+	return mc
+}
+
+func TestRecorder_RecordThenReplayGetTransfer(t *testing.T) {
+	cassette := filepath.Join(t.TempDir(), "get_transfer.json")
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/transfers/ec7e1848-dc80-4ab0-8827-dd7fc0737b43", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=abc")
+		w.Write([]byte(transferJson))
+	}))
+
+	// first run records the responses from the api
+	rec, err := moovtest.NewRecorder(cassette, nil)
+	require.NoError(t, err)
+	require.True(t, rec.Recording())
+
+	mc := newClient(t, rec, api.URL)
+	transfer, err := mc.GetTransfer(context.Background(), "ec7e1848-dc80-4ab0-8827-dd7fc0737b43", "")
+	require.NoError(t, err)
+	require.Equal(t, "amanda@classbooker.dev", transfer.Source.Account.Email)
+	require.NoError(t, rec.Save())
+
+	api.Close()
+
+	stored, err := os.ReadFile(cassette)
+	require.NoError(t, err)
+	require.NotContains(t, string(stored), "Authorization")
+	require.NotContains(t, string(stored), "session=abc")
+	require.NotContains(t, string(stored), "amanda@classbooker.dev")
+	require.NotContains(t, string(stored), "Amanda Yang")
+	require.Contains(t, string(stored), "273976369")
+
+	// later runs replay the cassette without calling the api
+	rec, err = moovtest.NewRecorder(cassette, nil)
+	require.NoError(t, err)
+	require.False(t, rec.Recording())
+
+	mc = newClient(t, rec, api.URL)
+	transfer, err = mc.GetTransfer(context.Background(), "ec7e1848-dc80-4ab0-8827-dd7fc0737b43", "")
+	require.NoError(t, err)
+	require.Equal(t, "ec7e1848-dc80-4ab0-8827-dd7fc0737b43", transfer.TransferID)
+	require.Equal(t, 1204, transfer.Amount.Value)
+	require.Equal(t, "REDACTED", transfer.Source.Account.Email)
+
+	// every recorded interaction is only replayed once
+	_, err = mc.GetTransfer(context.Background(), "ec7e1848-dc80-4ab0-8827-dd7fc0737b43", "")
+	require.ErrorIs(t, err, moovtest.ErrNoInteraction)
+}
+
+func TestRecorder_RedactsTokenCredentials(t *testing.T) {
+	cassette := filepath.Join(t.TempDir(), "token.json")
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token": "live-access-token", "refresh_token": "live-refresh-token", "token_type": "Bearer", "expires_in": 3600}`))
+	}))
+	defer api.Close()
+
+	rec, err := moovtest.NewRecorder(cassette, nil)
+	require.NoError(t, err)
+
+	mc := newClient(t, rec, api.URL)
+	_, err = mc.GenerateToken(context.Background(), []string{"/ping.read"})
+	require.NoError(t, err)
+	require.NoError(t, rec.Save())
+
+	stored, err := os.ReadFile(cassette)
+	require.NoError(t, err)
+	require.Contains(t, string(stored), "client_secret")
+	require.NotContains(t, string(stored), `"secret"`)
+	require.NotContains(t, string(stored), "live-access-token")
+	require.NotContains(t, string(stored), "live-refresh-token")
+}
+
+func TestRecorder_KeepsRequestBody(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.Equal(t, `{"key": "value"}`, string(body))
+	}))
+	defer api.Close()
+
+	rec, err := moovtest.NewRecorder(filepath.Join(t.TempDir(), "body.json"), nil)
+	require.NoError(t, err)
+
+	// a body without GetBody is only read once, on a clone of the request
+	body := io.NopCloser(strings.NewReader(`{"key": "value"}`))
+	req, err := http.NewRequest(http.MethodPost, api.URL, body)
+	require.NoError(t, err)
+	require.Nil(t, req.GetBody)
+
+	resp, err := rec.RoundTrip(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, body, req.Body, "the caller's request body isn't replaced")
+}
@@ -5,17 +5,65 @@ import (
 	"net/http"
 )
 
+const (
+	PAYMENT_METHOD_TYPE_MOOV_WALLET         = "moov-wallet"
+	PAYMENT_METHOD_TYPE_ACH_DEBIT_FUND      = "ach-debit-fund"
+	PAYMENT_METHOD_TYPE_ACH_DEBIT_COLLECT   = "ach-debit-collect"
+	PAYMENT_METHOD_TYPE_ACH_CREDIT_STANDARD = "ach-credit-standard"
+	PAYMENT_METHOD_TYPE_ACH_CREDIT_SAME_DAY = "ach-credit-same-day"
+	PAYMENT_METHOD_TYPE_RTP_CREDIT          = "rtp-credit"
+	PAYMENT_METHOD_TYPE_CARD_PAYMENT        = "card-payment"
+	PAYMENT_METHOD_TYPE_PUSH_TO_CARD        = "push-to-card"
+	PAYMENT_METHOD_TYPE_APPLE_PAY           = "apple-pay"
+)
+
+// paymentMethodRails maps the payment method types to the rail they move money over
+var paymentMethodRails = map[string]Rail{
+	PAYMENT_METHOD_TYPE_MOOV_WALLET:         RAIL_MOOV,
+	PAYMENT_METHOD_TYPE_ACH_DEBIT_FUND:      RAIL_ACH,
+	PAYMENT_METHOD_TYPE_ACH_DEBIT_COLLECT:   RAIL_ACH,
+	PAYMENT_METHOD_TYPE_ACH_CREDIT_STANDARD: RAIL_ACH,
+	PAYMENT_METHOD_TYPE_ACH_CREDIT_SAME_DAY: RAIL_ACH,
+	PAYMENT_METHOD_TYPE_RTP_CREDIT:          RAIL_RTP,
+	PAYMENT_METHOD_TYPE_CARD_PAYMENT:        RAIL_CARD,
+	PAYMENT_METHOD_TYPE_PUSH_TO_CARD:        RAIL_CARD,
+	PAYMENT_METHOD_TYPE_APPLE_PAY:           RAIL_CARD,
+}
+
+var paymentMethodTypes = map[string]bool{
+	PAYMENT_METHOD_TYPE_MOOV_WALLET:         true,
+	PAYMENT_METHOD_TYPE_ACH_DEBIT_FUND:      true,
+	PAYMENT_METHOD_TYPE_ACH_DEBIT_COLLECT:   true,
+	PAYMENT_METHOD_TYPE_ACH_CREDIT_STANDARD: true,
+	PAYMENT_METHOD_TYPE_ACH_CREDIT_SAME_DAY: true,
+	PAYMENT_METHOD_TYPE_RTP_CREDIT:          true,
+	PAYMENT_METHOD_TYPE_CARD_PAYMENT:        true,
+	PAYMENT_METHOD_TYPE_PUSH_TO_CARD:        true,
+	PAYMENT_METHOD_TYPE_APPLE_PAY:           true,
+}
+
 type PaymentMethod struct {
-	PaymentMethodID   string `json:"paymentMethodID,omitempty"`
-	PaymentMethodType string `json:"paymentMethodType,omitempty"`
-	Wallet            Wallet `json:"wallet,omitempty"`
+	PaymentMethodID   string      `json:"paymentMethodID,omitempty"`
+	PaymentMethodType string      `json:"paymentMethodType,omitempty"`
+	Wallet            Wallet      `json:"wallet,omitempty"`
+	BankAccount       BankAccount `json:"bankAccount,omitempty"`
+	Card              Card        `json:"card,omitempty"`
+	ApplePay          ApplePay    `json:"applePay,omitempty"`
 }
 
 type PaymentMethodListFilter callArg
 
 func WithPaymentMethodSourceID(id string) callArg {
 	return callBuilderFn(func(call *callBuilder) error {
-		call.params["sourceID"] = id
+		call.params.Set("sourceID", id)
+		return nil
+	})
+}
+
+// WithPaymentMethodType only lists payment methods of the given type, one of PAYMENT_METHOD_TYPE_*
+func WithPaymentMethodType(paymentMethodType string) callArg {
+	return callBuilderFn(func(call *callBuilder) error {
+		call.params.Set("paymentMethodType", paymentMethodType)
 		return nil
 	})
 }
@@ -37,7 +85,7 @@ func (c Client) ListPaymentMethods(ctx context.Context, accountID string, opts .
 // https://docs.moov.io/api/index.html#tag/Payment-methods/operation/getPaymentMethod
 func (c Client) GetPaymentMethod(ctx context.Context, accountID string, paymentMethodID string) (*PaymentMethod, error) {
 	resp, err := c.CallHttp(ctx,
-		Endpoint(http.MethodGet, pathPaymentMethods, accountID),
+		Endpoint(http.MethodGet, pathPaymentMethod, accountID, paymentMethodID),
 		AcceptJson())
 	if err != nil {
 		return nil, err
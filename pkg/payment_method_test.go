@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"net/http"
+	"strings"
 	"testing"
 
 	moov "github.com/moovfinancial/moov-go/pkg"
@@ -31,6 +33,46 @@ func TestPaymentMethodMarshal(t *testing.T) {
 	require.Equal(t, "ec7e1848-dc80-4ab0-8827-dd7fc0737b43", paymentMethod.PaymentMethodID)
 }
 
+func TestListPaymentMethods_Filter(t *testing.T) {
+	methods := []struct {
+		sourceID string
+		json     string
+	}{
+		{"bank-account-id", `{"paymentMethodID": "pm-1", "paymentMethodType": "ach-debit-fund", "bankAccount": {"bankAccountID": "bank-account-id"}}`},
+		{"bank-account-id", `{"paymentMethodID": "pm-2", "paymentMethodType": "ach-credit-standard", "bankAccount": {"bankAccountID": "bank-account-id"}}`},
+		{"wallet-id", `{"paymentMethodID": "pm-3", "paymentMethodType": "moov-wallet", "wallet": {"walletID": "wallet-id"}}`},
+	}
+
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/accounts/account-id/payment-methods", r.URL.Path)
+
+		query := r.URL.Query()
+		matched := []string{}
+		for _, method := range methods {
+			if sourceID := query.Get("sourceID"); sourceID != "" && sourceID != method.sourceID {
+				continue
+			}
+			if pmType := query.Get("paymentMethodType"); pmType != "" && !strings.Contains(method.json, `"`+pmType+`"`) {
+				continue
+			}
+			matched = append(matched, method.json)
+		}
+
+		WriteJson(w, http.StatusOK, "["+strings.Join(matched, ",")+"]")
+	})
+
+	pms, err := mc.ListPaymentMethods(BgCtx(), "account-id", moov.WithPaymentMethodType(moov.PAYMENT_METHOD_TYPE_ACH_DEBIT_FUND))
+	require.NoError(t, err)
+	require.Len(t, pms, 1)
+	require.Equal(t, "pm-1", pms[0].PaymentMethodID)
+	require.Equal(t, moov.PAYMENT_METHOD_TYPE_ACH_DEBIT_FUND, pms[0].PaymentMethodType)
+	require.Equal(t, "bank-account-id", pms[0].BankAccount.BankAccountID)
+
+	pms, err = mc.ListPaymentMethods(BgCtx(), "account-id", moov.WithPaymentMethodSourceID("bank-account-id"))
+	require.NoError(t, err)
+	require.Len(t, pms, 2)
+}
+
 type PaymentMethodTestSuite struct {
 	suite.Suite
 	// values for testing will be set in init()
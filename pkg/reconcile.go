@@ -0,0 +1,71 @@
+package moov
+
+import (
+	"context"
+	"sort"
+)
+
+// TRANSFER_METADATA_REFERENCE is the transfer metadata key ReconcileAgainst matches ledger entries by
+const TRANSFER_METADATA_REFERENCE = "reference"
+
+// ReconcileReport is the result of comparing Moov transfers to an external ledger
+type ReconcileReport struct {
+	// Transfers whose reference and amount match a ledger entry
+	Matched []SynchronousTransfer
+	// References in the ledger no transfer was found for, sorted
+	MissingInMoov []string
+	// Transfers with no ledger entry, including transfers without a reference
+	MissingInLedger []SynchronousTransfer
+	// Transfers whose amount differs from their ledger entry
+	AmountMismatches []AmountMismatch
+}
+
+// AmountMismatch is a transfer whose amount doesn't match the amount recorded in the ledger
+type AmountMismatch struct {
+	Reference string
+	Transfer  SynchronousTransfer
+	Ledger    Amount
+}
+
+// ReconcileAgainst fetches all transfers matching the search and compares them to the ledger, which maps the
+// reference stored in each transfer's metadata under TRANSFER_METADATA_REFERENCE to the amount it was booked for.
+// A reference is only matched once, later transfers with the same reference are reported as missing in the ledger.
+func (c Client) ReconcileAgainst(ctx context.Context, payload SearchQueryPayload, ledger map[string]Amount) (ReconcileReport, error) {
+	report := ReconcileReport{}
+	seen := make(map[string]bool, len(ledger))
+
+	err := c.eachTransfer(ctx, payload, func(transfer SynchronousTransfer) error {
+		reference := transfer.Metadata[TRANSFER_METADATA_REFERENCE]
+
+		booked, ok := ledger[reference]
+		if reference == "" || !ok || seen[reference] {
+			report.MissingInLedger = append(report.MissingInLedger, transfer)
+			return nil
+		}
+		seen[reference] = true
+
+		if booked != transfer.Amount {
+			report.AmountMismatches = append(report.AmountMismatches, AmountMismatch{
+				Reference: reference,
+				Transfer:  transfer,
+				Ledger:    booked,
+			})
+			return nil
+		}
+
+		report.Matched = append(report.Matched, transfer)
+		return nil
+	})
+	if err != nil {
+		return ReconcileReport{}, err
+	}
+
+	for reference := range ledger {
+		if !seen[reference] {
+			report.MissingInMoov = append(report.MissingInMoov, reference)
+		}
+	}
+	sort.Strings(report.MissingInMoov)
+
+	return report, nil
+}
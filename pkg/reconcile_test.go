@@ -0,0 +1,57 @@
+package moov_test
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	moov "github.com/moovfinancial/moov-go/pkg"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconcileAgainst(t *testing.T) {
+	transfers := []string{
+		`{"transferID": "t-1", "amount": {"currency": "USD", "value": 1000}, "metadata": {"reference": "inv-1"}}`,
+		`{"transferID": "t-2", "amount": {"currency": "USD", "value": 2500}, "metadata": {"reference": "inv-2"}}`,
+		`{"transferID": "t-3", "amount": {"currency": "USD", "value": 300}, "metadata": {"reference": "inv-unknown"}}`,
+		`{"transferID": "t-4", "amount": {"currency": "USD", "value": 400}}`,
+		`{"transferID": "t-5", "amount": {"currency": "USD", "value": 1000}, "metadata": {"reference": "inv-1"}}`,
+	}
+
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/transfers", r.URL.Path)
+		require.Equal(t, "completed", r.URL.Query().Get("status"))
+
+		skip, _ := strconv.Atoi(r.URL.Query().Get("skip"))
+		count, _ := strconv.Atoi(r.URL.Query().Get("count"))
+		end := min(skip+count, len(transfers))
+		WriteJson(w, http.StatusOK, "["+strings.Join(transfers[skip:end], ",")+"]")
+	})
+
+	ledger := map[string]moov.Amount{
+		"inv-1":       {Currency: "USD", Value: 1000},
+		"inv-2":       {Currency: "USD", Value: 2000},
+		"inv-missing": {Currency: "USD", Value: 700},
+	}
+
+	report, err := mc.ReconcileAgainst(BgCtx(), moov.SearchQueryPayload{Status: "completed", Count: 2}, ledger)
+	require.NoError(t, err)
+
+	transferIDs := func(transfers []moov.SynchronousTransfer) []string {
+		ids := []string{}
+		for _, transfer := range transfers {
+			ids = append(ids, transfer.TransferID)
+		}
+		return ids
+	}
+
+	require.Equal(t, []string{"t-1"}, transferIDs(report.Matched))
+	require.Equal(t, []string{"t-3", "t-4", "t-5"}, transferIDs(report.MissingInLedger))
+	require.Equal(t, []string{"inv-missing"}, report.MissingInMoov)
+
+	require.Len(t, report.AmountMismatches, 1)
+	require.Equal(t, "inv-2", report.AmountMismatches[0].Reference)
+	require.Equal(t, "t-2", report.AmountMismatches[0].Transfer.TransferID)
+	require.Equal(t, 2000, report.AmountMismatches[0].Ledger.Value)
+}
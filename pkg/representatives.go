@@ -0,0 +1,140 @@
+package moov
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// Representative is an individual with significant ownership or control of a business account,
+// ie: a beneficial owner, who needs to be verified along with the business
+type Representative struct {
+	RepresentativeID string           `json:"representativeID,omitempty"`
+	Name             Name             `json:"name,omitempty"`
+	Phone            Phone            `json:"phone,omitempty"`
+	Email            string           `json:"email,omitempty"`
+	Address          Address          `json:"address,omitempty"`
+	BirthDate        BirthDate        `json:"birthDate,omitempty"`
+	GovernmentID     GovernmentID     `json:"governmentID,omitempty"`
+	Responsibilities Responsibilities `json:"responsibilities,omitempty"`
+
+	BirthDateProvided    bool `json:"birthDateProvided,omitempty"`
+	GovernmentIDProvided bool `json:"governmentIDProvided,omitempty"`
+
+	CreatedOn  time.Time `json:"createdOn,omitempty"`
+	UpdatedOn  time.Time `json:"updatedOn,omitempty"`
+	DisabledOn time.Time `json:"disabledOn,omitempty"`
+}
+
+// Responsibilities describes the representative's role in the business
+type Responsibilities struct {
+	IsController bool `json:"isController,omitempty"`
+	IsOwner      bool `json:"isOwner,omitempty"`
+	// Percentage of the business owned by the representative, required when IsOwner is set
+	OwnershipPercentage int    `json:"ownershipPercentage,omitempty"`
+	JobTitle            string `json:"jobTitle,omitempty"`
+}
+
+func (r Responsibilities) jsonValue() interface{} {
+	if r != (Responsibilities{}) {
+		return r
+	}
+	return nil
+}
+
+func (r Representative) MarshalJSON() ([]byte, error) {
+	// Alias is an alias type of Representative to avoid recursion.
+	type Alias Representative
+
+	type AliasWithInterface struct {
+		Alias
+		Name             interface{} `json:"name,omitempty"`
+		Phone            interface{} `json:"phone,omitempty"`
+		Address          interface{} `json:"address,omitempty"`
+		BirthDate        interface{} `json:"birthDate,omitempty"`
+		GovernmentID     interface{} `json:"governmentID,omitempty"`
+		Responsibilities interface{} `json:"responsibilities,omitempty"`
+		CreatedOn        *time.Time  `json:"createdOn,omitempty"`
+		UpdatedOn        *time.Time  `json:"updatedOn,omitempty"`
+		DisabledOn       *time.Time  `json:"disabledOn,omitempty"`
+	}
+
+	return json.Marshal(AliasWithInterface{
+		Alias:            Alias(r),
+		Name:             r.Name.jsonValue(),
+		Phone:            r.Phone.jsonValue(),
+		Address:          r.Address.jsonValue(),
+		BirthDate:        r.BirthDate.jsonValue(),
+		GovernmentID:     r.GovernmentID.jsonValue(),
+		Responsibilities: r.Responsibilities.jsonValue(),
+		CreatedOn:        timeOrNil(r.CreatedOn),
+		UpdatedOn:        timeOrNil(r.UpdatedOn),
+		DisabledOn:       timeOrNil(r.DisabledOn),
+	})
+}
+
+// CreateRepresentative adds a representative to a business account
+// https://docs.moov.io/api/moov-accounts/representatives/create/
+func (c Client) CreateRepresentative(ctx context.Context, accountID string, representative Representative) (*Representative, error) {
+	resp, err := c.CallHttp(ctx,
+		Endpoint(http.MethodPost, pathRepresentatives, accountID),
+		AcceptJson(),
+		JsonBody(representative))
+	if err != nil {
+		return nil, err
+	}
+
+	return CompletedObjectOrError[Representative](resp)
+}
+
+// ListRepresentatives lists the representatives of a business account
+// https://docs.moov.io/api/moov-accounts/representatives/list/
+func (c Client) ListRepresentatives(ctx context.Context, accountID string) ([]Representative, error) {
+	resp, err := c.CallHttp(ctx,
+		Endpoint(http.MethodGet, pathRepresentatives, accountID),
+		AcceptJson())
+	if err != nil {
+		return nil, err
+	}
+
+	return CompletedListOrError[Representative](resp)
+}
+
+// GetRepresentative retrieves a representative of a business account
+// https://docs.moov.io/api/moov-accounts/representatives/get/
+func (c Client) GetRepresentative(ctx context.Context, accountID string, representativeID string) (*Representative, error) {
+	resp, err := c.CallHttp(ctx,
+		Endpoint(http.MethodGet, pathRepresentative, accountID, representativeID),
+		AcceptJson())
+	if err != nil {
+		return nil, err
+	}
+
+	return CompletedObjectOrError[Representative](resp)
+}
+
+// UpdateRepresentative updates the fields set on the representative
+// https://docs.moov.io/api/moov-accounts/representatives/patch/
+func (c Client) UpdateRepresentative(ctx context.Context, accountID string, representative Representative) (*Representative, error) {
+	resp, err := c.CallHttp(ctx,
+		Endpoint(http.MethodPatch, pathRepresentative, accountID, representative.RepresentativeID),
+		AcceptJson(),
+		JsonBody(representative))
+	if err != nil {
+		return nil, err
+	}
+
+	return CompletedObjectOrError[Representative](resp)
+}
+
+// DeleteRepresentative removes a representative from a business account
+// https://docs.moov.io/api/moov-accounts/representatives/delete/
+func (c Client) DeleteRepresentative(ctx context.Context, accountID string, representativeID string) error {
+	resp, err := c.CallHttp(ctx, Endpoint(http.MethodDelete, pathRepresentative, accountID, representativeID))
+	if err != nil {
+		return err
+	}
+
+	return CompletedNilOrError(resp)
+}
@@ -0,0 +1,131 @@
+package moov_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	moov "github.com/moovfinancial/moov-go/pkg"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepresentativeMarshal(t *testing.T) {
+	representative := moov.Representative{
+		Name:  moov.Name{FirstName: "Jordan", LastName: "Lee"},
+		Email: "jordan@example.com",
+		Responsibilities: moov.Responsibilities{
+			IsOwner:             true,
+			OwnershipPercentage: 25,
+			JobTitle:            "CFO",
+		},
+	}
+
+	body, err := json.Marshal(representative)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"name": {"firstName": "Jordan", "lastName": "Lee"},
+		"email": "jordan@example.com",
+		"responsibilities": {"isOwner": true, "ownershipPercentage": 25, "jobTitle": "CFO"}
+	}`, string(body))
+
+	parsed := moov.Representative{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"representativeID": "representative-id",
+		"name": {"firstName": "Jordan", "lastName": "Lee"},
+		"responsibilities": {"isController": true, "isOwner": true, "ownershipPercentage": 25, "jobTitle": "CFO"},
+		"birthDateProvided": true,
+		"createdOn": "2024-01-02T03:04:05Z"
+	}`), &parsed))
+	require.Equal(t, 25, parsed.Responsibilities.OwnershipPercentage)
+	require.True(t, parsed.Responsibilities.IsController)
+	require.True(t, parsed.BirthDateProvided)
+
+	// timestamps that are set survive a round trip
+	body, err = json.Marshal(parsed)
+	require.NoError(t, err)
+
+	roundTrip := moov.Representative{}
+	require.NoError(t, json.Unmarshal(body, &roundTrip))
+	require.Equal(t, parsed.CreatedOn, roundTrip.CreatedOn)
+	require.NotContains(t, string(body), "updatedOn")
+}
+
+func TestCreateRepresentative(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/accounts/account-id/representatives", r.URL.Path)
+
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.JSONEq(t, `{
+			"name": {"firstName": "Jordan", "lastName": "Lee"},
+			"address": {"addressLine1": "123 Main Street", "city": "Boulder", "stateOrProvince": "CO", "postalCode": "80301", "country": "US"},
+			"birthDate": {"day": 9, "month": 11, "year": 1989},
+			"responsibilities": {"isOwner": true, "ownershipPercentage": 25}
+		}`, string(body))
+
+		WriteJson(w, http.StatusOK, `{
+			"representativeID": "representative-id",
+			"name": {"firstName": "Jordan", "lastName": "Lee"},
+			"birthDateProvided": true,
+			"responsibilities": {"isOwner": true, "ownershipPercentage": 25}
+		}`)
+	})
+
+	representative, err := mc.CreateRepresentative(BgCtx(), "account-id", moov.Representative{
+		Name: moov.Name{FirstName: "Jordan", LastName: "Lee"},
+		Address: moov.Address{
+			AddressLine1:    "123 Main Street",
+			City:            "Boulder",
+			StateOrProvince: "CO",
+			PostalCode:      "80301",
+			Country:         "US",
+		},
+		BirthDate: moov.BirthDate{Day: 9, Month: 11, Year: 1989},
+		Responsibilities: moov.Responsibilities{
+			IsOwner:             true,
+			OwnershipPercentage: 25,
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "representative-id", representative.RepresentativeID)
+	require.Equal(t, 25, representative.Responsibilities.OwnershipPercentage)
+	require.True(t, representative.BirthDateProvided)
+}
+
+func TestRepresentativeRequests(t *testing.T) {
+	requests := []string{}
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+
+		switch {
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == "/accounts/account-id/representatives":
+			WriteJson(w, http.StatusOK, `[{"representativeID": "representative-id"}]`)
+		default:
+			WriteJson(w, http.StatusOK, `{"representativeID": "representative-id", "email": "jordan@example.com"}`)
+		}
+	})
+
+	list, err := mc.ListRepresentatives(BgCtx(), "account-id")
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+
+	_, err = mc.GetRepresentative(BgCtx(), "account-id", "representative-id")
+	require.NoError(t, err)
+
+	updated, err := mc.UpdateRepresentative(BgCtx(), "account-id", moov.Representative{RepresentativeID: "representative-id", Email: "jordan@example.com"})
+	require.NoError(t, err)
+	require.Equal(t, "jordan@example.com", updated.Email)
+
+	require.NoError(t, mc.DeleteRepresentative(BgCtx(), "account-id", "representative-id"))
+
+	require.Equal(t, []string{
+		"GET /accounts/account-id/representatives",
+		"GET /accounts/account-id/representatives/representative-id",
+		"PATCH /accounts/account-id/representatives/representative-id",
+		"DELETE /accounts/account-id/representatives/representative-id",
+	}, requests)
+}
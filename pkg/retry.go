@@ -0,0 +1,121 @@
+package moov
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+)
+
+// retryPolicy describes how failed calls made through CallHttp are retried. The zero value never retries.
+type retryPolicy struct {
+	maxAttempts int
+	backoff     time.Duration
+	budget      time.Duration
+}
+
+// WithRetry retries calls that fail with a network error or a retryable status, like rate limiting or server errors,
+// up to maxAttempts attempts in total. Only calls that are safe to send twice are retried, those with an idempotent
+// method or an IdempotencyKey. The wait between attempts starts at backoff and doubles after every attempt, up to
+// 5 minutes.
+func WithRetry(maxAttempts int, backoff time.Duration) ClientConfigurable {
+	return func(c *Client) error {
+		if maxAttempts < 1 {
+			return errors.New("retry max attempts must be at least 1")
+		}
+		if backoff < 0 {
+			return errors.New("retry backoff must not be negative")
+		}
+
+		c.retry.maxAttempts = maxAttempts
+		c.retry.backoff = backoff
+		return nil
+	}
+}
+
+// WithRetryBudget caps the total time spent on a call across all of its attempts, including the waits between them.
+// No further attempt is made once the elapsed time plus the next backoff would exceed maxTotal, and the last
+// response or error is returned instead. Only applies when retrying has been enabled with WithRetry.
+func WithRetryBudget(maxTotal time.Duration) ClientConfigurable {
+	return func(c *Client) error {
+		if maxTotal < 0 {
+			return errors.New("retry budget must not be negative")
+		}
+
+		c.retry.budget = maxTotal
+		return nil
+	}
+}
+
+// maxRetryBackoff caps how long the doubling backoff grows between attempts, a longer starting backoff is used as is
+const maxRetryBackoff = 5 * time.Minute
+
+// wait returns how long to wait before the attempt following the given one, and false if no further attempt should be made.
+func (p retryPolicy) wait(attempt int, elapsed time.Duration) (time.Duration, bool) {
+	if attempt >= p.maxAttempts {
+		return 0, false
+	}
+
+	// doubling stops at the cap so the wait can't overflow into a negative duration
+	limit := max(p.backoff, maxRetryBackoff)
+	wait := p.backoff
+	for i := 1; i < attempt && wait > 0 && wait < limit; i++ {
+		wait = min(wait*2, limit)
+	}
+
+	if p.budget > 0 && elapsed+wait > p.budget {
+		return 0, false
+	}
+
+	return wait, true
+}
+
+// retryable reports if the outcome of an attempt is worth trying again. Calls the server has already started
+// processing are never retried.
+func retryable(ctx context.Context, resp CallResponse, err error) bool {
+	if err != nil {
+		return ctx.Err() == nil
+	}
+
+	status := resp.Status()
+	return status.Retryable && status != StatusStarted
+}
+
+// idempotent reports if sending the call again can't repeat its effect, ie: create a second transfer
+func idempotent(call *callBuilder) bool {
+	switch call.method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	default:
+		return call.headers["X-Idempotency-Key"] != ""
+	}
+}
+
+// replayableBody returns a function producing a fresh reader over the body for every attempt. Bodies that can't be
+// read twice are only handed out once and reported as not replayable.
+func replayableBody(body io.Reader) (func() io.Reader, bool) {
+	switch b := body.(type) {
+	case nil:
+		return func() io.Reader { return nil }, true
+	case *bytes.Buffer:
+		payload := b.Bytes()
+		return func() io.Reader { return bytes.NewReader(payload) }, true
+	default:
+		return func() io.Reader { return body }, false
+	}
+}
+
+// sleep waits for the duration or until the context is done, whichever comes first.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
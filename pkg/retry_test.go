@@ -0,0 +1,94 @@
+package moov_test
+
+import (
+	"io"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	moov "github.com/moovfinancial/moov-go/pkg"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithRetry(t *testing.T) {
+	attempts := int32(0)
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}, moov.WithRetry(5, time.Millisecond))
+
+	resp, err := mc.CallHttp(BgCtx(), moov.Endpoint(http.MethodGet, "/ping"))
+	require.NoError(t, err)
+	require.NoError(t, moov.CompletedNilOrError(resp))
+	require.Equal(t, int32(3), atomic.LoadInt32(&attempts))
+}
+
+func TestWithRetry_NotRetryable(t *testing.T) {
+	attempts := int32(0)
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusBadRequest)
+	}, moov.WithRetry(5, time.Millisecond))
+
+	resp, err := mc.CallHttp(BgCtx(), moov.Endpoint(http.MethodGet, "/ping"))
+	require.NoError(t, err)
+	require.Equal(t, moov.StatusBadRequest, resp.Status())
+	require.Equal(t, int32(1), atomic.LoadInt32(&attempts))
+}
+
+func TestWithRetry_ReplaysBody(t *testing.T) {
+	bodies := []string{}
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		w.WriteHeader(http.StatusTooManyRequests)
+	}, moov.WithRetry(2, time.Millisecond))
+
+	resp, err := mc.CallHttp(BgCtx(),
+		moov.Endpoint(http.MethodPost, "/ping"),
+		moov.JsonBody(map[string]string{"a": "b"}),
+		moov.IdempotencyKey("key"))
+	require.NoError(t, err)
+	require.Equal(t, moov.StatusRateLimited, resp.Status())
+	require.Equal(t, []string{`{"a":"b"}`, `{"a":"b"}`}, bodies)
+}
+
+func TestWithRetry_NotIdempotent(t *testing.T) {
+	attempts := int32(0)
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}, moov.WithRetry(5, time.Millisecond))
+
+	for _, method := range []string{http.MethodPost, http.MethodPatch} {
+		atomic.StoreInt32(&attempts, 0)
+
+		resp, err := mc.CallHttp(BgCtx(), moov.Endpoint(method, "/ping"), moov.JsonBody(map[string]string{"a": "b"}))
+		require.NoError(t, err)
+		require.Equal(t, moov.StatusServerError, resp.Status())
+		require.Equal(t, int32(1), atomic.LoadInt32(&attempts), method)
+	}
+}
+
+func TestWithRetryBudget(t *testing.T) {
+	attempts := int32(0)
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}, moov.WithRetry(100, 10*time.Millisecond), moov.WithRetryBudget(100*time.Millisecond))
+
+	start := time.Now()
+	resp, err := mc.CallHttp(BgCtx(), moov.Endpoint(http.MethodGet, "/ping"))
+	elapsed := time.Since(start)
+
+	require.NoError(t, err)
+	require.Equal(t, moov.StatusServerError, resp.Status())
+
+	// waits of 10ms, 20ms and 40ms fit in the budget but the following 80ms doesn't
+	require.Equal(t, int32(4), atomic.LoadInt32(&attempts))
+	require.Less(t, elapsed, 100*time.Millisecond)
+}
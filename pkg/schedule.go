@@ -0,0 +1,224 @@
+package moov
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+var ErrNotScheduled = errors.New("transfer was not created by a schedule")
+
+type Schedule struct {
+	ScheduleID           string               `json:"scheduleID,omitempty"`
+	SourceAccountID      string               `json:"sourceAccountID,omitempty"`
+	DestinationAccountID string               `json:"destinationAccountID,omitempty"`
+	PartnerAccountID     string               `json:"partnerAccountID,omitempty"`
+	Description          string               `json:"description,omitempty"`
+	Recur                *ScheduleRecur       `json:"recur,omitempty"`
+	Occurrences          []ScheduleOccurrence `json:"occurrences,omitempty"`
+	CreatedOn            time.Time            `json:"createdOn,omitempty"`
+	UpdatedOn            time.Time            `json:"updatedOn,omitempty"`
+	DisabledOn           time.Time            `json:"disabledOn,omitempty"`
+}
+
+type ScheduleRecur struct {
+	RecurrenceRule string      `json:"recurrenceRule,omitempty"`
+	Start          time.Time   `json:"start,omitempty"`
+	End            time.Time   `json:"end,omitempty"`
+	Indefinite     bool        `json:"indefinite,omitempty"`
+	RunTransfer    RunTransfer `json:"runTransfer,omitempty"`
+}
+
+type ScheduleOccurrence struct {
+	OccurrenceID  string      `json:"occurrenceID,omitempty"`
+	Canceled      bool        `json:"canceled,omitempty"`
+	RunOn         time.Time   `json:"runOn,omitempty"`
+	RunTransfer   RunTransfer `json:"runTransfer,omitempty"`
+	Status        string      `json:"status,omitempty"`
+	RanOn         time.Time   `json:"ranOn,omitempty"`
+	RanTransferID string      `json:"ranTransferID,omitempty"`
+}
+
+// MarshalJSON leaves out unset start and end times
+func (r ScheduleRecur) MarshalJSON() ([]byte, error) {
+	// Alias is an alias type of ScheduleRecur to avoid recursion.
+	type Alias ScheduleRecur
+
+	type AliasWithTimes struct {
+		Alias
+		Start *time.Time `json:"start,omitempty"`
+		End   *time.Time `json:"end,omitempty"`
+	}
+
+	return json.Marshal(AliasWithTimes{
+		Alias: Alias(r),
+		Start: timeOrNil(r.Start),
+		End:   timeOrNil(r.End),
+	})
+}
+
+func timeOrNil(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// RunTransfer describes the transfer a schedule creates when an occurrence runs
+type RunTransfer struct {
+	Amount      Amount                   `json:"amount,omitempty"`
+	Description string                   `json:"description,omitempty"`
+	Source      RunTransferPaymentMethod `json:"source,omitempty"`
+	Destination RunTransferPaymentMethod `json:"destination,omitempty"`
+}
+
+type RunTransferPaymentMethod struct {
+	PaymentMethodID string `json:"paymentMethodID,omitempty"`
+}
+
+// ScheduleCreate is the payload to create or update a schedule. It needs at least one occurrence or a recurrence rule.
+type ScheduleCreate struct {
+	SourceAccountID      string                     `json:"sourceAccountID"`
+	DestinationAccountID string                     `json:"destinationAccountID"`
+	PartnerAccountID     string                     `json:"partnerAccountID,omitempty"`
+	Description          string                     `json:"description,omitempty"`
+	Occurrences          []ScheduleOccurrenceCreate `json:"occurrences,omitempty"`
+	Recur                *ScheduleRecur             `json:"recur,omitempty"`
+}
+
+// ScheduleOccurrenceCreate is a transfer to run at a future date
+type ScheduleOccurrenceCreate struct {
+	RunOn       time.Time   `json:"runOn"`
+	RunTransfer RunTransfer `json:"runTransfer"`
+}
+
+// CreateSchedule schedules future dated or recurring transfers
+// https://docs.moov.io/api/money-movement/schedules/create/
+func (c Client) CreateSchedule(ctx context.Context, accountID string, schedule ScheduleCreate) (*Schedule, error) {
+	resp, err := c.CallHttp(ctx,
+		Endpoint(http.MethodPost, pathSchedules, accountID),
+		AcceptJson(),
+		JsonBody(schedule))
+	if err != nil {
+		return nil, err
+	}
+
+	return CompletedObjectOrError[Schedule](resp)
+}
+
+// UpdateSchedule replaces the occurrences and recurrence of a schedule
+// https://docs.moov.io/api/money-movement/schedules/update/
+func (c Client) UpdateSchedule(ctx context.Context, accountID string, scheduleID string, schedule ScheduleCreate) (*Schedule, error) {
+	resp, err := c.CallHttp(ctx,
+		Endpoint(http.MethodPut, pathSchedule, accountID, scheduleID),
+		AcceptJson(),
+		JsonBody(schedule))
+	if err != nil {
+		return nil, err
+	}
+
+	return CompletedObjectOrError[Schedule](resp)
+}
+
+func WithScheduleCount(count int) callArg {
+	return callBuilderFn(func(call *callBuilder) error {
+		call.params.Set("count", fmt.Sprintf("%d", count))
+		return nil
+	})
+}
+
+func WithScheduleSkip(skip int) callArg {
+	return callBuilderFn(func(call *callBuilder) error {
+		call.params.Set("skip", fmt.Sprintf("%d", skip))
+		return nil
+	})
+}
+
+// ListSchedules lists the transfer schedules the account is part of
+// https://docs.moov.io/api/money-movement/schedules/list/
+func (c Client) ListSchedules(ctx context.Context, accountID string, opts ...callArg) ([]Schedule, error) {
+	resp, err := c.CallHttp(ctx, Endpoint(http.MethodGet, pathSchedules, accountID), prependArgs(opts, AcceptJson(), paged())...)
+	if err != nil {
+		return nil, err
+	}
+
+	return CompletedListOrError[Schedule](resp)
+}
+
+// GetSchedule retrieves a transfer schedule the account is part of
+// https://docs.moov.io/api/money-movement/schedules/get/
+func (c Client) GetSchedule(ctx context.Context, accountID string, scheduleID string) (*Schedule, error) {
+	resp, err := c.CallHttp(ctx, Endpoint(http.MethodGet, pathSchedule, accountID, scheduleID), AcceptJson())
+	if err != nil {
+		return nil, err
+	}
+
+	return CompletedObjectOrError[Schedule](resp)
+}
+
+// GetTransferSchedule retrieves the schedule that created the transfer through the transfer's source account.
+// Returns ErrNotScheduled if the transfer was created directly instead of by a schedule.
+func (c Client) GetTransferSchedule(ctx context.Context, transfer SynchronousTransfer) (*Schedule, error) {
+	scheduleID, _, ok := transfer.ScheduleRef()
+	if !ok {
+		return nil, ErrNotScheduled
+	}
+
+	return c.GetSchedule(ctx, transfer.Source.Account.AccountID, scheduleID)
+}
+
+// pending reports if the occurrence is still waiting to run
+func (o ScheduleOccurrence) pending() bool {
+	return !o.Canceled && o.RanOn.IsZero() && o.RanTransferID == ""
+}
+
+// ScheduleCancelSummary describes what was canceled by CancelSchedule
+type ScheduleCancelSummary struct {
+	ScheduleID string
+	// IDs of the occurrences that were waiting to run and got canceled
+	CanceledOccurrences []string
+}
+
+// CancelSchedule cancels the schedule so no further occurrences are created. Occurrences that are already scheduled
+// still run unless cancelPending is true, in which case each of them is canceled first.
+// https://docs.moov.io/api/money-movement/schedules/cancel/
+func (c Client) CancelSchedule(ctx context.Context, accountID string, scheduleID string, cancelPending bool) (*ScheduleCancelSummary, error) {
+	summary := &ScheduleCancelSummary{ScheduleID: scheduleID}
+
+	if cancelPending {
+		schedule, err := c.GetSchedule(ctx, accountID, scheduleID)
+		if err != nil {
+			return summary, err
+		}
+
+		for _, occurrence := range schedule.Occurrences {
+			if !occurrence.pending() {
+				continue
+			}
+
+			if err := c.cancelOccurrence(ctx, accountID, scheduleID, occurrence.OccurrenceID); err != nil {
+				return summary, err
+			}
+			summary.CanceledOccurrences = append(summary.CanceledOccurrences, occurrence.OccurrenceID)
+		}
+	}
+
+	resp, err := c.CallHttp(ctx, Endpoint(http.MethodDelete, pathSchedule, accountID, scheduleID))
+	if err != nil {
+		return summary, err
+	}
+
+	return summary, CompletedNilOrError(resp)
+}
+
+func (c Client) cancelOccurrence(ctx context.Context, accountID string, scheduleID string, occurrenceID string) error {
+	resp, err := c.CallHttp(ctx, Endpoint(http.MethodDelete, pathOccurrence, accountID, scheduleID, occurrenceID))
+	if err != nil {
+		return err
+	}
+
+	return CompletedNilOrError(resp)
+}
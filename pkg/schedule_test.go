@@ -0,0 +1,189 @@
+package moov_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	moov "github.com/moovfinancial/moov-go/pkg"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTransferSchedule(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/accounts/source-account/schedules/schedule-id", r.URL.Path)
+		WriteJson(w, http.StatusOK, `{
+			"scheduleID": "schedule-id",
+			"sourceAccountID": "source-account",
+			"destinationAccountID": "destination-account",
+			"occurrences": [{"occurrenceID": "occurrence-id", "status": "completed", "ranTransferID": "transfer-id"}]
+		}`)
+	})
+
+	scheduled := moov.SynchronousTransfer{}
+	require.NoError(t, scheduled.UnmarshalJSON([]byte(`{
+		"transferID": "transfer-id",
+		"scheduleID": "schedule-id",
+		"occurrenceID": "occurrence-id",
+		"source": {"account": {"accountID": "source-account"}}
+	}`)))
+	require.Empty(t, scheduled.Extra)
+
+	scheduleID, occurrenceID, ok := scheduled.ScheduleRef()
+	require.True(t, ok)
+	require.Equal(t, "schedule-id", scheduleID)
+	require.Equal(t, "occurrence-id", occurrenceID)
+
+	schedule, err := mc.GetTransferSchedule(BgCtx(), scheduled)
+	require.NoError(t, err)
+	require.Equal(t, "schedule-id", schedule.ScheduleID)
+	require.Len(t, schedule.Occurrences, 1)
+	require.Equal(t, "transfer-id", schedule.Occurrences[0].RanTransferID)
+}
+
+func TestGetTransferSchedule_AdHoc(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Fatalf("unexpected request to %s", r.URL.Path)
+	})
+
+	adHoc := moov.SynchronousTransfer{TransferID: "transfer-id"}
+
+	_, _, ok := adHoc.ScheduleRef()
+	require.False(t, ok)
+
+	_, err := mc.GetTransferSchedule(BgCtx(), adHoc)
+	require.ErrorIs(t, err, moov.ErrNotScheduled)
+}
+
+func TestCancelSchedule(t *testing.T) {
+	for _, cancelPending := range []bool{true, false} {
+		t.Run(fmt.Sprintf("cancelPending=%t", cancelPending), func(t *testing.T) {
+			deleted := []string{}
+			mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+				switch r.Method {
+				case http.MethodGet:
+					require.Equal(t, "/accounts/account-id/schedules/schedule-id", r.URL.Path)
+					WriteJson(w, http.StatusOK, `{
+						"scheduleID": "schedule-id",
+						"occurrences": [
+							{"occurrenceID": "ran", "status": "completed", "ranOn": "2024-01-01T00:00:00Z", "ranTransferID": "transfer-id"},
+							{"occurrenceID": "canceled", "canceled": true},
+							{"occurrenceID": "next", "runOn": "2030-01-01T00:00:00Z"},
+							{"occurrenceID": "later", "runOn": "2030-02-01T00:00:00Z"}
+						]
+					}`)
+				case http.MethodDelete:
+					deleted = append(deleted, r.URL.Path)
+					w.WriteHeader(http.StatusNoContent)
+				}
+			})
+
+			summary, err := mc.CancelSchedule(BgCtx(), "account-id", "schedule-id", cancelPending)
+			require.NoError(t, err)
+			require.Equal(t, "schedule-id", summary.ScheduleID)
+
+			if cancelPending {
+				require.Equal(t, []string{"next", "later"}, summary.CanceledOccurrences)
+				require.Equal(t, []string{
+					"/accounts/account-id/schedules/schedule-id/occurrences/next",
+					"/accounts/account-id/schedules/schedule-id/occurrences/later",
+					"/accounts/account-id/schedules/schedule-id",
+				}, deleted)
+			} else {
+				require.Empty(t, summary.CanceledOccurrences)
+				require.Equal(t, []string{"/accounts/account-id/schedules/schedule-id"}, deleted)
+			}
+		})
+	}
+}
+
+func TestCreateSchedule(t *testing.T) {
+	runOn := time.Date(2026, time.November, 2, 15, 0, 0, 0, time.UTC)
+	runTransfer := moov.RunTransfer{
+		Amount:      moov.Amount{Currency: "USD", Value: 1500},
+		Description: "Membership",
+		Source:      moov.RunTransferPaymentMethod{PaymentMethodID: "source-pm"},
+		Destination: moov.RunTransferPaymentMethod{PaymentMethodID: "destination-pm"},
+	}
+
+	t.Run("future dated", func(t *testing.T) {
+		mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, http.MethodPost, r.Method)
+			require.Equal(t, "/accounts/account-id/schedules", r.URL.Path)
+
+			body := map[string]any{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			require.NotContains(t, body, "recur")
+
+			occurrences := body["occurrences"].([]any)
+			require.Len(t, occurrences, 1)
+			occurrence := occurrences[0].(map[string]any)
+			require.Equal(t, "2026-11-02T15:00:00Z", occurrence["runOn"])
+			require.Equal(t, "Membership", occurrence["runTransfer"].(map[string]any)["description"])
+
+			WriteJson(w, http.StatusOK, `{"scheduleID": "schedule-id", "occurrences": [{"occurrenceID": "occurrence-id", "runOn": "2026-11-02T15:00:00Z"}]}`)
+		})
+
+		schedule, err := mc.CreateSchedule(BgCtx(), "account-id", moov.ScheduleCreate{
+			SourceAccountID:      "account-id",
+			DestinationAccountID: "destination-account",
+			Occurrences:          []moov.ScheduleOccurrenceCreate{{RunOn: runOn, RunTransfer: runTransfer}},
+		})
+		require.NoError(t, err)
+		require.Equal(t, "schedule-id", schedule.ScheduleID)
+		require.True(t, runOn.Equal(schedule.Occurrences[0].RunOn))
+	})
+
+	t.Run("weekly", func(t *testing.T) {
+		mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+			body := map[string]any{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			require.NotContains(t, body, "occurrences")
+
+			recur := body["recur"].(map[string]any)
+			require.Equal(t, "FREQ=WEEKLY;BYDAY=MO", recur["recurrenceRule"])
+			require.Equal(t, "2026-11-02T15:00:00Z", recur["start"])
+			require.NotContains(t, recur, "end")
+			require.Equal(t, true, recur["indefinite"])
+
+			WriteJson(w, http.StatusOK, `{"scheduleID": "schedule-id", "recur": {"recurrenceRule": "FREQ=WEEKLY;BYDAY=MO", "indefinite": true}}`)
+		})
+
+		schedule, err := mc.CreateSchedule(BgCtx(), "account-id", moov.ScheduleCreate{
+			SourceAccountID:      "account-id",
+			DestinationAccountID: "destination-account",
+			Recur: &moov.ScheduleRecur{
+				RecurrenceRule: "FREQ=WEEKLY;BYDAY=MO",
+				Start:          runOn,
+				Indefinite:     true,
+				RunTransfer:    runTransfer,
+			},
+		})
+		require.NoError(t, err)
+		require.Equal(t, "FREQ=WEEKLY;BYDAY=MO", schedule.Recur.RecurrenceRule)
+	})
+}
+
+func TestListAndUpdateSchedules(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			require.Equal(t, "/accounts/account-id/schedules", r.URL.Path)
+			require.Equal(t, "10", r.URL.Query().Get("count"))
+			WriteJson(w, http.StatusOK, `[{"scheduleID": "schedule-1"}, {"scheduleID": "schedule-2"}]`)
+		case http.MethodPut:
+			require.Equal(t, "/accounts/account-id/schedules/schedule-1", r.URL.Path)
+			WriteJson(w, http.StatusOK, `{"scheduleID": "schedule-1", "description": "updated"}`)
+		}
+	})
+
+	schedules, err := mc.ListSchedules(BgCtx(), "account-id", moov.WithScheduleCount(10))
+	require.NoError(t, err)
+	require.Len(t, schedules, 2)
+
+	schedule, err := mc.UpdateSchedule(BgCtx(), "account-id", "schedule-1", moov.ScheduleCreate{Description: "updated"})
+	require.NoError(t, err)
+	require.Equal(t, "updated", schedule.Description)
+}
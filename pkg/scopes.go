@@ -114,6 +114,35 @@ func (sl *scopeList) ProfileEnrichment() ScopeBuilder {
 	return appendScope("/profile-enrichment.read")
 }
 
+// TokenScope is a permission on a single account's resources, used to mint tokens with MintScopedToken
+type TokenScope string
+
+const (
+	ScopeBankAccountsRead     TokenScope = "bank-accounts.read"
+	ScopeBankAccountsWrite    TokenScope = "bank-accounts.write"
+	ScopeCapabilitiesRead     TokenScope = "capabilities.read"
+	ScopeCapabilitiesWrite    TokenScope = "capabilities.write"
+	ScopeCardsRead            TokenScope = "cards.read"
+	ScopeCardsWrite           TokenScope = "cards.write"
+	ScopeApplePayRead         TokenScope = "apple-pay.read"
+	ScopeApplePayWrite        TokenScope = "apple-pay.write"
+	ScopeProfileRead          TokenScope = "profile.read"
+	ScopeProfileWrite         TokenScope = "profile.write"
+	ScopeRepresentativesRead  TokenScope = "representatives.read"
+	ScopeRepresentativesWrite TokenScope = "representatives.write"
+	ScopeFilesRead            TokenScope = "files.read"
+	ScopeFilesWrite           TokenScope = "files.write"
+	ScopePaymentMethodsRead   TokenScope = "payment-methods.read"
+	ScopeTransfersRead        TokenScope = "transfers.read"
+	ScopeTransfersWrite       TokenScope = "transfers.write"
+	ScopeWalletsRead          TokenScope = "wallets.read"
+)
+
+// ForAccount formats the scope for the account, ie: /accounts/{accountID}/cards.write
+func (s TokenScope) ForAccount(accountID string) string {
+	return fmt.Sprintf("/accounts/%s/%s", accountID, s)
+}
+
 // Boilerplate for setting the above.
 
 type scopeList struct{}
@@ -0,0 +1,179 @@
+package moov
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+var (
+	ErrNoSweepConfig = errors.New("no sweep config with the specified sweepConfigID was found")
+)
+
+const (
+	SWEEP_CONFIG_STATUS_ENABLED  = "enabled"
+	SWEEP_CONFIG_STATUS_DISABLED = "disabled"
+
+	SWEEP_STATUS_ACCRUING        = "accruing"
+	SWEEP_STATUS_ACTION_REQUIRED = "action-required"
+	SWEEP_STATUS_CANCELED        = "canceled"
+	SWEEP_STATUS_COMPLETED       = "completed"
+	SWEEP_STATUS_FAILED          = "failed"
+)
+
+// AmountDecimal is an amount with decimal precision, ie: "12.987654321" USD
+type AmountDecimal struct {
+	Currency     string `json:"currency,omitempty"`
+	ValueDecimal string `json:"valueDecimal,omitempty"`
+}
+
+// SweepConfig automatically moves a wallet's balance to a bank account, or pulls from one when the balance drops below
+// zero, once a day
+type SweepConfig struct {
+	SweepConfigID string `json:"sweepConfigID,omitempty"`
+	WalletID      string `json:"walletID,omitempty"`
+	// One of SWEEP_CONFIG_STATUS_*
+	Status string `json:"status,omitempty"`
+	// Payment method the balance above MinimumBalance is pushed to
+	PushPaymentMethodID string `json:"pushPaymentMethodID,omitempty"`
+	// Payment method a negative balance is covered from
+	PullPaymentMethodID string `json:"pullPaymentMethodID,omitempty"`
+	StatementDescriptor string `json:"statementDescriptor,omitempty"`
+	// Decimal balance left in the wallet after a sweep, ie: "100.00"
+	MinimumBalance string    `json:"minimumBalance,omitempty"`
+	CreatedOn      time.Time `json:"createdOn,omitempty"`
+	UpdatedOn      time.Time `json:"updatedOn,omitempty"`
+}
+
+// MarshalJSON leaves out unset created and updated times
+func (sc SweepConfig) MarshalJSON() ([]byte, error) {
+	// Alias is an alias type of SweepConfig to avoid recursion.
+	type Alias SweepConfig
+
+	type AliasWithTimes struct {
+		Alias
+		CreatedOn *time.Time `json:"createdOn,omitempty"`
+		UpdatedOn *time.Time `json:"updatedOn,omitempty"`
+	}
+
+	return json.Marshal(AliasWithTimes{
+		Alias:     Alias(sc),
+		CreatedOn: timeOrNil(sc.CreatedOn),
+		UpdatedOn: timeOrNil(sc.UpdatedOn),
+	})
+}
+
+// SweepConfigUpdate holds the fields of a sweep config to change, fields left empty are unchanged
+type SweepConfigUpdate struct {
+	Status              string `json:"status,omitempty"`
+	PushPaymentMethodID string `json:"pushPaymentMethodID,omitempty"`
+	PullPaymentMethodID string `json:"pullPaymentMethodID,omitempty"`
+	StatementDescriptor string `json:"statementDescriptor,omitempty"`
+	MinimumBalance      string `json:"minimumBalance,omitempty"`
+}
+
+// Sweep is the balance a wallet accrues over a day and the transfer that moves it
+type Sweep struct {
+	SweepID string `json:"sweepID,omitempty"`
+	// One of SWEEP_STATUS_*
+	Status string `json:"status,omitempty"`
+	// Balance accrued in the wallet since the last sweep
+	AccruedAmount AmountDecimal `json:"accruedAmount,omitempty"`
+	// Amount pushed to the push payment method, or pulled when negative
+	PushPaymentAmount   AmountDecimal `json:"pushPaymentAmount,omitempty"`
+	TransferID          string        `json:"transferID,omitempty"`
+	StatementDescriptor string        `json:"statementDescriptor,omitempty"`
+	AccrualStartedOn    time.Time     `json:"accrualStartedOn,omitempty"`
+	AccrualEndedOn      time.Time     `json:"accrualEndedOn,omitempty"`
+}
+
+// CreateSweepConfig configures sweeps for one of the account's wallets
+// https://docs.moov.io/api/money-movement/sweeps/create-config/
+func (c Client) CreateSweepConfig(ctx context.Context, accountID string, config SweepConfig) (*SweepConfig, error) {
+	resp, err := c.CallHttp(ctx,
+		Endpoint(http.MethodPost, pathSweepConfigs, accountID),
+		AcceptJson(),
+		JsonBody(config))
+	if err != nil {
+		return nil, err
+	}
+
+	return CompletedObjectOrError[SweepConfig](resp)
+}
+
+// GetSweepConfig retrieves a sweep config of the account
+// https://docs.moov.io/api/money-movement/sweeps/get-config/
+func (c Client) GetSweepConfig(ctx context.Context, accountID string, sweepConfigID string) (*SweepConfig, error) {
+	resp, err := c.CallHttp(ctx, Endpoint(http.MethodGet, pathSweepConfig, accountID, sweepConfigID), AcceptJson())
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.Status() {
+	case StatusCompleted:
+		return UnmarshalObjectResponse[SweepConfig](resp)
+	case StatusNotFound:
+		return nil, ErrNoSweepConfig
+	default:
+		return nil, resp.Error()
+	}
+}
+
+// UpdateSweepConfig changes the fields set on the update, ie: disabling sweeps or changing the minimum balance
+// https://docs.moov.io/api/money-movement/sweeps/update-config/
+func (c Client) UpdateSweepConfig(ctx context.Context, accountID string, sweepConfigID string, update SweepConfigUpdate) (*SweepConfig, error) {
+	resp, err := c.CallHttp(ctx,
+		Endpoint(http.MethodPatch, pathSweepConfig, accountID, sweepConfigID),
+		AcceptJson(),
+		JsonBody(update))
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.Status() {
+	case StatusCompleted:
+		return UnmarshalObjectResponse[SweepConfig](resp)
+	case StatusNotFound:
+		return nil, ErrNoSweepConfig
+	default:
+		return nil, resp.Error()
+	}
+}
+
+func WithSweepCount(count int) callArg {
+	return callBuilderFn(func(call *callBuilder) error {
+		call.params.Set("count", fmt.Sprintf("%d", count))
+		return nil
+	})
+}
+
+func WithSweepSkip(skip int) callArg {
+	return callBuilderFn(func(call *callBuilder) error {
+		call.params.Set("skip", fmt.Sprintf("%d", skip))
+		return nil
+	})
+}
+
+// WithSweepStatus only lists sweeps with the status, one of SWEEP_STATUS_*
+func WithSweepStatus(status string) callArg {
+	return callBuilderFn(func(call *callBuilder) error {
+		call.params.Set("status", status)
+		return nil
+	})
+}
+
+// ListSweeps lists the sweeps of the wallet, newest first. Page through them with WithSweepCount and WithSweepSkip.
+// https://docs.moov.io/api/money-movement/sweeps/list/
+func (c Client) ListSweeps(ctx context.Context, accountID string, walletID string, opts ...callArg) ([]Sweep, error) {
+	resp, err := c.CallHttp(ctx,
+		Endpoint(http.MethodGet, pathSweeps, accountID, walletID),
+		prependArgs(opts, AcceptJson(), paged())...)
+	if err != nil {
+		return nil, err
+	}
+
+	return CompletedListOrError[Sweep](resp)
+}
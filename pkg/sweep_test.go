@@ -0,0 +1,88 @@
+package moov_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	moov "github.com/moovfinancial/moov-go/pkg"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateSweepConfig(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/accounts/account-id/sweep-configs", r.URL.Path)
+
+		body := map[string]any{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.Equal(t, map[string]any{
+			"walletID":            "wallet-id",
+			"status":              "enabled",
+			"pushPaymentMethodID": "push-pm",
+			"pullPaymentMethodID": "pull-pm",
+			"statementDescriptor": "SWEEP",
+			"minimumBalance":      "100.00",
+		}, body)
+
+		WriteJson(w, http.StatusOK, `{
+			"sweepConfigID": "sweep-config-id",
+			"walletID": "wallet-id",
+			"status": "enabled",
+			"pushPaymentMethodID": "push-pm",
+			"pullPaymentMethodID": "pull-pm",
+			"statementDescriptor": "SWEEP",
+			"minimumBalance": "100.00",
+			"createdOn": "2024-01-02T03:04:05Z"
+		}`)
+	})
+
+	config, err := mc.CreateSweepConfig(BgCtx(), "account-id", moov.SweepConfig{
+		WalletID:            "wallet-id",
+		Status:              moov.SWEEP_CONFIG_STATUS_ENABLED,
+		PushPaymentMethodID: "push-pm",
+		PullPaymentMethodID: "pull-pm",
+		StatementDescriptor: "SWEEP",
+		MinimumBalance:      "100.00",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "sweep-config-id", config.SweepConfigID)
+	require.Equal(t, "100.00", config.MinimumBalance)
+	require.False(t, config.CreatedOn.IsZero())
+}
+
+func TestUpdateSweepConfig_NotFound(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPatch, r.Method)
+		WriteJson(w, http.StatusNotFound, `{}`)
+	})
+
+	_, err := mc.UpdateSweepConfig(BgCtx(), "account-id", "sweep-config-id", moov.SweepConfigUpdate{Status: moov.SWEEP_CONFIG_STATUS_DISABLED})
+	require.ErrorIs(t, err, moov.ErrNoSweepConfig)
+}
+
+func TestListSweeps(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		require.Equal(t, "/accounts/account-id/wallets/wallet-id/sweeps", r.URL.Path)
+		require.Equal(t, "count=10&status=completed", r.URL.RawQuery)
+
+		WriteJson(w, http.StatusOK, `[
+			{
+				"sweepID": "sweep-id",
+				"status": "completed",
+				"accruedAmount": {"currency": "USD", "valueDecimal": "250.123"},
+				"pushPaymentAmount": {"currency": "USD", "valueDecimal": "150.12"},
+				"transferID": "transfer-id"
+			}
+		]`)
+	})
+
+	sweeps, err := mc.ListSweeps(BgCtx(), "account-id", "wallet-id", moov.WithSweepStatus(moov.SWEEP_STATUS_COMPLETED), moov.WithSweepCount(10))
+	require.NoError(t, err)
+	require.Len(t, sweeps, 1)
+	require.Equal(t, moov.SWEEP_STATUS_COMPLETED, sweeps[0].Status)
+	require.Equal(t, moov.AmountDecimal{Currency: "USD", ValueDecimal: "250.123"}, sweeps[0].AccruedAmount)
+	require.Equal(t, "150.12", sweeps[0].PushPaymentAmount.ValueDecimal)
+	require.Equal(t, "transfer-id", sweeps[0].TransferID)
+}
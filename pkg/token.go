@@ -3,6 +3,9 @@ package moov
 import (
 	"context"
 	"net/http"
+	"strings"
+	"sync"
+	"time"
 )
 
 type AccessTokenRequest struct {
@@ -25,11 +28,7 @@ func (atr *AccessTokenRequest) WithScopes(scopes ...ScopeBuilder) error {
 		return err
 	}
 
-	if atr.Scope != "" {
-		scp = scp + " " + atr.Scope
-	}
-
-	atr.Scope = scp
+	atr.Scope = strings.TrimSpace(scp + " " + atr.Scope)
 	return nil
 }
 
@@ -55,7 +54,8 @@ func (c *Client) AccessToken(ctx context.Context, tokenReq AccessTokenRequest, s
 
 	resp, err := c.CallHttp(ctx,
 		Endpoint(http.MethodPost, "/oauth2/token"),
-		JsonBody(tokenReq))
+		JsonBody(tokenReq),
+		withBasicAuth())
 	if err != nil {
 		return nil, err
 	}
@@ -108,3 +108,96 @@ func (c *Client) AccountAccessToken(ctx context.Context, accountID string, scope
 		ClientSecret: &c.Credentials.SecretKey,
 	}, scopes...)
 }
+
+// AccessToken is a token generated with the client credentials grant
+type AccessToken struct {
+	AccessToken string `json:"access_token,omitempty"`
+	TokenType   string `json:"token_type,omitempty"`
+	// Number of seconds the token is valid for from when it was generated
+	ExpiresIn int32 `json:"expires_in,omitempty"`
+
+	// Scopes the token was granted
+	Scopes []string `json:"-"`
+
+	// When the token expires, computed from ExpiresIn
+	ExpiresOn time.Time `json:"-"`
+}
+
+// GenerateToken generates an access token for the scopes using the client's API keys
+// https://docs.moov.io/api/authentication/access-tokens/create/
+func (c *Client) GenerateToken(ctx context.Context, scopes []string) (*AccessToken, error) {
+	requested := time.Now()
+
+	resp, err := c.AccessToken(ctx, AccessTokenRequest{
+		GrantType:    "client_credentials",
+		ClientId:     &c.Credentials.PublicKey,
+		ClientSecret: &c.Credentials.SecretKey,
+		Scope:        strings.Join(scopes, " "),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &AccessToken{
+		AccessToken: resp.AccessToken,
+		TokenType:   resp.TokenType,
+		ExpiresIn:   resp.ExpiresIn,
+		Scopes:      strings.Fields(resp.Scope),
+		ExpiresOn:   requested.Add(time.Duration(resp.ExpiresIn) * time.Second),
+	}, nil
+}
+
+// MintScopedToken generates a short lived token limited to the scopes on a single account, for Moov's drop-in
+// components and other frontend flows that call Moov directly.
+func (c *Client) MintScopedToken(ctx context.Context, accountID string, scopes []TokenScope) (*AccessToken, error) {
+	formatted := make([]string, len(scopes))
+	for i, scope := range scopes {
+		formatted[i] = scope.ForAccount(accountID)
+	}
+
+	return c.GenerateToken(ctx, formatted)
+}
+
+// WithTokenAuth authenticates calls with an access token for the scopes instead of sending the API keys on every call.
+// The token is generated on the first call and regenerated once it's within refreshBefore of expiring.
+func WithTokenAuth(refreshBefore time.Duration, scopes ...ScopeBuilder) ClientConfigurable {
+	return func(c *Client) error {
+		scope, err := buildScopes(scopes...)
+		if err != nil {
+			return err
+		}
+
+		c.tokens = &tokenSource{
+			scopes:        strings.Fields(scope),
+			refreshBefore: refreshBefore,
+		}
+		return nil
+	}
+}
+
+// tokenSource caches the client's access token. Callers wait on the lock while a token is generated so a token about
+// to expire is only refreshed once no matter how many calls are made at the same time.
+type tokenSource struct {
+	scopes        []string
+	refreshBefore time.Duration
+
+	mu    sync.Mutex
+	token *AccessToken
+}
+
+func (ts *tokenSource) get(ctx context.Context, c *Client) (string, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if ts.token != nil && time.Until(ts.token.ExpiresOn) > ts.refreshBefore {
+		return ts.token.AccessToken, nil
+	}
+
+	token, err := c.GenerateToken(ctx, ts.scopes)
+	if err != nil {
+		return "", err
+	}
+
+	ts.token = token
+	return token.AccessToken, nil
+}
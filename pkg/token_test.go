@@ -0,0 +1,140 @@
+package moov_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	moov "github.com/moovfinancial/moov-go/pkg"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateToken(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/oauth2/token", r.URL.Path)
+
+		user, pass, ok := r.BasicAuth()
+		require.True(t, ok)
+		require.Equal(t, "public", user)
+		require.Equal(t, "secret", pass)
+
+		req := moov.AccessTokenRequest{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		require.Equal(t, "client_credentials", req.GrantType)
+		require.Equal(t, "/ping.read /accounts.read", req.Scope)
+
+		WriteJson(w, http.StatusOK, `{"access_token": "token-1", "token_type": "Bearer", "expires_in": 3600}`)
+	})
+
+	token, err := mc.GenerateToken(BgCtx(), []string{"/ping.read", "/accounts.read"})
+	require.NoError(t, err)
+	require.Equal(t, "token-1", token.AccessToken)
+	require.Equal(t, "Bearer", token.TokenType)
+	require.Equal(t, int32(3600), token.ExpiresIn)
+	require.WithinDuration(t, time.Now().Add(time.Hour), token.ExpiresOn, time.Minute)
+}
+
+func TestTokenAuth_Refresh(t *testing.T) {
+	generated := atomic.Int32{}
+	authorizations := sync.Map{}
+
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/oauth2/token" {
+			n := generated.Add(1)
+
+			// the first token is about to expire so the next call has to refresh it
+			expiresIn := 3600
+			if n == 1 {
+				expiresIn = 10
+			}
+
+			// slow enough for concurrent calls to pile up waiting for the token
+			time.Sleep(10 * time.Millisecond)
+			WriteJson(w, http.StatusOK, fmt.Sprintf(`{"access_token": "token-%d", "token_type": "Bearer", "expires_in": %d}`, n, expiresIn))
+			return
+		}
+
+		authorizations.Store(r.Header.Get("Authorization"), true)
+		w.WriteHeader(http.StatusNoContent)
+	}, moov.WithTokenAuth(time.Minute, moov.Scopes.Ping()))
+
+	require.NoError(t, mc.Ping(BgCtx()))
+	require.Equal(t, int32(1), generated.Load())
+
+	// require can't fail the test from other goroutines, the errors are checked once they're done
+	errs := make([]error, 20)
+	wg := sync.WaitGroup{}
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = mc.Ping(BgCtx())
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+
+	require.Equal(t, int32(2), generated.Load())
+
+	seen := []string{}
+	authorizations.Range(func(key, _ any) bool {
+		seen = append(seen, key.(string))
+		return true
+	})
+	require.ElementsMatch(t, []string{"Bearer token-1", "Bearer token-2"}, seen)
+}
+
+func TestTokenAuth_TransferCalls(t *testing.T) {
+	authorizations := []string{}
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/oauth2/token" {
+			WriteJson(w, http.StatusOK, `{"access_token": "token", "token_type": "Bearer", "expires_in": 3600}`)
+			return
+		}
+
+		authorizations = append(authorizations, r.Header.Get("Authorization"))
+		WriteJson(w, http.StatusOK, `{}`)
+	}, moov.WithTokenAuth(time.Minute, moov.Scopes.Ping()))
+
+	_, err := mc.UpdateTransferMetaData(BgCtx(), "transfer-id", "account-id", map[string]string{"key": "value"})
+	require.NoError(t, err)
+
+	_, err = mc.TransferOptions(BgCtx(), moov.TransferOptionsPayload{
+		Source:      moov.TransferOptionsSourcePayload{PaymentMethodID: "source-id"},
+		Destination: moov.TransferOptionsDestinationPayload{PaymentMethodID: "destination-id"},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"Bearer token", "Bearer token"}, authorizations)
+}
+
+func TestMintScopedToken(t *testing.T) {
+	require.Equal(t, "/accounts/account-id/bank-accounts.write", moov.ScopeBankAccountsWrite.ForAccount("account-id"))
+
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		req := moov.AccessTokenRequest{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		require.Equal(t, "/accounts/account-id/bank-accounts.write /accounts/account-id/cards.write /accounts/account-id/transfers.read", req.Scope)
+
+		WriteJson(w, http.StatusOK, fmt.Sprintf(`{"access_token": "token", "token_type": "Bearer", "expires_in": 300, "scope": %q}`, req.Scope))
+	})
+
+	token, err := mc.MintScopedToken(BgCtx(), "account-id", []moov.TokenScope{
+		moov.ScopeBankAccountsWrite,
+		moov.ScopeCardsWrite,
+		moov.ScopeTransfersRead,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"/accounts/account-id/bank-accounts.write",
+		"/accounts/account-id/cards.write",
+		"/accounts/account-id/transfers.read",
+	}, token.Scopes)
+}
@@ -3,15 +3,75 @@ package moov
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math/big"
 	"net/http"
 	"net/url"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+var (
+	ErrTransferNotRefundable    = errors.New("the transfer is not in a state that can be refunded or canceled")
+	ErrRefundAmountExceeded     = errors.New("the refund amount exceeds the amount remaining on the transfer")
+	ErrPartialCancellation      = errors.New("the transfer hasn't settled and can only be canceled for its full amount")
+	ErrCurrencyNotAllowed       = errors.New("the transfer currency is not in the client's allowed currencies")
+	ErrAlreadySettled           = errors.New("the transfer has already settled and can no longer be canceled")
+	ErrCancelAccountNotSet      = errors.New("an accountID is needed to cancel a transfer without refunding it")
+	ErrNotPushToCardEligible    = errors.New("the destination card does not support push-to-card")
+	ErrPushToCardAccountNotSet  = errors.New("the destination account is needed to check the card supports push-to-card")
+	ErrInvalidAmountRange       = errors.New("the transfer amount range is invalid")
+	ErrInvalidOrderBy           = errors.New("transfers can't be ordered by the given field or direction")
+	ErrInvalidPaymentMethodType = errors.New("unknown payment method type")
+	ErrNotCancelable            = errors.New("the transfer has moved past the created or queued state and can no longer be canceled")
+	ErrUnexpectedAsync          = errors.New("a synchronous response was requested but the transfer is being processed asynchronously")
+	ErrInvalidTransfer          = errors.New("the transfer is missing required fields")
+	ErrTransferFailed           = errors.New("the transfer failed")
+	ErrFeeMismatch              = errors.New("the Moov fee details don't add up to the Moov fee")
+	ErrInvalidFacilitatorFee    = errors.New("the facilitator fee is not a valid amount")
+	ErrTransferOptionsParty     = errors.New("transfer options need a paymentMethodID or accountID for both the source and destination")
+	ErrNotModified              = errors.New("the transfer hasn't changed since it was last retrieved")
+
+	// ErrIdempotencyConflict is returned by CreateTransfer along with the transfer the X-Idempotency-Key already created.
+	// It matches ErrXIdempotencyKey.
+	ErrIdempotencyConflict = fmt.Errorf("%w, the existing transfer was returned", ErrXIdempotencyKey)
+)
+
+// UnexpectedAsyncError is returned by clients configured WithAsyncAsError when a synchronous call came back
+// asynchronous. It matches ErrUnexpectedAsync and carries the transfer to reconcile later.
+type UnexpectedAsyncError struct {
+	TransferID string
+}
+
+func (e *UnexpectedAsyncError) Error() string {
+	return fmt.Sprintf("%s: transfer %s", ErrUnexpectedAsync, e.TransferID)
+}
+
+func (e *UnexpectedAsyncError) Unwrap() error {
+	return ErrUnexpectedAsync
+}
+
+// TransferFailedError is returned by WaitForTransfer when the transfer failed. It matches ErrTransferFailed and
+// carries the reason Moov gave.
+type TransferFailedError struct {
+	TransferID    string
+	FailureReason string
+}
+
+func (e *TransferFailedError) Error() string {
+	return fmt.Sprintf("%s: transfer %s: %s", ErrTransferFailed, e.TransferID, e.FailureReason)
+}
+
+func (e *TransferFailedError) Unwrap() error {
+	return ErrTransferFailed
+}
+
 type TransferStatus int
 
 const (
@@ -22,6 +82,9 @@ const (
 	TransferStatusReversed
 	TransferStatusQueued
 	TransferStatusCanceled
+
+	// TransferStatusUnknown is returned when a status string isn't recognized by this client
+	TransferStatusUnknown TransferStatus = -1
 )
 
 var TransferStatusStrings = map[TransferStatus]string{
@@ -34,6 +97,24 @@ var TransferStatusStrings = map[TransferStatus]string{
 	TransferStatusCanceled:  "canceled",
 }
 
+// String returns the Moov API representation of the status or "unknown" for values outside of the enum.
+func (s TransferStatus) String() string {
+	if str, ok := TransferStatusStrings[s]; ok {
+		return str
+	}
+	return "unknown"
+}
+
+// ParseTransferStatus converts the Moov API representation of a transfer status into a TransferStatus
+func ParseTransferStatus(s string) (TransferStatus, error) {
+	for status, str := range TransferStatusStrings {
+		if str == s {
+			return status, nil
+		}
+	}
+	return TransferStatusUnknown, fmt.Errorf("unknown transfer status: %q", s)
+}
+
 type SynchronousTransfer struct {
 	TransferID     string            `json:"transferID,omitempty"`
 	CreatedOn      time.Time         `json:"createdOn,omitempty"`
@@ -47,13 +128,302 @@ type SynchronousTransfer struct {
 	MoovFee        int               `json:"moovFee,omitempty"`
 	MoovFeeDecimal string            `json:"moovFeeDecimal,omitempty"`
 	MoovFeeDetails MoovFeeDetails    `json:"moovFeeDetails,omitempty"`
+	MoovFees       []MoovFee         `json:"moovFees,omitempty"`
 	GroupID        string            `json:"groupID,omitempty"`
+	Cancellations  []RefundStatus    `json:"cancellations,omitempty"`
 	RefundedAmount Amount            `json:"refundedAmount,omitempty"`
 	Refunds        []Refund          `json:"refunds,omitempty"`
 	DisputedAmount Amount            `json:"disputedAmount,omitempty"`
 	Disputes       []Dispute         `json:"disputes,omitempty"`
 	Source         Source            `json:"source,omitempty"`
 	Destination    Destination       `json:"destination,omitempty"`
+	ScheduleID     string            `json:"scheduleID,omitempty"`
+	OccurrenceID   string            `json:"occurrenceID,omitempty"`
+	SalesTaxAmount *Amount           `json:"salesTaxAmount,omitempty"`
+	// Sweep the transfer's funds were moved with, only set on transfers created by a wallet sweep
+	SweepID string `json:"sweepID,omitempty"`
+	// Code of the payment link the transfer was paid through
+	PaymentLinkCode string `json:"paymentLinkCode,omitempty"`
+
+	// Extra holds any fields returned by Moov that aren't modeled by this client yet
+	Extra map[string]json.RawMessage `json:"-"`
+
+	// ETag of the transfer when it was retrieved with GetTransfer, pass it to IfNoneMatch to only get it again once
+	// it has changed
+	ETag string `json:"-"`
+}
+
+// MarshalJSON leaves out unset created and completed times
+func (t SynchronousTransfer) MarshalJSON() ([]byte, error) {
+	// Alias is an alias type of SynchronousTransfer to avoid recursion.
+	type Alias SynchronousTransfer
+
+	type AliasWithTimes struct {
+		Alias
+		CreatedOn   *time.Time `json:"createdOn,omitempty"`
+		CompletedOn *time.Time `json:"completedOn,omitempty"`
+	}
+
+	return json.Marshal(AliasWithTimes{
+		Alias:       Alias(t),
+		CreatedOn:   timeOrNil(t.CreatedOn),
+		CompletedOn: timeOrNil(t.CompletedOn),
+	})
+}
+
+// ScheduleRef returns the IDs of the schedule and occurrence that created the transfer.
+// ok is false for transfers that weren't created by a schedule.
+func (t SynchronousTransfer) ScheduleRef() (scheduleID, occurrenceID string, ok bool) {
+	if t.ScheduleID == "" {
+		return "", "", false
+	}
+	return t.ScheduleID, t.OccurrenceID, true
+}
+
+// StatusEvent is a status the transfer, or one of its sides, moved into and when it happened
+type StatusEvent struct {
+	// Source or destination for status changes of one side of the transfer, empty for the transfer itself
+	Side   string
+	Status string
+	Time   time.Time
+}
+
+// StatusTimeline assembles the status changes of the transfer and the ACH or card rails of its source and destination
+// sorted from oldest to newest. Statuses without a time are left out.
+func (t SynchronousTransfer) StatusTimeline() []StatusEvent {
+	events := []StatusEvent{}
+	add := func(side string, status string, at time.Time) {
+		if !at.IsZero() {
+			events = append(events, StatusEvent{Side: side, Status: status, Time: at})
+		}
+	}
+
+	add("", "created", t.CreatedOn)
+
+	for _, side := range []struct {
+		name string
+		ach  ACHStatusUpdates
+		card CardStatusUpdates
+	}{
+		{"source", t.Source.AchDetails.StatusUpdates, t.Source.CardDetails.StatusUpdates},
+		{"destination", t.Destination.AchDetails.StatusUpdates, t.Destination.CardDetails.StatusUpdates},
+	} {
+		add(side.name, "initiated", side.ach.Initiated)
+		add(side.name, "originated", side.ach.Originated)
+		add(side.name, "corrected", side.ach.Corrected)
+		add(side.name, "returned", side.ach.Returned)
+		add(side.name, "completed", side.ach.Completed)
+
+		add(side.name, "initiated", side.card.Initiated)
+		add(side.name, "confirmed", side.card.Confirmed)
+		add(side.name, "settled", side.card.Settled)
+		add(side.name, "failed", side.card.Failed)
+		add(side.name, "canceled", side.card.Canceled)
+		add(side.name, "completed", side.card.Completed)
+	}
+
+	add("", "completed", t.CompletedOn)
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Time.Before(events[j].Time)
+	})
+
+	return events
+}
+
+func (t *SynchronousTransfer) UnmarshalJSON(data []byte) error {
+	// Alias is an alias type of SynchronousTransfer to avoid recursion.
+	type Alias SynchronousTransfer
+
+	alias := (*Alias)(t)
+	if err := json.Unmarshal(data, alias); err != nil {
+		return err
+	}
+
+	extra, err := unknownFields(data, alias)
+	if err != nil {
+		return err
+	}
+
+	t.Extra = extra
+	return nil
+}
+
+// TypedStatus returns the transfer's status as a TransferStatus, or TransferStatusUnknown if it isn't recognized.
+func (t SynchronousTransfer) TypedStatus() TransferStatus {
+	status, _ := ParseTransferStatus(t.Status)
+	return status
+}
+
+type TransferFailureReason int
+
+const (
+	TransferFailureSourcePaymentError TransferFailureReason = iota
+	TransferFailureDestinationPaymentError
+	TransferFailureWalletInsufficientFunds
+	TransferFailureRejectedHighRisk
+	TransferFailureProcessingError
+
+	// TransferFailureUnknown is returned when a failure reason isn't recognized by this client
+	TransferFailureUnknown TransferFailureReason = -1
+)
+
+var TransferFailureReasonStrings = map[TransferFailureReason]string{
+	TransferFailureSourcePaymentError:      "source-payment-error",
+	TransferFailureDestinationPaymentError: "destination-payment-error",
+	TransferFailureWalletInsufficientFunds: "wallet-insufficient-funds",
+	TransferFailureRejectedHighRisk:        "rejected-high-risk",
+	TransferFailureProcessingError:         "processing-error",
+}
+
+// String returns the Moov API representation of the failure reason or "unknown" for values outside of the enum.
+func (r TransferFailureReason) String() string {
+	if str, ok := TransferFailureReasonStrings[r]; ok {
+		return str
+	}
+	return "unknown"
+}
+
+// IsRetryable reports if the transfer could succeed when created again, once the wallet has been funded or after a
+// processing error. Payment method errors and risk rejections need the transfer to change instead.
+func (r TransferFailureReason) IsRetryable() bool {
+	switch r {
+	case TransferFailureWalletInsufficientFunds, TransferFailureProcessingError:
+		return true
+	default:
+		return false
+	}
+}
+
+// ParseTransferFailureReason converts the Moov API representation of a failure reason into a TransferFailureReason
+func ParseTransferFailureReason(s string) (TransferFailureReason, error) {
+	for reason, str := range TransferFailureReasonStrings {
+		if str == s {
+			return reason, nil
+		}
+	}
+	return TransferFailureUnknown, fmt.Errorf("unknown transfer failure reason: %q", s)
+}
+
+// TypedFailureReason returns the transfer's failure reason as a TransferFailureReason, or TransferFailureUnknown if
+// it isn't recognized or the transfer didn't fail.
+func (t SynchronousTransfer) TypedFailureReason() TransferFailureReason {
+	reason, _ := ParseTransferFailureReason(t.FailureReason)
+	return reason
+}
+
+type RefundFailureCode int
+
+const (
+	RefundFailureCallIssuer RefundFailureCode = iota
+	RefundFailureDoNotHonor
+	RefundFailureProcessingError
+	RefundFailureInvalidTransaction
+	RefundFailureSuspectedFraud
+	RefundFailureOther
+
+	// RefundFailureUnknown is returned when a failure code isn't recognized by this client
+	RefundFailureUnknown RefundFailureCode = -1
+)
+
+var RefundFailureCodeStrings = map[RefundFailureCode]string{
+	RefundFailureCallIssuer:         "call-issuer",
+	RefundFailureDoNotHonor:         "do-not-honor",
+	RefundFailureProcessingError:    "processing-error",
+	RefundFailureInvalidTransaction: "invalid-transaction",
+	RefundFailureSuspectedFraud:     "suspected-fraud",
+	RefundFailureOther:              "other",
+}
+
+// String returns the Moov API representation of the failure code or "unknown" for values outside of the enum.
+func (c RefundFailureCode) String() string {
+	if str, ok := RefundFailureCodeStrings[c]; ok {
+		return str
+	}
+	return "unknown"
+}
+
+// IsRetryable reports if the refund could succeed when made again, which is only the case for processing errors.
+// The other codes come from the card issuer declining the refund.
+func (c RefundFailureCode) IsRetryable() bool {
+	return c == RefundFailureProcessingError
+}
+
+// ParseRefundFailureCode converts the Moov API representation of a refund failure code into a RefundFailureCode
+func ParseRefundFailureCode(s string) (RefundFailureCode, error) {
+	for code, str := range RefundFailureCodeStrings {
+		if str == s {
+			return code, nil
+		}
+	}
+	return RefundFailureUnknown, fmt.Errorf("unknown refund failure code: %q", s)
+}
+
+// TransferValidationError is returned when Moov rejects a transfer request. It holds the top level message along
+// with the messages Moov returned for each invalid field. Nested fields are keyed by their dotted path.
+type TransferValidationError struct {
+	Message string
+	Fields  map[string]string
+}
+
+func (e *TransferValidationError) Error() string {
+	msgs := []string{}
+	if e.Message != "" {
+		msgs = append(msgs, e.Message)
+	}
+
+	keys := make([]string, 0, len(e.Fields))
+	for k := range e.Fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		msgs = append(msgs, fmt.Sprintf("%s: %s", k, e.Fields[k]))
+	}
+
+	return fmt.Sprintf("transfer validation failed: %s", strings.Join(msgs, "; "))
+}
+
+func (e *TransferValidationError) UnmarshalJSON(data []byte) error {
+	body := map[string]any{}
+	if err := json.Unmarshal(data, &body); err != nil {
+		return err
+	}
+
+	e.Fields = map[string]string{}
+	if msg, ok := body["error"].(string); ok {
+		e.Message = msg
+		delete(body, "error")
+	}
+
+	flattenValidationFields(e.Fields, "", body)
+	return nil
+}
+
+func flattenValidationFields(fields map[string]string, prefix string, body map[string]any) {
+	for k, v := range body {
+		if prefix != "" {
+			k = prefix + "." + k
+		}
+
+		switch val := v.(type) {
+		case map[string]any:
+			flattenValidationFields(fields, k, val)
+		case string:
+			fields[k] = val
+		default:
+			fields[k] = fmt.Sprint(val)
+		}
+	}
+}
+
+func transferValidationError(resp CallResponse) error {
+	verr := &TransferValidationError{}
+	if err := resp.Unmarshal(verr); err != nil {
+		return fmt.Errorf("%w: %w", ErrBadRequest, resp.Error())
+	}
+	return verr
 }
 
 type AsynchronousTransfer struct {
@@ -66,6 +436,9 @@ type Amount struct {
 	Value    int    `json:"value,omitempty"`
 }
 
+// FacilitatorFee is the fee a facilitator charges on a transfer. Moov always collects it into the facilitator's own
+// wallet, the API has no way to route it to a different account, so split the fee out with a separate transfer when
+// it belongs elsewhere.
 type FacilitatorFee struct {
 	Total         int    `json:"total,omitempty"`
 	TotalDecimal  string `json:"totalDecimal,omitempty"`
@@ -73,12 +446,168 @@ type FacilitatorFee struct {
 	MarkupDecimal string `json:"markupDecimal,omitempty"`
 }
 
+// feeDecimalScale is the precision Moov accepts for the decimal facilitator fee fields, which are in cents
+const feeDecimalScale = 9
+
+// FacilitatorFeeFromMarkup builds a fee marked up on top of Moov's fees by a percentage of the transfer amount, ie: 2.9
+// for 2.9%. Markup is rounded to the nearest cent and MarkupDecimal keeps the exact fraction. A fixed part, like the
+// 30¢ of a 2.9% + 30¢ fee, has to be added in with FacilitatorFeeFromDecimal instead. No fee is returned for a
+// percentage that isn't positive.
+func FacilitatorFeeFromMarkup(total Amount, markupPercent float64) FacilitatorFee {
+	percent, err := parseDecimal(strconv.FormatFloat(markupPercent, 'f', -1, 64), feeDecimalScale)
+	if err != nil || percent <= 0 {
+		return FacilitatorFee{}
+	}
+
+	// value * percent / 100, rounded to the fee scale
+	markup := new(big.Int).Mul(big.NewInt(int64(total.Value)), big.NewInt(percent))
+	markup.Add(markup, big.NewInt(50))
+	markup.Quo(markup, big.NewInt(100))
+
+	fee := FacilitatorFee{}
+	fee.Markup, fee.MarkupDecimal = feeAmounts(markup.Int64())
+	return fee
+}
+
+// FacilitatorFeeFromDecimal builds a fee from decimal amounts in cents with up to 9 decimal places, ie: "65.786" for
+// 65.786¢. Either can be left empty but Moov doesn't accept both. The integer fields are set to the decimals rounded
+// to the nearest cent so the pairs always agree.
+func FacilitatorFeeFromDecimal(totalDecimal string, markupDecimal string) (FacilitatorFee, error) {
+	fee := FacilitatorFee{}
+	if totalDecimal != "" && markupDecimal != "" {
+		return fee, fmt.Errorf("%w: total and markup can't both be set", ErrInvalidFacilitatorFee)
+	}
+
+	for _, f := range []struct {
+		decimal string
+		value   *int
+		out     *string
+	}{
+		{totalDecimal, &fee.Total, &fee.TotalDecimal},
+		{markupDecimal, &fee.Markup, &fee.MarkupDecimal},
+	} {
+		if f.decimal == "" {
+			continue
+		}
+
+		nanos, err := parseDecimal(f.decimal, feeDecimalScale)
+		if err != nil {
+			return FacilitatorFee{}, fmt.Errorf("%w: %w", ErrInvalidFacilitatorFee, err)
+		}
+		if nanos < 0 {
+			return FacilitatorFee{}, fmt.Errorf("%w: %q is negative", ErrInvalidFacilitatorFee, f.decimal)
+		}
+
+		*f.value, *f.out = feeAmounts(nanos)
+	}
+
+	return fee, nil
+}
+
+// feeAmounts converts a fee in billionths of a cent into whole cents, rounded half up, and the decimal cents
+func feeAmounts(nanos int64) (int, string) {
+	const scale = 1_000_000_000
+
+	decimal := strings.TrimRight(formatDecimal(nanos, feeDecimalScale), "0")
+	decimal = strings.TrimSuffix(decimal, ".")
+
+	return int((nanos + scale/2) / scale), decimal
+}
+
+// MoovFee is the part of Moov's fees for a transfer charged to one of the accounts in the transfer
+type MoovFee struct {
+	AccountID   string        `json:"accountID,omitempty"`
+	FeeIDs      []string      `json:"feeIDs,omitempty"`
+	TotalAmount AmountDecimal `json:"totalAmount,omitempty"`
+}
+
 type MoovFeeDetails struct {
 	CardScheme     string `json:"cardScheme,omitempty"`
 	Interchange    string `json:"interchange,omitempty"`
 	MoovProcessing string `json:"moovProcessing,omitempty"`
 }
 
+// FeeAmount is a fee in billionths of a cent, the precision of Moov's decimal fee fields, so fees can be summed exactly
+type FeeAmount int64
+
+// Cents rounds the fee to the nearest cent
+func (f FeeAmount) Cents() int {
+	cents, _ := feeAmounts(int64(f))
+	return cents
+}
+
+// String formats the fee as decimal cents like Moov's decimal fee fields, ie: "12.5"
+func (f FeeAmount) String() string {
+	_, decimal := feeAmounts(int64(f))
+	return decimal
+}
+
+// FeeBreakdown is MoovFeeDetails parsed into amounts that can be summed
+type FeeBreakdown struct {
+	CardScheme     FeeAmount
+	Interchange    FeeAmount
+	MoovProcessing FeeAmount
+
+	// Sum of the other fees
+	Total FeeAmount
+}
+
+// Breakdown parses the fee details into a FeeBreakdown, fees Moov left out are zero. Use
+// SynchronousTransfer.MoovFeeBreakdown to also check the fees add up to the transfer's Moov fee.
+func (d MoovFeeDetails) Breakdown() (FeeBreakdown, error) {
+	breakdown := FeeBreakdown{}
+
+	for _, f := range []struct {
+		name    string
+		decimal string
+		amount  *FeeAmount
+	}{
+		{"cardScheme", d.CardScheme, &breakdown.CardScheme},
+		{"interchange", d.Interchange, &breakdown.Interchange},
+		{"moovProcessing", d.MoovProcessing, &breakdown.MoovProcessing},
+	} {
+		if f.decimal == "" {
+			continue
+		}
+
+		nanos, err := parseDecimal(f.decimal, feeDecimalScale)
+		if err != nil {
+			return FeeBreakdown{}, fmt.Errorf("%s: %w", f.name, err)
+		}
+
+		*f.amount = FeeAmount(nanos)
+		breakdown.Total += FeeAmount(nanos)
+	}
+
+	return breakdown, nil
+}
+
+// MoovFeeBreakdown parses the transfer's MoovFeeDetails and checks they add up to its MoovFeeDecimal, or to MoovFee
+// when Moov didn't return the decimal. ErrFeeMismatch is returned when they don't.
+func (t SynchronousTransfer) MoovFeeBreakdown() (FeeBreakdown, error) {
+	breakdown, err := t.MoovFeeDetails.Breakdown()
+	if err != nil {
+		return FeeBreakdown{}, err
+	}
+
+	if t.MoovFeeDecimal == "" {
+		if breakdown.Total.Cents() != t.MoovFee {
+			return FeeBreakdown{}, fmt.Errorf("%w: details add up to %s but the fee is %d", ErrFeeMismatch, breakdown.Total, t.MoovFee)
+		}
+		return breakdown, nil
+	}
+
+	fee, err := parseDecimal(t.MoovFeeDecimal, feeDecimalScale)
+	if err != nil {
+		return FeeBreakdown{}, fmt.Errorf("moovFeeDecimal: %w", err)
+	}
+	if FeeAmount(fee) != breakdown.Total {
+		return FeeBreakdown{}, fmt.Errorf("%w: details add up to %s but the fee is %s", ErrFeeMismatch, breakdown.Total, t.MoovFeeDecimal)
+	}
+
+	return breakdown, nil
+}
+
 type Refund struct {
 	RefundID    string      `json:"refundID,omitempty"`
 	CreatedOn   time.Time   `json:"createdOn,omitempty"`
@@ -89,6 +618,18 @@ type Refund struct {
 	CardDetails CardDetails `json:"cardDetails,omitempty"`
 }
 
+// TypedFailureCode returns the refund's failure code as a RefundFailureCode, or RefundFailureUnknown if it isn't
+// recognized or the refund didn't fail.
+func (r Refund) TypedFailureCode() RefundFailureCode {
+	code, _ := ParseRefundFailureCode(r.FailureCode)
+	return code
+}
+
+// Rail returns the rail the payment method moves money over, or an empty rail for unknown payment method types
+func (s Source) Rail() Rail {
+	return paymentMethodRails[s.PaymentMethodType]
+}
+
 type Source struct {
 	PaymentMethodID   string          `json:"paymentMethodID,omitempty"`
 	PaymentMethodType string          `json:"paymentMethodType,omitempty"`
@@ -130,6 +671,36 @@ type SearchQueryPayload struct {
 	Skip          int       `json:"skip,omitempty"`
 	Refunded      bool      `json:"refunded,omitempty"`
 	Disputed      bool      `json:"disputed,omitempty"`
+	// One of TRANSFER_ORDER_BY_*, Moov's default ordering is used when empty
+	OrderBy string `json:"orderBy,omitempty"`
+	// ORDER_ASC or ORDER_DESC, OrderBy must be set along with it
+	OrderDirection string `json:"orderDirection,omitempty"`
+	// One of PAYMENT_METHOD_TYPE_*, transfers from any payment method type are listed when empty
+	SourcePaymentMethodType string `json:"sourcePaymentMethodType,omitempty"`
+	// One of PAYMENT_METHOD_TYPE_*, transfers to any payment method type are listed when empty
+	DestinationPaymentMethodType string `json:"destinationPaymentMethodType,omitempty"`
+	// Smallest and largest transfer amounts to list in minor units, either bound is left open when 0
+	MinAmount int `json:"minAmount,omitempty"`
+	MaxAmount int `json:"maxAmount,omitempty"`
+	// ISO 4217 currency of the transfers to list
+	Currency string `json:"currency,omitempty"`
+}
+
+const (
+	TRANSFER_ORDER_BY_CREATED_ON   = "createdOn"
+	TRANSFER_ORDER_BY_COMPLETED_ON = "completedOn"
+	TRANSFER_ORDER_BY_AMOUNT       = "amount"
+	TRANSFER_ORDER_BY_STATUS       = "status"
+
+	ORDER_ASC  = "asc"
+	ORDER_DESC = "desc"
+)
+
+var transferOrderByFields = map[string]bool{
+	TRANSFER_ORDER_BY_CREATED_ON:   true,
+	TRANSFER_ORDER_BY_COMPLETED_ON: true,
+	TRANSFER_ORDER_BY_AMOUNT:       true,
+	TRANSFER_ORDER_BY_STATUS:       true,
 }
 
 type MetaDataPayload struct {
@@ -137,7 +708,12 @@ type MetaDataPayload struct {
 }
 
 type RefundPayload struct {
-	Amount int `json:"amount,omitempty"`
+	Amount   int               `json:"amount,omitempty"`
+	Reason   string            `json:"reason,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+
+	// Transfer being refunded, when set the amount is checked against what's left to refund before the refund is sent
+	Transfer *SynchronousTransfer `json:"-"`
 }
 
 type TransferOptionsSourcePayload struct {
@@ -154,16 +730,46 @@ type TransferOptionsPayload struct {
 	Source      TransferOptionsSourcePayload      `json:"source,omitempty"`
 	Destination TransferOptionsDestinationPayload `json:"destination,omitempty"`
 	Amount      Amount                            `json:"amount,omitempty"`
+
+	// Only return options on the rail, along with wallets which can be used with any rail. Moov doesn't filter by
+	// rail so the options are narrowed down after they're returned. All options are returned when empty.
+	Rail Rail `json:"-"`
+}
+
+// validate checks both sides of the payload identify a payment method or an account
+func (payload TransferOptionsPayload) validate() error {
+	if payload.Source.PaymentMethodID == "" && payload.Source.AccountID == "" {
+		return fmt.Errorf("%w: source is empty", ErrTransferOptionsParty)
+	}
+	if payload.Destination.PaymentMethodID == "" && payload.Destination.AccountID == "" {
+		return fmt.Errorf("%w: destination is empty", ErrTransferOptionsParty)
+	}
+	return nil
 }
 
+// optionsOnRail keeps the options on the rail and wallets
+func optionsOnRail(options []Source, rail Rail) []Source {
+	kept := []Source{}
+	for _, option := range options {
+		if option.Rail() == rail || option.Rail() == RAIL_MOOV {
+			kept = append(kept, option)
+		}
+	}
+	return kept
+}
+
+// CreatedTransferOptions are the payment methods a transfer can be made with. Each option has its payment method
+// type along with the wallet, bank account, card or Apple Pay details of the payment method.
 type CreatedTransferOptions struct {
 	SourceOptions      []Source `json:"sourceOptions,omitempty"`
 	DestinationOptions []Source `json:"destinationOptions,omitempty"`
 }
 
 type RefundStatus struct {
-	Status    string    `json:"status,omitempty"`
-	CreatedOn time.Time `json:"createdOn,omitempty"`
+	// Only set on the cancellations returned by CancelTransfer
+	CancellationID string    `json:"cancellationID,omitempty"`
+	Status         string    `json:"status,omitempty"`
+	CreatedOn      time.Time `json:"createdOn,omitempty"`
 }
 
 type CanceledTransfer struct {
@@ -178,15 +784,162 @@ type CreateTransfer struct {
 	FacilitatorFee FacilitatorFee    `json:"facilitatorFee,omitempty"`
 	Description    string            `json:"description,omitempty"`
 	Metadata       map[string]string `json:"metadata,omitempty"`
+	// Groups related transfers, like the legs of a split payment, so they can be listed together with
+	// SearchQueryPayload.GroupID
+	GroupID string `json:"groupID,omitempty"`
+	// Itemized purchase and sales tax for card transfers, which qualify for level 2 and 3 interchange rates when set
+	LineItems      []LineItem `json:"lineItems,omitempty"`
+	SalesTaxAmount *Amount    `json:"salesTaxAmount,omitempty"`
+	// Statement descriptor shown to the cardholder for card transfers, 4 to 22 characters. Sent as the source's
+	// cardDetails.dynamicDescriptor.
+	DynamicDescriptor string `json:"-"`
+}
+
+const (
+	dynamicDescriptorMinLen = 4
+	dynamicDescriptorMaxLen = 22
+)
+
+// dynamicDescriptorProblem describes why the descriptor can't be shown on a card statement, empty when it can. Card
+// networks only take printable ASCII without the characters < > \ ' " *
+func dynamicDescriptorProblem(descriptor string) string {
+	if len(descriptor) < dynamicDescriptorMinLen || len(descriptor) > dynamicDescriptorMaxLen {
+		return fmt.Sprintf("dynamicDescriptor must be %d to %d characters", dynamicDescriptorMinLen, dynamicDescriptorMaxLen)
+	}
+	for _, r := range descriptor {
+		if r < ' ' || r > '~' || strings.ContainsRune(`<>\'"*`, r) {
+			return fmt.Sprintf("dynamicDescriptor can't contain %q", r)
+		}
+	}
+	return ""
+}
+
+// LineItem is one of the items a card transfer pays for
+type LineItem struct {
+	Description string `json:"description,omitempty"`
+	Quantity    int    `json:"quantity,omitempty"`
+	// Price of a single item
+	UnitPrice Amount `json:"unitPrice,omitempty"`
+	// Quantity times the unit price
+	Total Amount `json:"total,omitempty"`
+}
+
+// Validate checks the transfer has a positive amount with an ISO 4217 currency, a source payment method or transfer,
+// and a destination payment method. When there are line items each total has to be its quantity times the unit price,
+// and the totals plus the sales tax have to add up to the amount. A dynamic descriptor has to fit on a card statement.
+// CreateTransfer calls it before sending the transfer to Moov.
+func (t CreateTransfer) Validate() error {
+	problems := []string{}
+	if t.Amount.Value <= 0 {
+		problems = append(problems, "amount.value must be positive")
+	}
+	if t.Amount.Currency == "" {
+		problems = append(problems, "amount.currency is required")
+	} else if err := ValidateCurrency(t.Amount.Currency); err != nil {
+		problems = append(problems, fmt.Sprintf("amount.currency %q is not an ISO 4217 code", t.Amount.Currency))
+	}
+	if t.Source.PaymentMethodID == "" && t.Source.TransferID == "" {
+		problems = append(problems, "source needs a paymentMethodID or transferID")
+	}
+	if t.Destination.PaymentMethodID == "" {
+		problems = append(problems, "destination needs a paymentMethodID")
+	}
+	for _, descriptor := range []string{t.DynamicDescriptor, t.Source.CardDetails.DynamicDescriptor} {
+		if descriptor == "" {
+			continue
+		}
+		if problem := dynamicDescriptorProblem(descriptor); problem != "" {
+			problems = append(problems, problem)
+		}
+	}
+	problems = append(problems, t.lineItemProblems()...)
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidTransfer, strings.Join(problems, ", "))
+	}
+	return nil
+}
+
+// lineItemProblems checks the line items and sales tax are consistent with each other and the amount
+func (t CreateTransfer) lineItemProblems() []string {
+	if len(t.LineItems) == 0 {
+		return nil
+	}
+
+	problems := []string{}
+	sameCurrency := func(a Amount) bool {
+		return strings.EqualFold(a.Currency, t.Amount.Currency)
+	}
+
+	total := 0
+	for i, item := range t.LineItems {
+		if item.Quantity <= 0 {
+			problems = append(problems, fmt.Sprintf("lineItems[%d].quantity must be positive", i))
+		}
+		if !sameCurrency(item.UnitPrice) || !sameCurrency(item.Total) {
+			problems = append(problems, fmt.Sprintf("lineItems[%d] must be in the transfer's currency", i))
+		}
+		if item.Total.Value != item.Quantity*item.UnitPrice.Value {
+			problems = append(problems, fmt.Sprintf("lineItems[%d].total must be the quantity times the unit price", i))
+		}
+		total += item.Total.Value
+	}
+
+	if t.SalesTaxAmount != nil {
+		if !sameCurrency(*t.SalesTaxAmount) {
+			problems = append(problems, "salesTaxAmount must be in the transfer's currency")
+		}
+		total += t.SalesTaxAmount.Value
+	}
+
+	if total != t.Amount.Value {
+		problems = append(problems, fmt.Sprintf("lineItems and salesTaxAmount add up to %d instead of the amount %d", total, t.Amount.Value))
+	}
+	return problems
+}
+
+// DestinationToCard builds a destination pushing funds to a push-to-card payment method. CreateTransfer checks the
+// card is eligible for push-to-card before creating the transfer, looking the payment method up on the destination's
+// Account when it's set or the client's transfer account context otherwise.
+func DestinationToCard(cardPaymentMethodID string) Destination {
+	return Destination{
+		PaymentMethodID:   cardPaymentMethodID,
+		PaymentMethodType: PAYMENT_METHOD_TYPE_PUSH_TO_CARD,
+	}
 }
 
 // CreateTransfer creates a new transfer
 // https://docs.moov.io/api/index.html#tag/Transfers/operation/createTransfer
-func (c Client) CreateTransfer(ctx context.Context, transfer CreateTransfer, isSync bool) (*SynchronousTransfer, *AsynchronousTransfer, error) {
-	args := []callArg{AcceptJson(), JsonBody(transfer)}
+// A random idempotency key is sent unless one is passed in with IdempotencyKey, which should be reused when retrying
+// a request that timed out so the transfer isn't created twice.
+// When isSync is true the call blocks until the rail responds, pass WaitFor to block on a different state instead.
+// If the state isn't reached in time the transfer is returned as an AsynchronousTransfer.
+// When the idempotency key was already used the transfer it created is returned with ErrIdempotencyConflict.
+func (c Client) CreateTransfer(ctx context.Context, transfer CreateTransfer, isSync bool, opts ...callArg) (*SynchronousTransfer, *AsynchronousTransfer, error) {
+	transfer.Amount.Currency = strings.ToUpper(transfer.Amount.Currency)
+	if err := transfer.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	if !c.currencyAllowed(transfer.Amount.Currency) {
+		return nil, nil, fmt.Errorf("%w: %s", ErrCurrencyNotAllowed, transfer.Amount.Currency)
+	}
+
+	if transfer.DynamicDescriptor != "" {
+		transfer.Source.CardDetails.DynamicDescriptor = transfer.DynamicDescriptor
+	}
+
+	if transfer.Destination.PaymentMethodType == PAYMENT_METHOD_TYPE_PUSH_TO_CARD {
+		if err := c.checkPushToCard(ctx, transfer.Destination); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	args := []callArg{AcceptJson(), JsonBody(transfer), IdempotencyKey(uuid.NewString())}
 	if isSync {
 		args = append(args, WaitFor("rail-response"))
 	}
+	args = prependArgs(opts, args...)
 
 	resp, err := c.CallHttp(ctx, Endpoint(http.MethodPost, pathTransfers), args...)
 	if err != nil {
@@ -199,36 +952,173 @@ func (c Client) CreateTransfer(ctx context.Context, transfer CreateTransfer, isS
 		return st, nil, err
 	case StatusStarted:
 		st, err := UnmarshalObjectResponse[AsynchronousTransfer](resp)
+		if err == nil && isSync && c.asyncAsError {
+			return nil, nil, &UnexpectedAsyncError{TransferID: st.TransferID}
+		}
 		return nil, st, err
 	case StatusStateConflict:
-		return nil, nil, ErrXIdempotencyKey
+		// Moov answers with the transfer created by the first request using the key
+		st, err := UnmarshalObjectResponse[SynchronousTransfer](resp)
+		if err != nil || st.TransferID == "" {
+			return nil, nil, ErrXIdempotencyKey
+		}
+		return st, nil, ErrIdempotencyConflict
 	default:
 		return nil, nil, resp.Error()
 	}
 }
 
+// BulkOptions controls how CreateTransfers creates the transfers
+type BulkOptions struct {
+	// Number of transfers created at once, defaults to 5
+	Concurrency int
+	// Wait for the rail response like CreateTransfer's isSync
+	Sync bool
+}
+
+// BulkTransferResult is the outcome of creating one of the transfers passed to CreateTransfers. Either Transfer or
+// Started is set when it was created, depending on if a synchronous response was received.
+type BulkTransferResult struct {
+	Transfer *SynchronousTransfer
+	Started  *AsynchronousTransfer
+	Err      error
+}
+
+// CreateTransfers creates each of the transfers concurrently, each with its own idempotency key. All transfers are
+// attempted even when some fail, the results are in the same order as the transfers and the returned error joins the
+// errors of the ones that failed. If the context is done first its error is set on the transfers that weren't
+// attempted.
+func (c Client) CreateTransfers(ctx context.Context, transfers []CreateTransfer, opts BulkOptions) ([]BulkTransferResult, error) {
+	results := make([]BulkTransferResult, len(transfers))
+	attempted := make([]bool, len(transfers))
+
+	err := forEachConcurrent(ctx, len(transfers), opts.Concurrency, func(ctx context.Context, i int) {
+		attempted[i] = true
+		completed, started, err := c.CreateTransfer(ctx, transfers[i], opts.Sync)
+		results[i] = BulkTransferResult{Transfer: completed, Started: started, Err: err}
+	})
+	if err != nil {
+		for i := range results {
+			if !attempted[i] {
+				results[i].Err = err
+			}
+		}
+	}
+
+	errs := []error{}
+	for i, result := range results {
+		if result.Err != nil {
+			errs = append(errs, fmt.Errorf("transfer %d: %w", i, result.Err))
+		}
+	}
+
+	return results, errors.Join(errs...)
+}
+
+// checkPushToCard makes sure the destination payment method is a card that funds can be pushed to
+func (c Client) checkPushToCard(ctx context.Context, destination Destination) error {
+	accountID := destination.Account.AccountID
+	if accountID == "" {
+		accountID = c.transferAccountID
+	}
+	if accountID == "" {
+		return ErrPushToCardAccountNotSet
+	}
+
+	pm, err := c.GetPaymentMethod(ctx, accountID, destination.PaymentMethodID)
+	if err != nil {
+		return err
+	}
+
+	if pm.PaymentMethodType != PAYMENT_METHOD_TYPE_PUSH_TO_CARD || !pm.Card.PushToCardEligible() {
+		return ErrNotPushToCardEligible
+	}
+
+	return nil
+}
+
 // ListTransfers lists all transfers
 // https://docs.moov.io/api/index.html#tag/Transfers/operation/listTransfers
-func (c Client) ListTransfers(payload SearchQueryPayload) ([]SynchronousTransfer, error) {
-	var respTransfers []SynchronousTransfer
+func (c Client) ListTransfers(ctx context.Context, payload SearchQueryPayload) ([]SynchronousTransfer, error) {
+	transfers, _, err := c.ListTransfersWithCount(ctx, payload)
+	return transfers, err
+}
+
+// ListAccountTransfers lists the transfers of a single account matching the rest of the filter. Any AccountIDs on
+// the filter are replaced by the account.
+// https://docs.moov.io/api/index.html#tag/Transfers/operation/listTransfers
+func (c Client) ListAccountTransfers(ctx context.Context, accountID string, filter SearchQueryPayload) ([]SynchronousTransfer, error) {
+	filter.AccountIDs = []string{accountID}
+	return c.ListTransfers(ctx, filter)
+}
 
+// ListTransfersWithCount lists transfers like ListTransfers along with the total number of transfers matching the
+// search across all pages, as reported by Moov in the X-Total-Count header. The total is -1 when Moov didn't send it.
+func (c Client) ListTransfersWithCount(ctx context.Context, payload SearchQueryPayload) ([]SynchronousTransfer, int, error) {
+	query, err := payload.queryValues()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	resp, err := c.CallHttp(ctx,
+		Endpoint(http.MethodGet, pathTransfers),
+		AcceptJson(),
+		QueryParams(query),
+		paged())
+	if err != nil {
+		return nil, 0, err
+	}
+
+	transfers, err := CompletedListOrError[SynchronousTransfer](resp)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return transfers, TotalCount(resp), nil
+}
+
+// eachTransfer pages through all transfers matching the search, starting at its Skip, and calls fn for each of them
+// until fn returns an error.
+func (c Client) eachTransfer(ctx context.Context, payload SearchQueryPayload, fn func(SynchronousTransfer) error) error {
+	if payload.Count <= 0 {
+		payload.Count = 200
+	}
+
+	for {
+		page, err := c.ListTransfers(ctx, payload)
+		if err != nil {
+			return err
+		}
+
+		for _, transfer := range page {
+			if err := fn(transfer); err != nil {
+				return err
+			}
+		}
+
+		if len(page) < payload.Count {
+			return nil
+		}
+		payload.Skip += payload.Count
+	}
+}
+
+// queryValues converts the non-empty fields of the search into query string values
+func (payload SearchQueryPayload) queryValues() (url.Values, error) {
 	values := url.Values{}
-	// Convert time values to ISO8601 format
-	startDateTimeStr := payload.StartDateTime.Format(time.RFC3339)
-	endDateTimeStr := payload.EndDateTime.Format(time.RFC3339)
 
-	// Add non-empty fields to the query string
 	if len(payload.AccountIDs) > 0 {
 		values.Add("accountIDs", strings.Join(payload.AccountIDs, ","))
 	}
 	if payload.Status != "" {
 		values.Add("status", payload.Status)
 	}
+	// Convert time values to ISO8601 format
 	if !payload.StartDateTime.IsZero() {
-		values.Add("startDateTime", startDateTimeStr)
+		values.Add("startDateTime", payload.StartDateTime.Format(time.RFC3339))
 	}
 	if !payload.EndDateTime.IsZero() {
-		values.Add("endDateTime", endDateTimeStr)
+		values.Add("endDateTime", payload.EndDateTime.Format(time.RFC3339))
 	}
 	if payload.GroupID != "" {
 		values.Add("groupID", payload.GroupID)
@@ -245,241 +1135,519 @@ func (c Client) ListTransfers(payload SearchQueryPayload) ([]SynchronousTransfer
 	if payload.Disputed {
 		values.Add("disputed", "true")
 	}
+	if payload.MinAmount < 0 || payload.MaxAmount < 0 {
+		return nil, fmt.Errorf("%w: amounts must not be negative", ErrInvalidAmountRange)
+	}
+	if payload.MinAmount > 0 && payload.MaxAmount > 0 && payload.MinAmount > payload.MaxAmount {
+		return nil, fmt.Errorf("%w: min %d is more than max %d", ErrInvalidAmountRange, payload.MinAmount, payload.MaxAmount)
+	}
+	if payload.MinAmount > 0 {
+		values.Add("minAmount", fmt.Sprint(payload.MinAmount))
+	}
+	if payload.MaxAmount > 0 {
+		values.Add("maxAmount", fmt.Sprint(payload.MaxAmount))
+	}
+	if payload.Currency != "" {
+		if err := ValidateCurrency(payload.Currency); err != nil {
+			return nil, err
+		}
+		values.Add("currency", strings.ToUpper(payload.Currency))
+	}
+	if payload.SourcePaymentMethodType != "" {
+		if !paymentMethodTypes[payload.SourcePaymentMethodType] {
+			return nil, fmt.Errorf("%w: source %q", ErrInvalidPaymentMethodType, payload.SourcePaymentMethodType)
+		}
+		values.Add("sourcePaymentMethodType", payload.SourcePaymentMethodType)
+	}
+	if payload.DestinationPaymentMethodType != "" {
+		if !paymentMethodTypes[payload.DestinationPaymentMethodType] {
+			return nil, fmt.Errorf("%w: destination %q", ErrInvalidPaymentMethodType, payload.DestinationPaymentMethodType)
+		}
+		values.Add("destinationPaymentMethodType", payload.DestinationPaymentMethodType)
+	}
+
+	if payload.OrderBy != "" {
+		if !transferOrderByFields[payload.OrderBy] {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidOrderBy, payload.OrderBy)
+		}
+		values.Add("orderBy", payload.OrderBy)
+
+		switch payload.OrderDirection {
+		case "":
+		case ORDER_ASC, ORDER_DESC:
+			values.Add("orderDirection", payload.OrderDirection)
+		default:
+			return nil, fmt.Errorf("%w: direction %q", ErrInvalidOrderBy, payload.OrderDirection)
+		}
+	} else if payload.OrderDirection != "" {
+		return nil, fmt.Errorf("%w: direction %q without an OrderBy", ErrInvalidOrderBy, payload.OrderDirection)
+	}
 
-	urlStr := fmt.Sprintf("%s/%s?%s", baseURL, pathTransfers, values.Encode())
+	return values, nil
+}
 
-	body, statusCode, err := c.GetHTTPResponse(http.MethodGet, urlStr, nil, nil)
+// GetTransfer retrieves a transfer. Polling can pass IfNoneMatch with the ETag of the transfer it last got, in which
+// case ErrNotModified is returned until the transfer changes.
+// https://docs.moov.io/api/index.html#tag/Transfers/operation/getTransfer
+func (c Client) GetTransfer(ctx context.Context, transferID string, accountID string, opts ...callArg) (SynchronousTransfer, error) {
+	args := prependArgs(opts, AcceptJson(), QueryParams(c.transferAccountQuery(accountID)))
+	resp, err := c.CallHttp(ctx, Endpoint(http.MethodGet, pathTransfer, transferID), args...)
 	if err != nil {
-		return respTransfers, err
+		return SynchronousTransfer{}, err
 	}
 
-	switch statusCode {
-	case http.StatusOK:
-		err = json.Unmarshal(body, &respTransfers)
+	switch resp.Status() {
+	case StatusCompleted:
+		transfer, err := UnmarshalObjectResponse[SynchronousTransfer](resp)
 		if err != nil {
-			return respTransfers, err
+			return SynchronousTransfer{}, err
 		}
-		return respTransfers, nil
-	case http.StatusTooManyRequests:
-		return respTransfers, ErrRateLimit
+		transfer.ETag = resp.Header("ETag")
+		return *transfer, nil
+	case StatusNotModified:
+		return SynchronousTransfer{}, ErrNotModified
+	case StatusRateLimited:
+		return SynchronousTransfer{}, ErrRateLimit
+	default:
+		return SynchronousTransfer{}, resp.Error()
 	}
-	return respTransfers, ErrDefault(statusCode)
 }
 
-// GetTransfer retrieves a transfer
-// https://docs.moov.io/api/index.html#tag/Transfers/operation/getTransfer
-func (c Client) GetTransfer(transferID string, accountID string) (SynchronousTransfer, error) {
-	var respTransfer SynchronousTransfer
-
-	values := url.Values{}
-	if accountID != "" {
-		values.Add("accountID", accountID)
+// GetTransfers retrieves each of the transfers concurrently, returning the ones that were found keyed by their ID.
+// Every transfer is attempted even when some fail, the returned error joins the errors of the ones that failed. If the
+// context is done first its error is included for the transfers that weren't attempted.
+func (c Client) GetTransfers(ctx context.Context, accountID string, transferIDs []string) (map[string]SynchronousTransfer, error) {
+	// repeated IDs are only retrieved once
+	ids := []string{}
+	seen := map[string]bool{}
+	for _, id := range transferIDs {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
 	}
 
-	urlStr := fmt.Sprintf("%s/%s/%s?%s", baseURL, pathTransfers, transferID, values.Encode())
+	var mu sync.Mutex
+	transfers := make(map[string]SynchronousTransfer, len(ids))
+	errs := make([]error, len(ids))
+	attempted := make([]bool, len(ids))
+
+	err := forEachConcurrent(ctx, len(ids), defaultConcurrency, func(ctx context.Context, i int) {
+		attempted[i] = true
+		transfer, err := c.GetTransfer(ctx, ids[i], accountID)
+		if err != nil {
+			errs[i] = fmt.Errorf("transfer %s: %w", ids[i], err)
+			return
+		}
 
-	body, statusCode, err := c.GetHTTPResponse(http.MethodGet, urlStr, nil, nil)
+		mu.Lock()
+		transfers[ids[i]] = transfer
+		mu.Unlock()
+	})
 	if err != nil {
-		return respTransfer, err
+		for i := range ids {
+			if !attempted[i] {
+				errs[i] = fmt.Errorf("transfer %s: %w", ids[i], err)
+			}
+		}
 	}
 
-	switch statusCode {
-	case http.StatusOK:
-		err = json.Unmarshal(body, &respTransfer)
+	return transfers, errors.Join(errs...)
+}
+
+// WaitOptions controls how often WaitForTransfer polls the transfer
+type WaitOptions struct {
+	// Wait before the first poll, doubled after every poll that isn't final. Defaults to a second.
+	Interval time.Duration
+
+	// Longest wait between polls. Defaults to 30 seconds.
+	MaxInterval time.Duration
+}
+
+// WaitForTransfer polls the transfer until it's completed, failed, reversed or canceled, or the context is done. The
+// final transfer is returned, along with a TransferFailedError when it failed.
+func (c Client) WaitForTransfer(ctx context.Context, accountID string, transferID string, opts WaitOptions) (*SynchronousTransfer, error) {
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = time.Second
+	}
+	maxInterval := opts.MaxInterval
+	if maxInterval <= 0 {
+		maxInterval = 30 * time.Second
+	}
+
+	for {
+		if err := sleep(ctx, interval); err != nil {
+			return nil, err
+		}
+
+		transfer, err := c.GetTransfer(ctx, transferID, accountID)
 		if err != nil {
-			return respTransfer, err
+			return nil, err
 		}
-		return respTransfer, nil
-	case http.StatusTooManyRequests:
-		return respTransfer, ErrRateLimit
+
+		switch transfer.TypedStatus() {
+		case TransferStatusCompleted, TransferStatusReversed, TransferStatusCanceled:
+			return &transfer, nil
+		case TransferStatusFailed:
+			return &transfer, &TransferFailedError{TransferID: transfer.TransferID, FailureReason: transfer.FailureReason}
+		}
+
+		interval = min(interval*2, maxInterval)
 	}
-	return respTransfer, ErrDefault(statusCode)
 }
 
-// UpdateTransferMetaData updates the metadata for a transfer
-// https://docs.moov.io/api/index.html#tag/Transfers/operation/patchTransfer
-func (c Client) UpdateTransferMetaData(transferID string, accountID string, metadata map[string]string) (SynchronousTransfer, error) {
-	var respTransfer SynchronousTransfer
+// transferAccountQuery returns the accountID query for a transfer read, using the client's transfer account context
+// when accountID is empty.
+func (c Client) transferAccountQuery(accountID string) url.Values {
+	if accountID == "" {
+		accountID = c.transferAccountID
+	}
 
 	values := url.Values{}
 	if accountID != "" {
 		values.Add("accountID", accountID)
 	}
-	urlStr := fmt.Sprintf("%s/%s/%s?%s", baseURL, pathTransfers, transferID, values.Encode())
-	metaDataPayload := MetaDataPayload{
-		Metadata: metadata,
+	return values
+}
+
+// UpdateTransferMetaData updates the metadata for a transfer
+// https://docs.moov.io/api/index.html#tag/Transfers/operation/patchTransfer
+func (c Client) UpdateTransferMetaData(ctx context.Context, transferID string, accountID string, metadata map[string]string) (SynchronousTransfer, error) {
+	var respTransfer SynchronousTransfer
+
+	if err := validateMetadata(metadata); err != nil {
+		return respTransfer, err
 	}
 
-	body, statusCode, err := c.GetHTTPResponse(http.MethodPatch, urlStr, metaDataPayload, nil)
+	resp, err := c.CallHttp(ctx,
+		Endpoint(http.MethodPatch, pathTransfer, transferID),
+		AcceptJson(),
+		QueryParams(c.transferAccountQuery(accountID)),
+		JsonBody(MetaDataPayload{Metadata: metadata}))
 	if err != nil {
 		return respTransfer, err
 	}
 
-	switch statusCode {
-	case http.StatusOK:
-		err = json.Unmarshal(body, &respTransfer)
-		if err != nil {
+	switch resp.Status() {
+	case StatusCompleted:
+		if err := resp.Unmarshal(&respTransfer); err != nil {
 			return respTransfer, err
 		}
 		return respTransfer, nil
-	case http.StatusTooManyRequests:
+	case StatusRateLimited:
 		return respTransfer, ErrRateLimit
+	default:
+		return respTransfer, resp.Error()
 	}
-	return respTransfer, ErrDefault(statusCode)
 }
 
-// TransferOptions lists all transfer options between a source and destination
+// TransferOptions lists all transfer options between a source and destination. Each side needs a paymentMethodID or
+// an accountID, ErrTransferOptionsParty is returned without calling Moov otherwise.
 // https://docs.moov.io/api/#tag/Transfers/operation/createTransferOptions
-func (c Client) TransferOptions(payload TransferOptionsPayload) (CreatedTransferOptions, error) {
+func (c Client) TransferOptions(ctx context.Context, payload TransferOptionsPayload) (CreatedTransferOptions, error) {
 	var respOptions CreatedTransferOptions
-	urlStr := fmt.Sprintf("%s/%s", baseURL, pathTransferOptions)
+	if err := payload.validate(); err != nil {
+		return respOptions, err
+	}
 
-	body, statusCode, err := c.GetHTTPResponse(http.MethodPost, urlStr, payload, nil)
+	resp, err := c.CallHttp(ctx,
+		Endpoint(http.MethodPost, pathTransferOptions),
+		AcceptJson(),
+		JsonBody(payload))
 	if err != nil {
 		return respOptions, err
 	}
 
-	switch statusCode {
-	case http.StatusOK:
-		err = json.Unmarshal(body, &respOptions)
-		if err != nil {
+	switch resp.Status() {
+	case StatusCompleted:
+		if err := resp.Unmarshal(&respOptions); err != nil {
 			return respOptions, err
 		}
+		if payload.Rail != "" {
+			respOptions.SourceOptions = optionsOnRail(respOptions.SourceOptions, payload.Rail)
+			respOptions.DestinationOptions = optionsOnRail(respOptions.DestinationOptions, payload.Rail)
+		}
 		return respOptions, nil
-	case http.StatusTooManyRequests:
+	case StatusRateLimited:
 		return respOptions, ErrRateLimit
+	default:
+		return respOptions, resp.Error()
 	}
-	return respOptions, ErrDefault(statusCode)
 }
 
-// RefundTransfer refunds a transfer
+// RefundTransfer refunds a transfer. Like CreateTransfer a random idempotency key is sent unless one is passed in.
 // https://docs.moov.io/api/#tag/Transfers/operation/refundTransfer
-func (c Client) RefundTransfer(transferID string, isSync bool, amount int) (Refund, error) {
-	var respRefund Refund
+func (c Client) RefundTransfer(ctx context.Context, transferID string, isSync bool, amount int, opts ...callArg) (Refund, error) {
+	return c.RefundTransferWithPayload(ctx, transferID, isSync, RefundPayload{Amount: amount}, opts...)
+}
 
-	urlStr := fmt.Sprintf("%s/%s/%s/refunds", baseURL, pathTransfers, transferID)
-	uuidV4 := uuid.NewString()
-	header := map[string]string{"X-Idempotency-Key": uuidV4}
-	if isSync {
-		header["X-Wait-For"] = "rail-response"
+// RefundTransferWithPayload refunds a transfer with a reason and metadata. Pass an IdempotencyKey to safely retry the
+// refund, otherwise a random key is sent. When the payload holds the transfer, ErrRefundAmountExceeded is returned
+// without calling Moov for amounts over what's left to refund.
+// https://docs.moov.io/api/#tag/Transfers/operation/refundTransfer
+func (c Client) RefundTransferWithPayload(ctx context.Context, transferID string, isSync bool, payload RefundPayload, opts ...callArg) (Refund, error) {
+	if payload.Transfer != nil {
+		// without an amount Moov refunds the whole transfer, which is too much once any of it has been refunded
+		amount := payload.Amount
+		if amount == 0 {
+			amount = payload.Transfer.Amount.Value
+		}
+
+		remaining := payload.Transfer.Amount.Value - payload.Transfer.RefundedAmount.Value
+		if amount < 0 || amount > remaining {
+			return Refund{}, ErrRefundAmountExceeded
+		}
 	}
 
-	refundPayload := RefundPayload{
-		Amount: amount,
+	args := []callArg{AcceptJson(), JsonBody(payload), IdempotencyKey(uuid.NewString())}
+	if isSync {
+		args = append(args, WaitFor("rail-response"))
 	}
-	body, statusCode, err := c.GetHTTPResponse(http.MethodPost, urlStr, refundPayload, header)
+	args = prependArgs(opts, args...)
 
+	resp, err := c.CallHttp(ctx, Endpoint(http.MethodPost, pathTransferRefunds, transferID), args...)
 	if err != nil {
-		return respRefund, err
+		return Refund{}, err
 	}
 
-	switch statusCode {
-	case http.StatusOK, http.StatusAccepted:
-		err = json.Unmarshal(body, &respRefund)
-		if err != nil {
-			return respRefund, err
-		}
-		return respRefund, nil
-	case http.StatusBadRequest:
-		var err error
-		_ = json.Unmarshal(body, &err)
-		return respRefund, err
-	case http.StatusConflict:
-		return respRefund, ErrXIdempotencyKey
-	case http.StatusUnprocessableEntity:
-		return respRefund, ErrRequestBody
-	case http.StatusTooManyRequests:
-		return respRefund, ErrRateLimit
+	if isSync && c.asyncAsError && resp.Status() == StatusStarted {
+		return Refund{}, &UnexpectedAsyncError{TransferID: transferID}
+	}
+
+	refund, err := transferActionResult[Refund](resp)
+	if err != nil {
+		return Refund{}, err
 	}
-	return respRefund, ErrDefault(statusCode)
+	return *refund, nil
 }
 
-// ListRefunds lists all refunds for a transfer
+const (
+	REFUND_STATUS_CREATED   = "created"
+	REFUND_STATUS_PENDING   = "pending"
+	REFUND_STATUS_COMPLETED = "completed"
+	REFUND_STATUS_FAILED    = "failed"
+)
+
+// ListRefunds lists the refunds for a transfer, only returning the ones in the given REFUND_STATUS_* statuses when
+// any are passed. The client's transfer account context is used when accountID is empty.
 // https://docs.moov.io/api/index.html#tag/Transfers/operation/getRefunds
-func (c Client) ListRefunds(transferID string) ([]Refund, error) {
-	var respRefunds []Refund
+func (c Client) ListRefunds(ctx context.Context, transferID string, accountID string, statuses ...string) ([]Refund, error) {
+	resp, err := c.CallHttp(ctx,
+		Endpoint(http.MethodGet, pathTransferRefunds, transferID),
+		AcceptJson(),
+		QueryParams(c.transferAccountQuery(accountID)))
+	if err != nil {
+		return nil, err
+	}
 
-	urlStr := fmt.Sprintf("%s/%s/%s/refunds", baseURL, pathTransfers, transferID)
+	refunds, err := CompletedListOrError[Refund](resp)
+	if err != nil || len(statuses) == 0 {
+		return refunds, err
+	}
 
-	body, statusCode, err := c.GetHTTPResponse(http.MethodGet, urlStr, nil, nil)
+	filtered := []Refund{}
+	for _, refund := range refunds {
+		for _, status := range statuses {
+			if refund.Status == status {
+				filtered = append(filtered, refund)
+				break
+			}
+		}
+	}
+	return filtered, nil
+}
+
+// GetRefund retrieves a refund for a transfer. The client's transfer account context is used when accountID is empty.
+// https://docs.moov.io/api/index.html#tag/Transfers/operation/getRefund
+func (c Client) GetRefund(ctx context.Context, transferID string, refundID string, accountID string) (*Refund, error) {
+	resp, err := c.CallHttp(ctx,
+		Endpoint(http.MethodGet, pathTransferRefund, transferID, refundID),
+		AcceptJson(),
+		QueryParams(c.transferAccountQuery(accountID)))
 	if err != nil {
-		return respRefunds, err
+		return nil, err
 	}
 
-	switch statusCode {
-	case http.StatusOK:
-		err = json.Unmarshal(body, &respRefunds)
+	return CompletedObjectOrError[Refund](resp)
+}
+
+// ReverseOptions controls how a transfer is reversed
+type ReverseOptions struct {
+	// RefundIfSettled allows Moov to refund the transfer when it has already settled and can't be canceled.
+	// When false the transfer is only canceled and ErrAlreadySettled is returned once Moov can no longer cancel it.
+	RefundIfSettled bool
+
+	// AccountID the transfer is canceled on behalf of when RefundIfSettled is false, the client's transfer account
+	// context is used when empty
+	AccountID string
+}
+
+// ReverseTransfer reverses a transfer by canceling it or refunding it if it has already settled
+// https://docs.moov.io/api/index.html#tag/Transfers/operation/reverseTransfer
+func (c Client) ReverseTransfer(ctx context.Context, transferID string, amount int, callOpts ...callArg) (CanceledTransfer, error) {
+	return c.ReverseTransferWithOptions(ctx, transferID, amount, ReverseOptions{RefundIfSettled: true}, callOpts...)
+}
+
+// ReverseTransferWithOptions reverses a transfer, see ReverseOptions for controlling if a refund can be issued. When
+// refunds aren't allowed the transfer is canceled through CancelTransfer, and the amount doesn't apply.
+// https://docs.moov.io/api/index.html#tag/Transfers/operation/reverseTransfer
+func (c Client) ReverseTransferWithOptions(ctx context.Context, transferID string, amount int, opts ReverseOptions, callOpts ...callArg) (CanceledTransfer, error) {
+	respTransfer := CanceledTransfer{}
+
+	// Moov decides if the transfer can still be canceled, checking beforehand races with the transfer settling
+	if !opts.RefundIfSettled {
+		accountID := opts.AccountID
+		if accountID == "" {
+			accountID = c.transferAccountID
+		}
+		if accountID == "" {
+			return respTransfer, ErrCancelAccountNotSet
+		}
+
+		canceled, err := c.cancelTransfer(ctx, accountID, transferID, callOpts...)
+		if errors.Is(err, ErrNotCancelable) {
+			return respTransfer, fmt.Errorf("%w: %w", ErrAlreadySettled, err)
+		}
 		if err != nil {
-			return respRefunds, err
+			return respTransfer, err
 		}
-		return respRefunds, nil
-	case http.StatusTooManyRequests:
-		return respRefunds, ErrRateLimit
+		return *canceled, nil
 	}
-	return respRefunds, ErrDefault(statusCode)
-}
 
-// GetRefund retrieves a refund for a transfer
-// https://docs.moov.io/api/index.html#tag/Transfers/operation/getRefund
-func (c Client) GetRefund(transferID string, refundID string) (Refund, error) {
-	var respRefund Refund
+	args := prependArgs(callOpts, AcceptJson(), JsonBody(RefundPayload{Amount: amount}), IdempotencyKey(uuid.NewString()))
+
+	resp, err := c.CallHttp(ctx, Endpoint(http.MethodPost, pathTransferReversal, transferID), args...)
+	if err != nil {
+		return respTransfer, err
+	}
 
-	urlStr := fmt.Sprintf("%s/%s/%s/refunds/%s", baseURL, pathTransfers, transferID, refundID)
+	reversal, err := transferActionResult[CanceledTransfer](resp)
+	if err != nil {
+		return respTransfer, err
+	}
+	return *reversal, nil
+}
+
+// CancelTransfer cancels a transfer that is still created or queued without refunding it. Returns ErrNotCancelable
+// once the transfer has moved on, use ReverseTransfer to refund it instead.
+// https://docs.moov.io/api/money-movement/transfers/cancel/
+func (c Client) CancelTransfer(ctx context.Context, accountID string, transferID string) (*CanceledTransfer, error) {
+	return c.cancelTransfer(ctx, accountID, transferID)
+}
 
-	body, statusCode, err := c.GetHTTPResponse(http.MethodGet, urlStr, nil, nil)
+func (c Client) cancelTransfer(ctx context.Context, accountID string, transferID string, opts ...callArg) (*CanceledTransfer, error) {
+	resp, err := c.CallHttp(ctx,
+		Endpoint(http.MethodPost, pathTransferCancels, accountID, transferID),
+		prependArgs(opts, AcceptJson())...)
 	if err != nil {
-		return respRefund, err
+		return nil, err
 	}
 
-	switch statusCode {
-	case http.StatusOK:
-		err = json.Unmarshal(body, &respRefund)
+	switch resp.Status() {
+	case StatusCompleted, StatusStarted:
+		cancellation, err := UnmarshalObjectResponse[RefundStatus](resp)
 		if err != nil {
-			return respRefund, err
+			return nil, err
 		}
-		return respRefund, nil
-	case http.StatusTooManyRequests:
-		return respRefund, ErrRateLimit
+		return &CanceledTransfer{Cancellation: *cancellation}, nil
+	case StatusStateConflict:
+		return nil, ErrNotCancelable
+	default:
+		return nil, resp.Error()
 	}
-	return respRefund, ErrDefault(statusCode)
 }
 
-// ReverseTransfer reverses a transfer
-// https://docs.moov.io/api/index.html#tag/Transfers/operation/reverseTransfer
-func (c Client) ReverseTransfer(transferID string, amount int) (CanceledTransfer, error) {
-	respTransfer := CanceledTransfer{}
+// transferActionResult reads the result of refunding or reversing a transfer, which is returned both when the
+// action completed and when it's still being processed.
+func transferActionResult[A interface{}](resp CallResponse) (*A, error) {
+	switch resp.Status() {
+	case StatusCompleted, StatusStarted:
+		return UnmarshalObjectResponse[A](resp)
+	case StatusBadRequest:
+		return nil, transferValidationError(resp)
+	case StatusStateConflict:
+		return nil, ErrXIdempotencyKey
+	case StatusFailedValidation:
+		return nil, ErrRequestBody
+	case StatusRateLimited:
+		return nil, ErrRateLimit
+	default:
+		return nil, resp.Error()
+	}
+}
 
-	urlStr := fmt.Sprintf("%s/%s/%s/reversals", baseURL, pathTransfers, transferID)
-	uuidV4 := uuid.NewString()
-	header := map[string]string{"X-Idempotency-Key": uuidV4}
+type RefundPreviewAction string
 
-	refundPayload := RefundPayload{
-		Amount: amount,
-	}
+const (
+	// RefundPreviewCancellation the transfer hasn't settled so reversing it cancels the full transfer
+	RefundPreviewCancellation RefundPreviewAction = "cancellation"
+	// RefundPreviewRefund the transfer has settled so reversing it refunds the amount to the source
+	RefundPreviewRefund RefundPreviewAction = "refund"
+)
 
-	body, statusCode, err := c.GetHTTPResponse(http.MethodPost, urlStr, refundPayload, header)
+// RefundPreview describes what reversing a transfer would do without making any changes
+type RefundPreview struct {
+	TransferID string              `json:"transferID,omitempty"`
+	Action     RefundPreviewAction `json:"action,omitempty"`
+	// Amount that will be returned to the source
+	Amount Amount `json:"amount,omitempty"`
+	// Moov and facilitator fees returned because the transfer was canceled before being charged
+	FeesReturned int `json:"feesReturned,omitempty"`
+	// Moov and facilitator fees that are kept when the transfer is refunded
+	NonRefundableFees int `json:"nonRefundableFees,omitempty"`
+}
 
+// PreviewRefund retrieves a transfer and computes the outcome of reversing it for the given amount.
+// An amount of zero previews returning everything that hasn't been refunded yet. Transfers that haven't settled are
+// canceled in full, ErrPartialCancellation is returned for any other amount.
+func (c Client) PreviewRefund(ctx context.Context, transferID string, accountID string, amount int) (*RefundPreview, error) {
+	transfer, err := c.GetTransfer(ctx, transferID, accountID)
 	if err != nil {
-		return respTransfer, err
+		return nil, err
 	}
 
-	switch statusCode {
-	case http.StatusOK, http.StatusAccepted:
-		err = json.Unmarshal(body, &respTransfer)
-		if err != nil {
-			return respTransfer, err
+	return previewRefund(transfer, amount)
+}
+
+func previewRefund(transfer SynchronousTransfer, amount int) (*RefundPreview, error) {
+	fees := transfer.MoovFee + transfer.FacilitatorFee.Total
+
+	preview := &RefundPreview{
+		TransferID: transfer.TransferID,
+		Amount: Amount{
+			Currency: transfer.Amount.Currency,
+		},
+	}
+
+	switch transfer.TypedStatus() {
+	case TransferStatusCreated, TransferStatusQueued, TransferStatusPending:
+		// Canceling returns the entire transfer along with any fees that were going to be collected
+		if amount != 0 && amount != transfer.Amount.Value {
+			return nil, ErrPartialCancellation
 		}
-		return respTransfer, nil
-	case http.StatusBadRequest:
-		var err error
-		_ = json.Unmarshal(body, &err)
-		return respTransfer, err
-	case http.StatusConflict:
-		return respTransfer, ErrXIdempotencyKey
-	case http.StatusUnprocessableEntity:
-		return respTransfer, ErrRequestBody
-	case http.StatusTooManyRequests:
-		return respTransfer, ErrRateLimit
+
+		preview.Action = RefundPreviewCancellation
+		preview.Amount.Value = transfer.Amount.Value
+		preview.FeesReturned = fees
+
+	case TransferStatusCompleted:
+		remaining := transfer.Amount.Value - transfer.RefundedAmount.Value
+		if amount == 0 {
+			amount = remaining
+		}
+
+		if amount < 0 || amount > remaining {
+			return nil, ErrRefundAmountExceeded
+		}
+
+		preview.Action = RefundPreviewRefund
+		preview.Amount.Value = amount
+		preview.NonRefundableFees = fees
+
+	default:
+		return nil, ErrTransferNotRefundable
 	}
-	return respTransfer, ErrDefault(statusCode)
+
+	return preview, nil
 }
@@ -5,7 +5,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	moov "github.com/moovfinancial/moov-go/pkg"
 	"github.com/stretchr/testify/suite"
@@ -205,9 +211,1206 @@ func TestSynchronousTransferMarshalResponse(t *testing.T) {
 	require.NoError(t, err)
 
 	require.Equal(t, "Gym Dues", transfer.Source.AchDetails.CompanyEntryDescription)
+	require.Empty(t, transfer.Extra, "all fields returned by Moov should be modeled")
 	t.Logf("%#v", transfer)
 }
 
+func TestSynchronousTransferUnmarshalExtra(t *testing.T) {
+	input := []byte(`{
+		"transferID": "ec7e1848-dc80-4ab0-8827-dd7fc0737b43",
+		"status": "completed",
+		"amount": { "currency": "USD","value": 1204 },
+		"futureField": {"nested": true},
+		"anotherField": "value"
+	}`)
+
+	transfer := moov.SynchronousTransfer{}
+	err := json.Unmarshal(input, &transfer)
+	require.NoError(t, err)
+
+	require.Equal(t, "ec7e1848-dc80-4ab0-8827-dd7fc0737b43", transfer.TransferID)
+	require.Equal(t, 1204, transfer.Amount.Value)
+
+	require.Len(t, transfer.Extra, 2)
+	require.JSONEq(t, `{"nested": true}`, string(transfer.Extra["futureField"]))
+	require.JSONEq(t, `"value"`, string(transfer.Extra["anotherField"]))
+
+	// modeled fields never end up in Extra
+	require.NotContains(t, transfer.Extra, "transferID")
+	require.NotContains(t, transfer.Extra, "amount")
+
+	// Extra is not sent back to Moov
+	out, err := json.Marshal(transfer)
+	require.NoError(t, err)
+	require.NotContains(t, string(out), "futureField")
+}
+
+// decodeStrict decodes the json into v failing on any field v doesn't model
+func decodeStrict(t *testing.T, data []byte, v any) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	require.NoError(t, dec.Decode(v), string(data))
+}
+
+func TestSynchronousTransferUnmarshal_Current(t *testing.T) {
+	// A card payment as Moov returns it today, sent from a payment link and later partly canceled
+	input := []byte(`{
+		"transferID": "ec7e1848-dc80-4ab0-8827-dd7fc0737b43",
+		"createdOn": "2024-05-01T14:15:22Z",
+		"completedOn": "2024-05-03T14:15:22Z",
+		"status": "completed",
+		"amount": {"currency": "USD", "value": 3200},
+		"description": "Yoga class",
+		"metadata": {"class": "yoga"},
+		"facilitatorFee": {"total": 8, "totalDecimal": "8.0", "markup": 8, "markupDecimal": "8.0"},
+		"moovFee": 6,
+		"moovFeeDecimal": "6.25",
+		"moovFeeDetails": {"cardScheme": "0.25", "interchange": "5", "moovProcessing": "1"},
+		"moovFees": [{
+			"accountID": "3dfff852-927d-47e8-822c-2fffc57ff6b9",
+			"feeIDs": ["c3ad8d81-6c88-47e0-a5ca-fd3d4f37c1d2"],
+			"totalAmount": {"currency": "USD", "valueDecimal": "6.25"}
+		}],
+		"groupID": "ec7e1848-dc80-4ab0-8827-dd7fc0737b43",
+		"cancellations": [{"cancellationID": "4a4f7a7e-8bce-4b27-bdb1-d9ac0dca87e8", "status": "completed", "createdOn": "2024-05-01T15:00:00Z"}],
+		"refundedAmount": {"currency": "USD", "value": 0},
+		"refunds": [{
+			"refundID": "d5b2d1b0-6b55-4e1f-8f4a-c0e7a8ed2e5f",
+			"createdOn": "2024-05-04T14:15:22Z",
+			"updatedOn": "2024-05-04T14:15:22Z",
+			"status": "failed",
+			"failureCode": "call-issuer",
+			"amount": {"currency": "USD", "value": 1000},
+			"cardDetails": {"status": "failed", "failureCode": "call-issuer", "statusUpdates": {"initiated": "2024-05-04T14:15:22Z", "failed": "2024-05-04T14:15:23Z"}}
+		}],
+		"disputedAmount": {"currency": "USD", "value": 0},
+		"disputes": [],
+		"salesTaxAmount": {"currency": "USD", "value": 200},
+		"paymentLinkCode": "uc7ZYKrMhi",
+		"source": {
+			"paymentMethodID": "9506dbf6-4208-44c3-ad8a-e4431660e1f2",
+			"paymentMethodType": "card-payment",
+			"account": {"accountID": "c520f1b9-0ba7-4f42-a2a4-a3ae7d1cb9d7", "email": "jules@example.com", "displayName": "Jules Jackson"},
+			"card": {"cardID": "01234567-89ab-cdef-0123-456789abcdef", "brand": "Visa", "cardType": "credit", "lastFourCardNumber": "1111", "bin": "411111", "expiration": {"month": "12", "year": "30"}},
+			"cardDetails": {
+				"status": "completed",
+				"dynamicDescriptor": "WhlBdy *Yoga 11-12",
+				"transactionSource": "first-recurring",
+				"statusUpdates": {"initiated": "2024-05-01T14:15:22Z", "confirmed": "2024-05-01T14:15:23Z", "settled": "2024-05-02T14:15:22Z", "completed": "2024-05-03T14:15:22Z"}
+			}
+		},
+		"destination": {
+			"paymentMethodID": "ec7e1848-dc80-4ab0-8827-dd7fc0737b43",
+			"paymentMethodType": "moov-wallet",
+			"account": {"accountID": "3dfff852-927d-47e8-822c-2fffc57ff6b9", "email": "amanda@classbooker.dev", "displayName": "Whole Body Fitness"},
+			"wallet": {"walletID": "ec7e1848-dc80-4ab0-8827-dd7fc0737b43"}
+		}
+	}`)
+
+	transfer := moov.SynchronousTransfer{}
+	decodeStrict(t, input, &transfer)
+	require.Empty(t, transfer.Extra, "all fields returned by Moov should be modeled")
+
+	require.Equal(t, "uc7ZYKrMhi", transfer.PaymentLinkCode)
+	require.Equal(t, &moov.Amount{Currency: "USD", Value: 200}, transfer.SalesTaxAmount)
+	require.Len(t, transfer.Cancellations, 1)
+	require.Equal(t, "completed", transfer.Cancellations[0].Status)
+	require.Equal(t, "6.25", transfer.MoovFees[0].TotalAmount.ValueDecimal)
+
+	// SynchronousTransfer decodes itself so nested objects aren't held to DisallowUnknownFields above, check them
+	// one by one
+	sections := map[string]json.RawMessage{}
+	require.NoError(t, json.Unmarshal(input, &sections))
+
+	decodeStrict(t, sections["source"], &moov.Source{})
+	decodeStrict(t, sections["destination"], &moov.Destination{})
+	decodeStrict(t, sections["refunds"], &[]moov.Refund{})
+	decodeStrict(t, sections["cancellations"], &[]moov.RefundStatus{})
+	decodeStrict(t, sections["moovFees"], &[]moov.MoovFee{})
+
+	// and a sweep transfer
+	sweep := moov.SynchronousTransfer{}
+	decodeStrict(t, []byte(`{"transferID": "transfer-id", "sweepID": "sweep-id", "status": "pending"}`), &sweep)
+	require.Equal(t, "sweep-id", sweep.SweepID)
+	require.Empty(t, sweep.Extra)
+}
+
+func TestStatusTimeline(t *testing.T) {
+	transfer := moov.SynchronousTransfer{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"transferID": "ec7e1848-dc80-4ab0-8827-dd7fc0737b43",
+		"createdOn": "2024-01-02T10:00:00Z",
+		"completedOn": "2024-01-05T10:00:00Z",
+		"status": "completed",
+		"source": {
+			"achDetails": {
+				"statusUpdates": {
+					"initiated": "2024-01-02T10:00:01Z",
+					"originated": "2024-01-02T18:00:00Z",
+					"corrected": "2024-01-04T09:00:00Z",
+					"completed": "2024-01-03T12:00:00Z"
+				}
+			}
+		},
+		"destination": {
+			"achDetails": {
+				"statusUpdates": {
+					"initiated": "2024-01-03T12:00:01Z",
+					"originated": "2024-01-03T18:00:00Z",
+					"returned": "2024-01-05T09:00:00Z"
+				}
+			}
+		}
+	}`), &transfer))
+
+	at := func(s string) time.Time {
+		parsed, err := time.Parse(time.RFC3339, s)
+		require.NoError(t, err)
+		return parsed
+	}
+
+	require.Equal(t, []moov.StatusEvent{
+		{Side: "", Status: "created", Time: at("2024-01-02T10:00:00Z")},
+		{Side: "source", Status: "initiated", Time: at("2024-01-02T10:00:01Z")},
+		{Side: "source", Status: "originated", Time: at("2024-01-02T18:00:00Z")},
+		{Side: "source", Status: "completed", Time: at("2024-01-03T12:00:00Z")},
+		{Side: "destination", Status: "initiated", Time: at("2024-01-03T12:00:01Z")},
+		{Side: "destination", Status: "originated", Time: at("2024-01-03T18:00:00Z")},
+		{Side: "source", Status: "corrected", Time: at("2024-01-04T09:00:00Z")},
+		{Side: "destination", Status: "returned", Time: at("2024-01-05T09:00:00Z")},
+		{Side: "", Status: "completed", Time: at("2024-01-05T10:00:00Z")},
+	}, transfer.StatusTimeline())
+
+	require.Empty(t, moov.SynchronousTransfer{}.StatusTimeline())
+}
+
+func TestTransferStatus(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected moov.TransferStatus
+	}{
+		{"created", moov.TransferStatusCreated},
+		{"pending", moov.TransferStatusPending},
+		{"completed", moov.TransferStatusCompleted},
+		{"failed", moov.TransferStatusFailed},
+		{"reversed", moov.TransferStatusReversed},
+		{"queued", moov.TransferStatusQueued},
+		{"canceled", moov.TransferStatusCanceled},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.input, func(t *testing.T) {
+			status, err := moov.ParseTransferStatus(tc.input)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, status)
+			require.Equal(t, tc.input, status.String())
+
+			transfer := moov.SynchronousTransfer{Status: tc.input}
+			require.Equal(t, tc.expected, transfer.TypedStatus())
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		status, err := moov.ParseTransferStatus("settled")
+		require.Error(t, err)
+		require.Equal(t, moov.TransferStatusUnknown, status)
+		require.Equal(t, "unknown", status.String())
+		require.Equal(t, "unknown", moov.TransferStatus(42).String())
+
+		transfer := moov.SynchronousTransfer{Status: "settled"}
+		require.Equal(t, moov.TransferStatusUnknown, transfer.TypedStatus())
+	})
+}
+
+func TestTransferFailureReason(t *testing.T) {
+	cases := []struct {
+		input     string
+		expected  moov.TransferFailureReason
+		retryable bool
+	}{
+		{"source-payment-error", moov.TransferFailureSourcePaymentError, false},
+		{"destination-payment-error", moov.TransferFailureDestinationPaymentError, false},
+		{"wallet-insufficient-funds", moov.TransferFailureWalletInsufficientFunds, true},
+		{"rejected-high-risk", moov.TransferFailureRejectedHighRisk, false},
+		{"processing-error", moov.TransferFailureProcessingError, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.input, func(t *testing.T) {
+			reason, err := moov.ParseTransferFailureReason(tc.input)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, reason)
+			require.Equal(t, tc.input, reason.String())
+			require.Equal(t, tc.retryable, reason.IsRetryable())
+
+			transfer := moov.SynchronousTransfer{Status: "failed", FailureReason: tc.input}
+			require.Equal(t, tc.expected, transfer.TypedFailureReason())
+		})
+	}
+
+	t.Run("not failed", func(t *testing.T) {
+		reason := moov.SynchronousTransfer{Status: "completed"}.TypedFailureReason()
+		require.Equal(t, moov.TransferFailureUnknown, reason)
+		require.Equal(t, "unknown", reason.String())
+		require.False(t, reason.IsRetryable())
+	})
+}
+
+func TestRefundFailureCode(t *testing.T) {
+	cases := []struct {
+		input     string
+		expected  moov.RefundFailureCode
+		retryable bool
+	}{
+		{"call-issuer", moov.RefundFailureCallIssuer, false},
+		{"do-not-honor", moov.RefundFailureDoNotHonor, false},
+		{"processing-error", moov.RefundFailureProcessingError, true},
+		{"invalid-transaction", moov.RefundFailureInvalidTransaction, false},
+		{"suspected-fraud", moov.RefundFailureSuspectedFraud, false},
+		{"other", moov.RefundFailureOther, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.input, func(t *testing.T) {
+			code, err := moov.ParseRefundFailureCode(tc.input)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, code)
+			require.Equal(t, tc.input, code.String())
+			require.Equal(t, tc.retryable, code.IsRetryable())
+
+			refund := moov.Refund{Status: "failed", FailureCode: tc.input}
+			require.Equal(t, tc.expected, refund.TypedFailureCode())
+		})
+	}
+
+	code, err := moov.ParseRefundFailureCode("insufficient-funds")
+	require.Error(t, err)
+	require.Equal(t, moov.RefundFailureUnknown, code)
+}
+
+func TestPreviewRefund(t *testing.T) {
+	cases := []struct {
+		name              string
+		status            string
+		amount            int
+		expectedAction    moov.RefundPreviewAction
+		expectedAmount    int
+		expectedReturned  int
+		expectedNonRefund int
+		expectedErr       error
+	}{
+		{name: "created", status: "created", amount: 1204, expectedAction: moov.RefundPreviewCancellation, expectedAmount: 1204, expectedReturned: 33},
+		{name: "created partial", status: "created", amount: 500, expectedErr: moov.ErrPartialCancellation},
+		{name: "pending", status: "pending", expectedAction: moov.RefundPreviewCancellation, expectedAmount: 1204, expectedReturned: 33},
+		{name: "completed full", status: "completed", expectedAction: moov.RefundPreviewRefund, expectedAmount: 1004, expectedNonRefund: 33},
+		{name: "completed partial", status: "completed", amount: 500, expectedAction: moov.RefundPreviewRefund, expectedAmount: 500, expectedNonRefund: 33},
+		{name: "completed exceeded", status: "completed", amount: 1100, expectedErr: moov.ErrRefundAmountExceeded},
+		{name: "failed", status: "failed", expectedErr: moov.ErrTransferNotRefundable},
+		{name: "reversed", status: "reversed", expectedErr: moov.ErrTransferNotRefundable},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, http.MethodGet, r.Method)
+				WriteJson(w, http.StatusOK, fmt.Sprintf(`{
+					"transferID": "ec7e1848-dc80-4ab0-8827-dd7fc0737b43",
+					"status": %q,
+					"amount": {"currency": "USD", "value": 1204},
+					"refundedAmount": {"currency": "USD", "value": 200},
+					"moovFee": 25,
+					"facilitatorFee": {"total": 8}
+				}`, tc.status))
+			})
+
+			preview, err := mc.PreviewRefund(BgCtx(), "ec7e1848-dc80-4ab0-8827-dd7fc0737b43", "", tc.amount)
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			require.Equal(t, tc.expectedAction, preview.Action)
+			require.Equal(t, "USD", preview.Amount.Currency)
+			require.Equal(t, tc.expectedAmount, preview.Amount.Value)
+			require.Equal(t, tc.expectedReturned, preview.FeesReturned)
+			require.Equal(t, tc.expectedNonRefund, preview.NonRefundableFees)
+		})
+	}
+}
+
+// newCreateTransfer returns a transfer of the amount between two payment methods that passes local validation
+func newCreateTransfer(currency string, value int) moov.CreateTransfer {
+	return moov.CreateTransfer{
+		Source:      moov.Source{PaymentMethodID: "source-payment-method-id"},
+		Destination: moov.Destination{PaymentMethodID: "destination-payment-method-id"},
+		Amount:      moov.Amount{Currency: currency, Value: value},
+	}
+}
+
+func TestCreateTransfer_LineItems(t *testing.T) {
+	transfer := newCreateTransfer("USD", 2600)
+	transfer.LineItems = []moov.LineItem{
+		{Description: "Yoga class", Quantity: 2, UnitPrice: moov.Amount{Currency: "USD", Value: 1000}, Total: moov.Amount{Currency: "USD", Value: 2000}},
+		{Description: "Mat rental", Quantity: 1, UnitPrice: moov.Amount{Currency: "USD", Value: 400}, Total: moov.Amount{Currency: "USD", Value: 400}},
+	}
+	transfer.SalesTaxAmount = &moov.Amount{Currency: "USD", Value: 200}
+
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body := map[string]json.RawMessage{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		require.JSONEq(t, `{"currency": "USD", "value": 200}`, string(body["salesTaxAmount"]))
+		require.JSONEq(t, `[
+			{"description": "Yoga class", "quantity": 2, "unitPrice": {"currency": "USD", "value": 1000}, "total": {"currency": "USD", "value": 2000}},
+			{"description": "Mat rental", "quantity": 1, "unitPrice": {"currency": "USD", "value": 400}, "total": {"currency": "USD", "value": 400}}
+		]`, string(body["lineItems"]))
+
+		WriteJson(w, http.StatusOK, `{"transferID": "ec7e1848-dc80-4ab0-8827-dd7fc0737b43"}`)
+	})
+
+	_, _, err := mc.CreateTransfer(BgCtx(), transfer, true)
+	require.NoError(t, err)
+
+	// transfers without line items leave them out
+	data, err := json.Marshal(newCreateTransfer("USD", 2600))
+	require.NoError(t, err)
+	require.NotContains(t, string(data), "lineItems")
+	require.NotContains(t, string(data), "salesTaxAmount")
+}
+
+func TestCreateTransfer_LineItemsInconsistent(t *testing.T) {
+	item := moov.LineItem{Description: "Yoga class", Quantity: 2, UnitPrice: moov.Amount{Currency: "USD", Value: 1000}, Total: moov.Amount{Currency: "USD", Value: 2000}}
+
+	transfer := newCreateTransfer("USD", 2600)
+	transfer.LineItems = []moov.LineItem{item}
+	err := transfer.Validate()
+	require.ErrorIs(t, err, moov.ErrInvalidTransfer)
+	require.Contains(t, err.Error(), "add up to 2000 instead of the amount 2600")
+
+	item.Total.Value = 1000
+	transfer = newCreateTransfer("USD", 1000)
+	transfer.LineItems = []moov.LineItem{item}
+	err = transfer.Validate()
+	require.ErrorIs(t, err, moov.ErrInvalidTransfer)
+	require.Contains(t, err.Error(), "lineItems[0].total must be the quantity times the unit price")
+
+	// nothing is sent when the line items don't add up
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("inconsistent line items should not be sent")
+	})
+	_, _, err = mc.CreateTransfer(BgCtx(), transfer, true)
+	require.ErrorIs(t, err, moov.ErrInvalidTransfer)
+}
+
+func TestCreateTransfer_DynamicDescriptor(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body := struct {
+			Source struct {
+				CardDetails struct {
+					DynamicDescriptor string `json:"dynamicDescriptor"`
+				} `json:"cardDetails"`
+			} `json:"source"`
+		}{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.Equal(t, "WhlBdy Yoga 11-19", body.Source.CardDetails.DynamicDescriptor)
+
+		WriteJson(w, http.StatusOK, `{"transferID": "ec7e1848-dc80-4ab0-8827-dd7fc0737b43"}`)
+	})
+
+	transfer := newCreateTransfer("USD", 1204)
+	transfer.DynamicDescriptor = "WhlBdy Yoga 11-19"
+
+	_, _, err := mc.CreateTransfer(BgCtx(), transfer, true)
+	require.NoError(t, err)
+}
+
+func TestCreateTransfer_DynamicDescriptorInvalid(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("invalid descriptors should not be sent")
+	})
+
+	cases := map[string]string{
+		"WhlBdy Yoga Studio Membership": "must be 4 to 22 characters",
+		"WB":                            "must be 4 to 22 characters",
+		`WhlBdy "Yoga"`:                 "can't contain '\"'",
+		"WhlBdy Yoga\n":                 "can't contain '\\n'",
+	}
+
+	for descriptor, problem := range cases {
+		transfer := newCreateTransfer("USD", 1204)
+		transfer.DynamicDescriptor = descriptor
+
+		_, _, err := mc.CreateTransfer(BgCtx(), transfer, true)
+		require.ErrorIs(t, err, moov.ErrInvalidTransfer, descriptor)
+		require.Contains(t, err.Error(), problem, descriptor)
+	}
+}
+
+func TestCreateTransfer_AllowedCurrencies(t *testing.T) {
+	calls := 0
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		WriteJson(w, http.StatusOK, `{"transferID": "ec7e1848-dc80-4ab0-8827-dd7fc0737b43", "amount": {"currency": "USD", "value": 1204}}`)
+	}, moov.WithAllowedCurrencies("usd"))
+
+	completed, _, err := mc.CreateTransfer(BgCtx(), newCreateTransfer("USD", 1204), true)
+	require.NoError(t, err)
+	require.Equal(t, "ec7e1848-dc80-4ab0-8827-dd7fc0737b43", completed.TransferID)
+	require.Equal(t, 1, calls)
+
+	_, _, err = mc.CreateTransfer(BgCtx(), newCreateTransfer("EUR", 1204), true)
+	require.ErrorIs(t, err, moov.ErrCurrencyNotAllowed)
+	require.Equal(t, 1, calls, "disallowed currency should not be sent to Moov")
+}
+
+func TestCreateTransfer_WaitFor(t *testing.T) {
+	waitFor := ""
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		waitFor = r.Header.Get("X-Wait-For")
+		if waitFor == "" {
+			WriteJson(w, http.StatusCreated, `{"transferID": "ec7e1848-dc80-4ab0-8827-dd7fc0737b43", "createdOn": "2019-08-24T14:15:22Z"}`)
+			return
+		}
+		WriteJson(w, http.StatusOK, `{"transferID": "ec7e1848-dc80-4ab0-8827-dd7fc0737b43", "status": "pending"}`)
+	})
+
+	transfer := newCreateTransfer("USD", 1204)
+
+	t.Run("default", func(t *testing.T) {
+		completed, started, err := mc.CreateTransfer(BgCtx(), transfer, true)
+		require.NoError(t, err)
+		require.Nil(t, started)
+		require.Equal(t, "pending", completed.Status)
+		require.Equal(t, "rail-response", waitFor)
+	})
+
+	t.Run("custom", func(t *testing.T) {
+		completed, started, err := mc.CreateTransfer(BgCtx(), transfer, true, moov.WaitFor("payment"))
+		require.NoError(t, err)
+		require.Nil(t, started)
+		require.NotNil(t, completed)
+		require.Equal(t, "payment", waitFor)
+	})
+
+	t.Run("async", func(t *testing.T) {
+		completed, started, err := mc.CreateTransfer(BgCtx(), transfer, false)
+		require.NoError(t, err)
+		require.Nil(t, completed)
+		require.Equal(t, "ec7e1848-dc80-4ab0-8827-dd7fc0737b43", started.TransferID)
+		require.Empty(t, waitFor)
+	})
+}
+
+func TestAsyncAsError(t *testing.T) {
+	// the rail didn't respond in time so every call comes back asynchronous
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/refunds") {
+			WriteJson(w, http.StatusAccepted, `{"refundID": "refund-id", "status": "created"}`)
+			return
+		}
+		WriteJson(w, http.StatusCreated, `{"transferID": "transfer-id", "createdOn": "2019-08-24T14:15:22Z"}`)
+	}
+
+	transfer := newCreateTransfer("USD", 1204)
+
+	t.Run("default", func(t *testing.T) {
+		mc := NewMockClient(t, handler)
+
+		completed, started, err := mc.CreateTransfer(BgCtx(), transfer, true)
+		require.NoError(t, err)
+		require.Nil(t, completed)
+		require.Equal(t, "transfer-id", started.TransferID)
+
+		refund, err := mc.RefundTransfer(BgCtx(), "transfer-id", true, 100)
+		require.NoError(t, err)
+		require.Equal(t, "refund-id", refund.RefundID)
+	})
+
+	t.Run("as error", func(t *testing.T) {
+		mc := NewMockClient(t, handler, moov.WithAsyncAsError())
+
+		completed, started, err := mc.CreateTransfer(BgCtx(), transfer, true)
+		require.ErrorIs(t, err, moov.ErrUnexpectedAsync)
+		require.Nil(t, completed)
+		require.Nil(t, started)
+
+		asyncErr := &moov.UnexpectedAsyncError{}
+		require.ErrorAs(t, err, &asyncErr)
+		require.Equal(t, "transfer-id", asyncErr.TransferID)
+
+		_, err = mc.RefundTransfer(BgCtx(), "transfer-id", true, 100)
+		require.ErrorAs(t, err, &asyncErr)
+		require.Equal(t, "transfer-id", asyncErr.TransferID)
+
+		// asynchronous calls are expected to start
+		_, started, err = mc.CreateTransfer(BgCtx(), transfer, false)
+		require.NoError(t, err)
+		require.Equal(t, "transfer-id", started.TransferID)
+	})
+}
+
+func TestRefundTransferWithPayload(t *testing.T) {
+	requests := 0
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		require.Equal(t, "/transfers/transfer-id/refunds", r.URL.Path)
+		require.Equal(t, "refund-key", r.Header.Get("X-Idempotency-Key"))
+
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"amount": 500, "reason": "customer request", "metadata": {"ticket": "1234"}}`, string(body))
+
+		WriteJson(w, http.StatusOK, `{"refundID": "refund-id", "status": "created", "amount": {"currency": "USD", "value": 500}}`)
+	})
+
+	payload := moov.RefundPayload{
+		Amount:   500,
+		Reason:   "customer request",
+		Metadata: map[string]string{"ticket": "1234"},
+	}
+
+	refund, err := mc.RefundTransferWithPayload(BgCtx(), "transfer-id", false, payload, moov.IdempotencyKey("refund-key"))
+	require.NoError(t, err)
+	require.Equal(t, "refund-id", refund.RefundID)
+
+	// the transfer is only sent along for the local check
+	payload.Transfer = &moov.SynchronousTransfer{
+		Amount:         moov.Amount{Currency: "USD", Value: 1000},
+		RefundedAmount: moov.Amount{Currency: "USD", Value: 500},
+	}
+	_, err = mc.RefundTransferWithPayload(BgCtx(), "transfer-id", false, payload, moov.IdempotencyKey("refund-key"))
+	require.NoError(t, err)
+	require.Equal(t, 2, requests)
+
+	payload.Amount = 501
+	_, err = mc.RefundTransferWithPayload(BgCtx(), "transfer-id", false, payload, moov.IdempotencyKey("refund-key"))
+	require.ErrorIs(t, err, moov.ErrRefundAmountExceeded)
+	require.Equal(t, 2, requests)
+
+	// a full refund is more than what's left of a partly refunded transfer
+	payload.Amount = 0
+	_, err = mc.RefundTransferWithPayload(BgCtx(), "transfer-id", false, payload, moov.IdempotencyKey("refund-key"))
+	require.ErrorIs(t, err, moov.ErrRefundAmountExceeded)
+	require.Equal(t, 2, requests)
+}
+
+func TestTransfer_ContextCanceled(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	})
+
+	transferID := "ec7e1848-dc80-4ab0-8827-dd7fc0737b43"
+	calls := map[string]func(ctx context.Context) error{
+		"ListTransfers": func(ctx context.Context) error {
+			_, err := mc.ListTransfers(ctx, moov.SearchQueryPayload{})
+			return err
+		},
+		"GetTransfer": func(ctx context.Context) error {
+			_, err := mc.GetTransfer(ctx, transferID, "")
+			return err
+		},
+		"UpdateTransferMetaData": func(ctx context.Context) error {
+			_, err := mc.UpdateTransferMetaData(ctx, transferID, "", map[string]string{"key": "value"})
+			return err
+		},
+		"TransferOptions": func(ctx context.Context) error {
+			_, err := mc.TransferOptions(ctx, moov.TransferOptionsPayload{
+				Source:      moov.TransferOptionsSourcePayload{AccountID: "source-account-id"},
+				Destination: moov.TransferOptionsDestinationPayload{AccountID: "destination-account-id"},
+			})
+			return err
+		},
+		"RefundTransfer": func(ctx context.Context) error {
+			_, err := mc.RefundTransfer(ctx, transferID, false, 100)
+			return err
+		},
+		"ListRefunds": func(ctx context.Context) error {
+			_, err := mc.ListRefunds(ctx, transferID, "")
+			return err
+		},
+		"GetRefund": func(ctx context.Context) error {
+			_, err := mc.GetRefund(ctx, transferID, "refund-id", "")
+			return err
+		},
+		"ReverseTransfer": func(ctx context.Context) error {
+			_, err := mc.ReverseTransfer(ctx, transferID, 100)
+			return err
+		},
+	}
+
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(BgCtx(), 20*time.Millisecond)
+			defer cancel()
+
+			start := time.Now()
+			err := call(ctx)
+			require.ErrorIs(t, err, context.DeadlineExceeded)
+			require.Less(t, time.Since(start), time.Second)
+		})
+	}
+}
+
+func TestCreateTransfer_PushToCard(t *testing.T) {
+	created := false
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/accounts/account-id/payment-methods/eligible-pm":
+			WriteJson(w, http.StatusOK, `{"paymentMethodID": "eligible-pm", "paymentMethodType": "push-to-card", "card": {"domesticPushToCard": "fast-funds"}}`)
+		case "/accounts/account-id/payment-methods/ineligible-pm":
+			WriteJson(w, http.StatusOK, `{"paymentMethodID": "ineligible-pm", "paymentMethodType": "push-to-card", "card": {"domesticPushToCard": "not-supported"}}`)
+		case "/transfers":
+			created = true
+
+			transfer := moov.CreateTransfer{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&transfer))
+			require.Equal(t, "eligible-pm", transfer.Destination.PaymentMethodID)
+
+			WriteJson(w, http.StatusOK, `{"transferID": "ec7e1848-dc80-4ab0-8827-dd7fc0737b43"}`)
+		default:
+			t.Fatalf("unexpected request to %s", r.URL.Path)
+		}
+	}
+	mc := NewMockClient(t, handler, moov.WithTransferAccountContext("account-id"))
+
+	t.Run("eligible", func(t *testing.T) {
+		created = false
+		transfer := newCreateTransfer("USD", 1204)
+		transfer.Destination = moov.DestinationToCard("eligible-pm")
+		_, _, err := mc.CreateTransfer(BgCtx(), transfer, true)
+		require.NoError(t, err)
+		require.True(t, created)
+	})
+
+	t.Run("ineligible", func(t *testing.T) {
+		created = false
+		transfer := newCreateTransfer("USD", 1204)
+		transfer.Destination = moov.DestinationToCard("ineligible-pm")
+		_, _, err := mc.CreateTransfer(BgCtx(), transfer, true)
+		require.ErrorIs(t, err, moov.ErrNotPushToCardEligible)
+		require.False(t, created)
+	})
+
+	t.Run("destination account", func(t *testing.T) {
+		created = false
+		transfer := newCreateTransfer("USD", 1204)
+		transfer.Destination = moov.DestinationToCard("eligible-pm")
+		transfer.Destination.Account.AccountID = "account-id"
+		_, _, err := NewMockClient(t, handler).CreateTransfer(BgCtx(), transfer, true)
+		require.NoError(t, err)
+		require.True(t, created)
+	})
+
+	t.Run("no account", func(t *testing.T) {
+		created = false
+		transfer := newCreateTransfer("USD", 1204)
+		transfer.Destination = moov.DestinationToCard("eligible-pm")
+		_, _, err := NewMockClient(t, handler).CreateTransfer(BgCtx(), transfer, true)
+		require.ErrorIs(t, err, moov.ErrPushToCardAccountNotSet)
+		require.False(t, created)
+	})
+}
+
+func TestGetTransfer_IfNoneMatch(t *testing.T) {
+	etags := []string{}
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/transfers/transfer-id", r.URL.Path)
+
+		etag := r.Header.Get("If-None-Match")
+		etags = append(etags, etag)
+		if etag == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Header().Set("ETag", `"v1"`)
+		WriteJson(w, http.StatusOK, `{"transferID": "transfer-id", "status": "pending"}`)
+	})
+
+	transfer, err := mc.GetTransfer(BgCtx(), "transfer-id", "")
+	require.NoError(t, err)
+	require.Equal(t, "pending", transfer.Status)
+	require.Equal(t, `"v1"`, transfer.ETag)
+
+	_, err = mc.GetTransfer(BgCtx(), "transfer-id", "", moov.IfNoneMatch(transfer.ETag))
+	require.ErrorIs(t, err, moov.ErrNotModified)
+
+	require.Equal(t, []string{"", `"v1"`}, etags)
+}
+
+func TestWithTransferAccountContext(t *testing.T) {
+	accountIDs := []string{}
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		accountIDs = append(accountIDs, r.URL.Query().Get("accountID"))
+		if strings.HasSuffix(r.URL.Path, "/refunds") {
+			WriteJson(w, http.StatusOK, `[]`)
+			return
+		}
+		WriteJson(w, http.StatusOK, `{}`)
+	}
+
+	transferID := "ec7e1848-dc80-4ab0-8827-dd7fc0737b43"
+
+	mc := NewMockClient(t, handler, moov.WithTransferAccountContext("facilitator-id"))
+
+	_, err := mc.GetTransfer(BgCtx(), transferID, "")
+	require.NoError(t, err)
+	_, err = mc.GetTransfer(BgCtx(), transferID, "other-id")
+	require.NoError(t, err)
+	_, err = mc.ListRefunds(BgCtx(), transferID, "")
+	require.NoError(t, err)
+	_, err = mc.GetRefund(BgCtx(), transferID, "refund-id", "")
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"facilitator-id", "other-id", "facilitator-id", "facilitator-id"}, accountIDs)
+
+	// without the option no accountID is sent by default
+	accountIDs = nil
+	_, err = NewMockClient(t, handler).GetTransfer(BgCtx(), transferID, "")
+	require.NoError(t, err)
+	require.Equal(t, []string{""}, accountIDs)
+}
+
+func TestListRefunds_Filter(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/transfers/transfer-id/refunds", r.URL.Path)
+		require.Equal(t, "account-id", r.URL.Query().Get("accountID"))
+
+		WriteJson(w, http.StatusOK, `[
+			{"refundID": "refund-1", "status": "completed", "cardDetails": {"dynamicDescriptor": "WWW.CLASSBOOKER.DEV"}},
+			{"refundID": "refund-2", "status": "failed", "failureCode": "call-issuer"},
+			{"refundID": "refund-3", "status": "pending"}
+		]`)
+	})
+
+	refunds, err := mc.ListRefunds(BgCtx(), "transfer-id", "account-id")
+	require.NoError(t, err)
+	require.Len(t, refunds, 3)
+
+	refunds, err = mc.ListRefunds(BgCtx(), "transfer-id", "account-id", moov.REFUND_STATUS_COMPLETED)
+	require.NoError(t, err)
+	require.Len(t, refunds, 1)
+	require.Equal(t, "refund-1", refunds[0].RefundID)
+	require.Equal(t, "WWW.CLASSBOOKER.DEV", refunds[0].CardDetails.DynamicDescriptor)
+
+	refunds, err = mc.ListRefunds(BgCtx(), "transfer-id", "account-id", moov.REFUND_STATUS_PENDING, moov.REFUND_STATUS_FAILED)
+	require.NoError(t, err)
+	require.Len(t, refunds, 2)
+	require.Equal(t, "refund-2", refunds[0].RefundID)
+	require.Equal(t, "refund-3", refunds[1].RefundID)
+}
+
+func TestCreateTransfers(t *testing.T) {
+	keys := sync.Map{}
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, dup := keys.LoadOrStore(r.Header.Get("X-Idempotency-Key"), true)
+		require.False(t, dup)
+
+		transfer := moov.CreateTransfer{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&transfer))
+
+		// odd amounts fail validation
+		if transfer.Amount.Value%2 == 1 {
+			WriteJson(w, http.StatusUnprocessableEntity, `{"error": "invalid amount"}`)
+			return
+		}
+		WriteJson(w, http.StatusOK, fmt.Sprintf(`{"transferID": "transfer-%d", "status": "pending"}`, transfer.Amount.Value))
+	})
+
+	transfers := []moov.CreateTransfer{}
+	for i := 0; i < 10; i++ {
+		transfers = append(transfers, newCreateTransfer("USD", 100+i))
+	}
+
+	results, err := mc.CreateTransfers(BgCtx(), transfers, moov.BulkOptions{Concurrency: 3, Sync: true})
+	require.Error(t, err)
+	require.Len(t, results, 10)
+
+	for i, result := range results {
+		if i%2 == 1 {
+			require.Nil(t, result.Transfer)
+			require.Error(t, result.Err)
+			require.Contains(t, err.Error(), fmt.Sprintf("transfer %d:", i))
+
+			var callErr moov.HttpCallError
+			require.ErrorAs(t, result.Err, &callErr)
+			require.Equal(t, http.StatusUnprocessableEntity, callErr.StatusCode())
+			continue
+		}
+
+		require.NoError(t, result.Err)
+		require.Equal(t, fmt.Sprintf("transfer-%d", 100+i), result.Transfer.TransferID)
+	}
+}
+
+func TestGetTransfers(t *testing.T) {
+	var calls atomic.Int32
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		require.Equal(t, "account-id", r.URL.Query().Get("accountID"))
+
+		id := strings.TrimPrefix(r.URL.Path, "/transfers/")
+		if strings.HasPrefix(id, "missing") {
+			WriteJson(w, http.StatusNotFound, `{"error": "transfer not found"}`)
+			return
+		}
+		WriteJson(w, http.StatusOK, fmt.Sprintf(`{"transferID": %q, "status": "completed"}`, id))
+	})
+
+	ids := []string{"transfer-1", "missing-1", "transfer-2", "missing-2", "transfer-3", "transfer-1"}
+	transfers, err := mc.GetTransfers(BgCtx(), "account-id", ids)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "transfer missing-1:")
+	require.Contains(t, err.Error(), "transfer missing-2:")
+
+	var callErr moov.HttpCallError
+	require.ErrorAs(t, err, &callErr)
+	require.Equal(t, http.StatusNotFound, callErr.StatusCode())
+
+	require.Len(t, transfers, 3)
+	for _, id := range []string{"transfer-1", "transfer-2", "transfer-3"} {
+		require.Equal(t, id, transfers[id].TransferID)
+	}
+
+	// the repeated ID is only retrieved once
+	require.Equal(t, int32(5), calls.Load())
+}
+
+func TestGetTransfers_ContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(BgCtx())
+	defer cancel()
+
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		cancel()
+		WriteJson(w, http.StatusOK, `{"transferID": "transfer-id"}`)
+	})
+
+	ids := []string{}
+	for i := 0; i < 20; i++ {
+		ids = append(ids, fmt.Sprintf("transfer-%d", i))
+	}
+
+	transfers, err := mc.GetTransfers(ctx, "", ids)
+	require.ErrorIs(t, err, context.Canceled)
+	require.Empty(t, transfers)
+}
+
+func TestTransfer_IdempotencyKey(t *testing.T) {
+	keys := []string{}
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("X-Idempotency-Key"))
+		WriteJson(w, http.StatusOK, `{"transferID": "ec7e1848-dc80-4ab0-8827-dd7fc0737b43"}`)
+	})
+
+	transfer := newCreateTransfer("USD", 1204)
+	transferID := "ec7e1848-dc80-4ab0-8827-dd7fc0737b43"
+
+	_, _, err := mc.CreateTransfer(BgCtx(), transfer, true, moov.IdempotencyKey("create-key"))
+	require.NoError(t, err)
+
+	_, err = mc.RefundTransfer(BgCtx(), transferID, false, 100, moov.IdempotencyKey("refund-key"))
+	require.NoError(t, err)
+
+	_, err = mc.ReverseTransfer(BgCtx(), transferID, 100, moov.IdempotencyKey("reverse-key"))
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"create-key", "refund-key", "reverse-key"}, keys)
+
+	// a key is generated for every request when one isn't given
+	_, _, err = mc.CreateTransfer(BgCtx(), transfer, true)
+	require.NoError(t, err)
+	_, _, err = mc.CreateTransfer(BgCtx(), transfer, true)
+	require.NoError(t, err)
+
+	require.Len(t, keys, 5)
+	require.NotEmpty(t, keys[3])
+	require.NotEqual(t, keys[3], keys[4])
+}
+
+func TestCreateTransfer_IdempotencyConflict(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "create-key", r.Header.Get("X-Idempotency-Key"))
+		WriteJson(w, http.StatusConflict, `{"transferID": "ec7e1848-dc80-4ab0-8827-dd7fc0737b43", "status": "pending", "amount": {"currency": "USD", "value": 1204}}`)
+	})
+
+	completed, started, err := mc.CreateTransfer(BgCtx(), newCreateTransfer("USD", 1204), true, moov.IdempotencyKey("create-key"))
+	require.ErrorIs(t, err, moov.ErrIdempotencyConflict)
+	require.ErrorIs(t, err, moov.ErrXIdempotencyKey)
+	require.Nil(t, started)
+	require.NotNil(t, completed)
+	require.Equal(t, "ec7e1848-dc80-4ab0-8827-dd7fc0737b43", completed.TransferID)
+	require.Equal(t, 1204, completed.Amount.Value)
+
+	// without a transfer in the body there's nothing to recover
+	mc = NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		WriteJson(w, http.StatusConflict, `{"error": "duplicate idempotency key"}`)
+	})
+
+	completed, _, err = mc.CreateTransfer(BgCtx(), newCreateTransfer("USD", 1204), true)
+	require.ErrorIs(t, err, moov.ErrXIdempotencyKey)
+	require.NotErrorIs(t, err, moov.ErrIdempotencyConflict)
+	require.Nil(t, completed)
+}
+
+func TestTransferValidationError(t *testing.T) {
+	body := `{
+		"error": "the request could not be processed",
+		"amount": "must be no greater than the transfer amount",
+		"source": {"paymentMethodID": "must be a valid UUID"}
+	}`
+
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		WriteJson(w, http.StatusBadRequest, body)
+	})
+
+	assertValidationErr := func(t *testing.T, err error) {
+		var verr *moov.TransferValidationError
+		require.ErrorAs(t, err, &verr)
+
+		require.Equal(t, "the request could not be processed", verr.Message)
+		require.Equal(t, map[string]string{
+			"amount":                 "must be no greater than the transfer amount",
+			"source.paymentMethodID": "must be a valid UUID",
+		}, verr.Fields)
+		require.Contains(t, verr.Error(), "amount: must be no greater than the transfer amount")
+	}
+
+	t.Run("refund", func(t *testing.T) {
+		_, err := mc.RefundTransfer(BgCtx(), "ec7e1848-dc80-4ab0-8827-dd7fc0737b43", false, 5000)
+		assertValidationErr(t, err)
+	})
+
+	t.Run("reverse", func(t *testing.T) {
+		_, err := mc.ReverseTransfer(BgCtx(), "ec7e1848-dc80-4ab0-8827-dd7fc0737b43", 5000)
+		assertValidationErr(t, err)
+	})
+}
+
+func TestListAccountTransfers(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/transfers", r.URL.Path)
+
+		query := r.URL.Query()
+		require.Equal(t, []string{"account-id"}, query["accountIDs"])
+		require.Equal(t, "completed", query.Get("status"))
+		require.Equal(t, "10", query.Get("count"))
+		require.Equal(t, 1, strings.Count(r.URL.RawQuery, "account-id"))
+
+		WriteJson(w, http.StatusOK, `[{"transferID": "ec7e1848-dc80-4ab0-8827-dd7fc0737b43"}]`)
+	})
+
+	transfers, err := mc.ListAccountTransfers(BgCtx(), "account-id", moov.SearchQueryPayload{
+		AccountIDs: []string{"other-id"},
+		Status:     "completed",
+		Count:      10,
+	})
+	require.NoError(t, err)
+	require.Len(t, transfers, 1)
+}
+
+func TestListTransfers_QueryString(t *testing.T) {
+	start := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+
+	cases := []struct {
+		name     string
+		payload  moov.SearchQueryPayload
+		expected string
+	}{
+		{
+			name:     "empty",
+			payload:  moov.SearchQueryPayload{},
+			expected: "",
+		},
+		{
+			name: "all fields",
+			payload: moov.SearchQueryPayload{
+				AccountIDs:    []string{"a1", "a2"},
+				Status:        "completed",
+				StartDateTime: start,
+				EndDateTime:   end,
+				GroupID:       "group",
+				Count:         50,
+				Skip:          100,
+				Refunded:      true,
+				Disputed:      true,
+			},
+			expected: "accountIDs=a1%2Ca2&count=50&disputed=true&endDateTime=2024-01-03T03%3A04%3A05Z&groupID=group&refunded=true&skip=100&startDateTime=2024-01-02T03%3A04%3A05Z&status=completed",
+		},
+		{
+			name: "ordered",
+			payload: moov.SearchQueryPayload{
+				Count:          10,
+				OrderBy:        moov.TRANSFER_ORDER_BY_CREATED_ON,
+				OrderDirection: moov.ORDER_DESC,
+			},
+			expected: "count=10&orderBy=createdOn&orderDirection=desc",
+		},
+		{
+			name: "payment method types",
+			payload: moov.SearchQueryPayload{
+				SourcePaymentMethodType:      moov.PAYMENT_METHOD_TYPE_CARD_PAYMENT,
+				DestinationPaymentMethodType: moov.PAYMENT_METHOD_TYPE_MOOV_WALLET,
+			},
+			expected: "destinationPaymentMethodType=moov-wallet&sourcePaymentMethodType=card-payment",
+		},
+		{
+			name: "amount range",
+			payload: moov.SearchQueryPayload{
+				MinAmount: 1000,
+				MaxAmount: 5000,
+				Currency:  "usd",
+			},
+			expected: "currency=USD&maxAmount=5000&minAmount=1000",
+		},
+		{
+			name:     "min amount only",
+			payload:  moov.SearchQueryPayload{MinAmount: 1000},
+			expected: "minAmount=1000",
+		},
+		{
+			name: "source payment method type only",
+			payload: moov.SearchQueryPayload{
+				SourcePaymentMethodType: moov.PAYMENT_METHOD_TYPE_RTP_CREDIT,
+			},
+			expected: "sourcePaymentMethodType=rtp-credit",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, http.MethodGet, r.Method)
+				require.Equal(t, "/transfers", r.URL.Path)
+				require.Equal(t, tc.expected, r.URL.RawQuery)
+
+				WriteJson(w, http.StatusOK, `[{"transferID": "ec7e1848-dc80-4ab0-8827-dd7fc0737b43"}]`)
+			})
+
+			transfers, err := mc.ListTransfers(BgCtx(), tc.payload)
+			require.NoError(t, err)
+			require.Len(t, transfers, 1)
+		})
+	}
+}
+
+func TestListTransfers_InvalidOrderBy(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("invalid ordering should not be sent")
+	})
+
+	_, err := mc.ListTransfers(BgCtx(), moov.SearchQueryPayload{OrderBy: "description"})
+	require.ErrorIs(t, err, moov.ErrInvalidOrderBy)
+
+	_, err = mc.ListTransfers(BgCtx(), moov.SearchQueryPayload{OrderBy: moov.TRANSFER_ORDER_BY_AMOUNT, OrderDirection: "up"})
+	require.ErrorIs(t, err, moov.ErrInvalidOrderBy)
+
+	_, err = mc.ListTransfers(BgCtx(), moov.SearchQueryPayload{OrderDirection: moov.ORDER_ASC})
+	require.ErrorIs(t, err, moov.ErrInvalidOrderBy)
+}
+
+func TestListTransfers_InvalidAmountRange(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("invalid amount range should not be sent")
+	})
+
+	_, err := mc.ListTransfers(BgCtx(), moov.SearchQueryPayload{MinAmount: 5000, MaxAmount: 1000})
+	require.ErrorIs(t, err, moov.ErrInvalidAmountRange)
+
+	_, err = mc.ListTransfers(BgCtx(), moov.SearchQueryPayload{MinAmount: -1})
+	require.ErrorIs(t, err, moov.ErrInvalidAmountRange)
+
+	_, err = mc.ListTransfers(BgCtx(), moov.SearchQueryPayload{Currency: "dollars"})
+	require.ErrorIs(t, err, moov.ErrInvalidCurrency)
+}
+
+func TestListTransfers_InvalidPaymentMethodType(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("invalid payment method type should not be sent")
+	})
+
+	_, err := mc.ListTransfers(BgCtx(), moov.SearchQueryPayload{SourcePaymentMethodType: "card"})
+	require.ErrorIs(t, err, moov.ErrInvalidPaymentMethodType)
+
+	_, err = mc.ListTransfers(BgCtx(), moov.SearchQueryPayload{DestinationPaymentMethodType: "wallet"})
+	require.ErrorIs(t, err, moov.ErrInvalidPaymentMethodType)
+}
+
+func TestCancelTransfer(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+
+		switch r.URL.Path {
+		case "/accounts/account-id/transfers/queued-id/cancellations":
+			WriteJson(w, http.StatusOK, `{"cancellationID": "cancellation-id", "status": "completed", "createdOn": "2019-08-24T14:15:22Z"}`)
+		case "/accounts/account-id/transfers/settled-id/cancellations":
+			WriteJson(w, http.StatusConflict, `{"error": "transfer can no longer be canceled"}`)
+		default:
+			WriteJson(w, http.StatusNotFound, `{}`)
+		}
+	})
+
+	canceled, err := mc.CancelTransfer(BgCtx(), "account-id", "queued-id")
+	require.NoError(t, err)
+	require.Equal(t, "cancellation-id", canceled.Cancellation.CancellationID)
+	require.Equal(t, "completed", canceled.Cancellation.Status)
+	require.Empty(t, canceled.Refund)
+
+	_, err = mc.CancelTransfer(BgCtx(), "account-id", "settled-id")
+	require.ErrorIs(t, err, moov.ErrNotCancelable)
+}
+
+func TestReverseTransfer_CancelOnly(t *testing.T) {
+	cases := []struct {
+		name       string
+		cancelable bool
+	}{
+		{name: "cancelable", cancelable: true},
+		{name: "settled", cancelable: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+				// a cancel-only reversal never goes through the reversals endpoint, which would refund
+				require.Equal(t, http.MethodPost, r.Method)
+				require.Equal(t, "/accounts/account-id/transfers/transfer-id/cancellations", r.URL.Path)
+
+				if !tc.cancelable {
+					WriteJson(w, http.StatusConflict, `{"error": "transfer can no longer be canceled"}`)
+					return
+				}
+				WriteJson(w, http.StatusOK, `{"cancellationID": "cancellation-id", "status": "completed", "createdOn": "2019-08-24T14:15:22Z"}`)
+			}, moov.WithTransferAccountContext("account-id"))
+
+			result, err := mc.ReverseTransferWithOptions(BgCtx(), "transfer-id", 0, moov.ReverseOptions{RefundIfSettled: false})
+			if !tc.cancelable {
+				require.ErrorIs(t, err, moov.ErrAlreadySettled)
+				require.ErrorIs(t, err, moov.ErrNotCancelable)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, "completed", result.Cancellation.Status)
+			require.Empty(t, result.Refund.RefundID)
+		})
+	}
+
+	t.Run("no account", func(t *testing.T) {
+		mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+			require.Fail(t, "unexpected request")
+		})
+
+		_, err := mc.ReverseTransferWithOptions(BgCtx(), "transfer-id", 0, moov.ReverseOptions{RefundIfSettled: false})
+		require.ErrorIs(t, err, moov.ErrCancelAccountNotSet)
+	})
+}
+
 type TransferTestSuite struct {
 	suite.Suite
 	// values for testing will be set in init()
@@ -277,7 +1480,7 @@ func (s *TransferTestSuite) SetupSuite() {
 	s.paymentMethodSource = respPaymentMethods[0]
 
 	// get payment method of wallet
-	respWallets, err := mc.ListWallets(s.accountID)
+	respWallets, err := mc.ListWallets(context.Background(), s.accountID)
 	s.NoError(err)
 
 	respPaymentMethods1, err := mc.ListPaymentMethods(context.Background(), s.accountID, moov.WithPaymentMethodSourceID(respWallets[0].WalletID))
@@ -287,7 +1490,7 @@ func (s *TransferTestSuite) SetupSuite() {
 
 	//	get sample transfer
 	payload := moov.SearchQueryPayload{}
-	respTransfers, err := mc.ListTransfers(payload)
+	respTransfers, err := mc.ListTransfers(BgCtx(), payload)
 	s.NoError(err)
 	s.Require().NotEmpty(respTransfers)
 
@@ -359,7 +1562,7 @@ func (s *TransferTestSuite) TestListTransfers() {
 	mc := NewTestClient(s.T())
 
 	payload := moov.SearchQueryPayload{}
-	transfers, err := mc.ListTransfers(payload)
+	transfers, err := mc.ListTransfers(BgCtx(), payload)
 	s.NoError(err)
 
 	s.NotEmpty(transfers)
@@ -373,7 +1576,7 @@ func (s *TransferTestSuite) TestGetTransfer() {
 		transferID = s.transfer.TransferID
 	}
 
-	transfer, err := mc.GetTransfer(transferID, "")
+	transfer, err := mc.GetTransfer(BgCtx(), transferID, "")
 	s.NoError(err)
 
 	s.Equal(transferID, transfer.TransferID)
@@ -390,7 +1593,7 @@ func (s *TransferTestSuite) TestUpdateTransferMetaData() {
 		transferID = s.transfer.TransferID
 	}
 
-	transfer, err := mc.UpdateTransferMetaData(transferID, "", metadata)
+	transfer, err := mc.UpdateTransferMetaData(BgCtx(), transferID, "", metadata)
 	s.NoError(err, "Error updating transfer metadata")
 
 	s.Equal(transfer.Metadata, metadata)
@@ -414,7 +1617,7 @@ func (s *TransferTestSuite) TestTransferOptions() {
 		},
 	}
 
-	options, err := mc.TransferOptions(payload)
+	options, err := mc.TransferOptions(BgCtx(), payload)
 	s.NoError(err)
 
 	// @todo check if dest or origin are not empty?
@@ -430,7 +1633,7 @@ func (s *TransferTestSuite) TestRefundTransfer() {
 		transferID = s.transfer.TransferID
 	}
 
-	refund, err := mc.RefundTransfer(transferID, true, 1000)
+	refund, err := mc.RefundTransfer(BgCtx(), transferID, true, 1000)
 	s.NoError(err)
 
 	s.NotEmpty(refund.RefundID)
@@ -444,7 +1647,7 @@ func (s *TransferTestSuite) TestListRefunds() {
 		transferID = s.transfer.TransferID
 	}
 
-	refunds, err := mc.ListRefunds(transferID)
+	refunds, err := mc.ListRefunds(BgCtx(), transferID, "")
 	s.NoError(err)
 
 	fmt.Println(len(refunds))
@@ -460,7 +1663,7 @@ func (s *TransferTestSuite) TestGetRefund() {
 	}
 
 	refundID := "8b491eb3-a262-4eba-a0ca-35983bef3262"
-	refund, err := mc.GetRefund(transferID, refundID)
+	refund, err := mc.GetRefund(BgCtx(), transferID, refundID, "")
 	s.NoError(err)
 
 	s.Equal(refundID, refund.RefundID)
@@ -474,8 +1677,406 @@ func (s *TransferTestSuite) TestReverseTransfer() {
 		transferID = s.transfer.TransferID
 	}
 
-	reverse, err := mc.ReverseTransfer(transferID, 50)
+	reverse, err := mc.ReverseTransfer(BgCtx(), transferID, 50)
 	s.NoError(err)
 
 	s.NotEmpty(reverse.Refund.RefundID)
 }
+
+func TestSynchronousTransfer_MarshalOmitsUnsetTimes(t *testing.T) {
+	initiated := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	transfer := moov.SynchronousTransfer{TransferID: "transfer-id"}
+	transfer.Source.AchDetails.StatusUpdates.Initiated = initiated
+
+	data, err := json.Marshal(transfer)
+	require.NoError(t, err)
+	require.NotContains(t, string(data), "0001-01-01")
+	require.NotContains(t, string(data), "createdOn")
+	require.NotContains(t, string(data), "completedOn")
+	require.Contains(t, string(data), `"statusUpdates":{"initiated":"2024-01-02T03:04:05Z"}`)
+
+	var decoded moov.SynchronousTransfer
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.Equal(t, initiated, decoded.Source.AchDetails.StatusUpdates.Initiated)
+	require.True(t, decoded.CreatedOn.IsZero())
+
+	data, err = json.Marshal(moov.Dispute{DisputeID: "dispute-id"})
+	require.NoError(t, err)
+	require.NotContains(t, string(data), "respondBy")
+	require.NotContains(t, string(data), "0001-01-01")
+}
+
+func TestCreateTransfer_GroupID(t *testing.T) {
+	groupID := "7b4a1b4c-9c1f-4a52-8f7b-0f4b6c0a1d2e"
+	var created []string
+
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			var body map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			require.Equal(t, groupID, body["groupID"])
+
+			transferID := fmt.Sprintf("transfer-%d", len(created)+1)
+			created = append(created, transferID)
+			WriteJson(w, http.StatusOK, fmt.Sprintf(`{"transferID":%q,"groupID":%q}`, transferID, body["groupID"]))
+		case http.MethodGet:
+			require.Equal(t, groupID, r.URL.Query().Get("groupID"))
+			WriteJson(w, http.StatusOK, fmt.Sprintf(`[{"transferID":%q,"groupID":%q},{"transferID":%q,"groupID":%q}]`,
+				created[0], groupID, created[1], groupID))
+		}
+	})
+
+	for i := 0; i < 2; i++ {
+		create := newCreateTransfer("USD", 100)
+		create.GroupID = groupID
+		transfer, _, err := mc.CreateTransfer(BgCtx(), create, true)
+		require.NoError(t, err)
+		require.Equal(t, groupID, transfer.GroupID)
+	}
+
+	transfers, err := mc.ListTransfers(BgCtx(), moov.SearchQueryPayload{GroupID: groupID})
+	require.NoError(t, err)
+	require.Len(t, transfers, 2)
+	for i, transfer := range transfers {
+		require.Equal(t, created[i], transfer.TransferID)
+		require.Equal(t, groupID, transfer.GroupID)
+	}
+}
+
+func TestTransferOptions(t *testing.T) {
+	cases := []struct {
+		name     string
+		payload  moov.TransferOptionsPayload
+		expected string
+	}{
+		{
+			name: "by account",
+			payload: moov.TransferOptionsPayload{
+				Source:      moov.TransferOptionsSourcePayload{AccountID: "source-account-id"},
+				Destination: moov.TransferOptionsDestinationPayload{AccountID: "destination-account-id"},
+				Amount:      moov.Amount{Currency: "USD", Value: 100},
+			},
+			expected: `{"source":{"accountID":"source-account-id"},"destination":{"accountID":"destination-account-id"},"amount":{"currency":"USD","value":100}}`,
+		},
+		{
+			name: "by payment method",
+			payload: moov.TransferOptionsPayload{
+				Source:      moov.TransferOptionsSourcePayload{PaymentMethodID: "source-payment-method-id"},
+				Destination: moov.TransferOptionsDestinationPayload{PaymentMethodID: "destination-payment-method-id"},
+				Amount:      moov.Amount{Currency: "USD", Value: 100},
+			},
+			expected: `{"source":{"paymentMethodID":"source-payment-method-id"},"destination":{"paymentMethodID":"destination-payment-method-id"},"amount":{"currency":"USD","value":100}}`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, http.MethodPost, r.Method)
+				require.Equal(t, "/transfer-options", r.URL.Path)
+
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				require.JSONEq(t, tc.expected, string(body))
+
+				WriteJson(w, http.StatusOK, `{
+					"sourceOptions": [
+						{"paymentMethodID": "wallet-pm", "paymentMethodType": "moov-wallet", "wallet": {"walletID": "wallet-id", "availableBalance": {"currency": "USD", "value": 500}}},
+						{"paymentMethodID": "bank-pm", "paymentMethodType": "ach-debit-fund", "bankAccount": {"bankAccountID": "bank-account-id", "lastFourAccountNumber": "6789"}}
+					],
+					"destinationOptions": [
+						{"paymentMethodID": "dest-wallet-pm", "paymentMethodType": "moov-wallet", "wallet": {"walletID": "dest-wallet-id"}}
+					]
+				}`)
+			})
+
+			options, err := mc.TransferOptions(BgCtx(), tc.payload)
+			require.NoError(t, err)
+
+			require.Len(t, options.SourceOptions, 2)
+			require.Equal(t, "wallet-id", options.SourceOptions[0].Wallet.WalletID)
+			require.Equal(t, 500, options.SourceOptions[0].Wallet.AvailableBalance.Value)
+			require.Equal(t, moov.PAYMENT_METHOD_TYPE_ACH_DEBIT_FUND, options.SourceOptions[1].PaymentMethodType)
+			require.Equal(t, "bank-account-id", options.SourceOptions[1].BankAccount.BankAccountID)
+			require.Equal(t, "6789", options.SourceOptions[1].BankAccount.LastFourAccountNumber)
+
+			require.Len(t, options.DestinationOptions, 1)
+			require.Equal(t, "dest-wallet-id", options.DestinationOptions[0].Wallet.WalletID)
+		})
+	}
+}
+
+func TestTransferOptions_MissingParty(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("incomplete transfer options should not be sent")
+	})
+
+	_, err := mc.TransferOptions(BgCtx(), moov.TransferOptionsPayload{
+		Destination: moov.TransferOptionsDestinationPayload{AccountID: "destination-account-id"},
+	})
+	require.ErrorIs(t, err, moov.ErrTransferOptionsParty)
+
+	_, err = mc.TransferOptions(BgCtx(), moov.TransferOptionsPayload{
+		Source: moov.TransferOptionsSourcePayload{PaymentMethodID: "source-payment-method-id"},
+	})
+	require.ErrorIs(t, err, moov.ErrTransferOptionsParty)
+}
+
+func TestFacilitatorFeeFromMarkup(t *testing.T) {
+	// 2.9% of $12.34
+	fee := moov.FacilitatorFeeFromMarkup(moov.Amount{Currency: "USD", Value: 1234}, 2.9)
+	require.Equal(t, moov.FacilitatorFee{Markup: 36, MarkupDecimal: "35.786"}, fee)
+
+	fee = moov.FacilitatorFeeFromMarkup(moov.Amount{Currency: "USD", Value: 10000}, 2.9)
+	require.Equal(t, moov.FacilitatorFee{Markup: 290, MarkupDecimal: "290"}, fee)
+}
+
+func TestFacilitatorFeeFromDecimal(t *testing.T) {
+	// 2.9% + 30¢ of $12.34 is 35.786¢ + 30¢
+	fee, err := moov.FacilitatorFeeFromDecimal("65.786", "")
+	require.NoError(t, err)
+	require.Equal(t, moov.FacilitatorFee{Total: 66, TotalDecimal: "65.786"}, fee)
+
+	fee, err = moov.FacilitatorFeeFromDecimal("", "30.25")
+	require.NoError(t, err)
+	require.Equal(t, moov.FacilitatorFee{Markup: 30, MarkupDecimal: "30.25"}, fee)
+
+	fee, err = moov.FacilitatorFeeFromDecimal("0.123456789", "")
+	require.NoError(t, err)
+	require.Equal(t, moov.FacilitatorFee{Total: 0, TotalDecimal: "0.123456789"}, fee)
+
+	_, err = moov.FacilitatorFeeFromDecimal("65.786", "30")
+	require.ErrorIs(t, err, moov.ErrInvalidFacilitatorFee)
+
+	_, err = moov.FacilitatorFeeFromDecimal("2.9%", "")
+	require.ErrorIs(t, err, moov.ErrInvalidFacilitatorFee)
+	require.ErrorIs(t, err, moov.ErrInvalidDecimalAmount)
+
+	_, err = moov.FacilitatorFeeFromDecimal("-1", "")
+	require.ErrorIs(t, err, moov.ErrInvalidFacilitatorFee)
+}
+
+func TestMoovFeeBreakdown(t *testing.T) {
+	transfer := moov.SynchronousTransfer{
+		MoovFee:        26,
+		MoovFeeDecimal: "25.63",
+		MoovFeeDetails: moov.MoovFeeDetails{
+			CardScheme:     "0.13",
+			Interchange:    "15.5",
+			MoovProcessing: "10",
+		},
+	}
+
+	breakdown, err := transfer.MoovFeeBreakdown()
+	require.NoError(t, err)
+	require.Equal(t, "0.13", breakdown.CardScheme.String())
+	require.Equal(t, "15.5", breakdown.Interchange.String())
+	require.Equal(t, 10, breakdown.MoovProcessing.Cents())
+	require.Equal(t, "25.63", breakdown.Total.String())
+	require.Equal(t, 26, breakdown.Total.Cents())
+
+	// without the decimal the whole cent fee is checked
+	transfer.MoovFeeDecimal = ""
+	_, err = transfer.MoovFeeBreakdown()
+	require.NoError(t, err)
+
+	// ACH transfers have no card fees
+	breakdown, err = moov.MoovFeeDetails{MoovProcessing: "0.5"}.Breakdown()
+	require.NoError(t, err)
+	require.Equal(t, moov.FeeAmount(0), breakdown.CardScheme)
+	require.Equal(t, "0.5", breakdown.Total.String())
+}
+
+func TestMoovFeeBreakdown_Mismatch(t *testing.T) {
+	transfer := moov.SynchronousTransfer{
+		MoovFee:        26,
+		MoovFeeDecimal: "25.64",
+		MoovFeeDetails: moov.MoovFeeDetails{
+			CardScheme:     "0.13",
+			Interchange:    "15.5",
+			MoovProcessing: "10",
+		},
+	}
+
+	_, err := transfer.MoovFeeBreakdown()
+	require.ErrorIs(t, err, moov.ErrFeeMismatch)
+
+	transfer.MoovFeeDecimal = ""
+	transfer.MoovFee = 25
+	_, err = transfer.MoovFeeBreakdown()
+	require.ErrorIs(t, err, moov.ErrFeeMismatch)
+
+	transfer.MoovFeeDetails.Interchange = "15,5"
+	_, err = transfer.MoovFeeBreakdown()
+	require.ErrorIs(t, err, moov.ErrInvalidDecimalAmount)
+}
+
+func TestWaitForTransfer(t *testing.T) {
+	statuses := []string{"pending", "pending", "completed"}
+	polls := 0
+
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/transfers/transfer-id", r.URL.Path)
+		require.Equal(t, "account-id", r.URL.Query().Get("accountID"))
+
+		status := statuses[min(polls, len(statuses)-1)]
+		polls++
+		WriteJson(w, http.StatusOK, fmt.Sprintf(`{"transferID":"transfer-id","status":%q}`, status))
+	})
+
+	transfer, err := mc.WaitForTransfer(BgCtx(), "account-id", "transfer-id", moov.WaitOptions{
+		Interval:    time.Millisecond,
+		MaxInterval: 2 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	require.Equal(t, moov.TransferStatusCompleted, transfer.TypedStatus())
+	require.Equal(t, 3, polls)
+}
+
+func TestWaitForTransfer_Failed(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		WriteJson(w, http.StatusOK, `{"transferID":"transfer-id","status":"failed","failureReason":"insufficient-funds"}`)
+	})
+
+	transfer, err := mc.WaitForTransfer(BgCtx(), "account-id", "transfer-id", moov.WaitOptions{Interval: time.Millisecond})
+	require.ErrorIs(t, err, moov.ErrTransferFailed)
+	require.Equal(t, moov.TransferStatusFailed, transfer.TypedStatus())
+
+	var failedErr *moov.TransferFailedError
+	require.ErrorAs(t, err, &failedErr)
+	require.Equal(t, "insufficient-funds", failedErr.FailureReason)
+}
+
+func TestWaitForTransfer_ContextCanceled(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		WriteJson(w, http.StatusOK, `{"transferID":"transfer-id","status":"pending"}`)
+	})
+
+	ctx, cancel := context.WithTimeout(BgCtx(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := mc.WaitForTransfer(ctx, "account-id", "transfer-id", moov.WaitOptions{Interval: time.Millisecond, MaxInterval: time.Hour})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestListTransfersWithCount(t *testing.T) {
+	total := "542"
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/transfers", r.URL.Path)
+		if total != "" {
+			w.Header().Set("X-Total-Count", total)
+		}
+		WriteJson(w, http.StatusOK, `[{"transferID":"transfer-1"},{"transferID":"transfer-2"}]`)
+	})
+
+	transfers, count, err := mc.ListTransfersWithCount(BgCtx(), moov.SearchQueryPayload{Count: 2})
+	require.NoError(t, err)
+	require.Len(t, transfers, 2)
+	require.Equal(t, 542, count)
+
+	total = ""
+	transfers, count, err = mc.ListTransfersWithCount(BgCtx(), moov.SearchQueryPayload{Count: 2})
+	require.NoError(t, err)
+	require.Len(t, transfers, 2)
+	require.Equal(t, -1, count)
+}
+
+func TestCreateTransfer_Validate(t *testing.T) {
+	cases := []struct {
+		name    string
+		modify  func(transfer *moov.CreateTransfer)
+		problem string
+	}{
+		{"zero amount", func(tr *moov.CreateTransfer) { tr.Amount.Value = 0 }, "amount.value must be positive"},
+		{"negative amount", func(tr *moov.CreateTransfer) { tr.Amount.Value = -100 }, "amount.value must be positive"},
+		{"no currency", func(tr *moov.CreateTransfer) { tr.Amount.Currency = "" }, "amount.currency is required"},
+		{"no source", func(tr *moov.CreateTransfer) { tr.Source = moov.Source{} }, "source needs a paymentMethodID or transferID"},
+		{"no destination", func(tr *moov.CreateTransfer) { tr.Destination = moov.Destination{} }, "destination needs a paymentMethodID"},
+	}
+
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("invalid transfer should not be sent")
+	})
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			transfer := newCreateTransfer("USD", 1204)
+			tc.modify(&transfer)
+
+			_, _, err := mc.CreateTransfer(BgCtx(), transfer, true)
+			require.ErrorIs(t, err, moov.ErrInvalidTransfer)
+			require.Contains(t, err.Error(), tc.problem)
+		})
+	}
+
+	// a transfer can be sourced from another transfer's funds
+	transfer := newCreateTransfer("USD", 1204)
+	transfer.Source = moov.Source{TransferID: "transfer-id"}
+	require.NoError(t, transfer.Validate())
+
+	require.NoError(t, newCreateTransfer("USD", 1204).Validate())
+}
+
+func TestCreateTransfer_Currency(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		transfer := moov.CreateTransfer{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&transfer))
+		require.Equal(t, "USD", transfer.Amount.Currency)
+
+		WriteJson(w, http.StatusOK, `{"transferID": "transfer-id"}`)
+	})
+
+	_, _, err := mc.CreateTransfer(BgCtx(), newCreateTransfer("usd", 1204), true)
+	require.NoError(t, err)
+
+	_, _, err = mc.CreateTransfer(BgCtx(), newCreateTransfer("US", 1204), true)
+	require.ErrorIs(t, err, moov.ErrInvalidTransfer)
+	require.Contains(t, err.Error(), `amount.currency "US" is not an ISO 4217 code`)
+}
+
+func TestTransferOptions_Rail(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.NotContains(t, string(body), "rail", "the rail is filtered locally")
+
+		WriteJson(w, http.StatusOK, `{
+			"sourceOptions": [
+				{"paymentMethodID": "wallet-pm", "paymentMethodType": "moov-wallet"},
+				{"paymentMethodID": "ach-pm", "paymentMethodType": "ach-debit-fund"}
+			],
+			"destinationOptions": [
+				{"paymentMethodID": "ach-standard-pm", "paymentMethodType": "ach-credit-standard"},
+				{"paymentMethodID": "ach-same-day-pm", "paymentMethodType": "ach-credit-same-day"},
+				{"paymentMethodID": "rtp-pm", "paymentMethodType": "rtp-credit"},
+				{"paymentMethodID": "card-pm", "paymentMethodType": "push-to-card"}
+			]
+		}`)
+	})
+
+	payload := moov.TransferOptionsPayload{
+		Source:      moov.TransferOptionsSourcePayload{AccountID: "source-account-id"},
+		Destination: moov.TransferOptionsDestinationPayload{AccountID: "destination-account-id"},
+		Amount:      moov.Amount{Currency: "USD", Value: 100},
+	}
+
+	options, err := mc.TransferOptions(BgCtx(), payload)
+	require.NoError(t, err)
+	require.Len(t, options.SourceOptions, 2)
+	require.Len(t, options.DestinationOptions, 4)
+	require.Equal(t, moov.RAIL_ACH, options.DestinationOptions[1].Rail())
+	require.Equal(t, moov.RAIL_CARD, options.DestinationOptions[3].Rail())
+
+	payload.Rail = moov.RAIL_RTP
+	options, err = mc.TransferOptions(BgCtx(), payload)
+	require.NoError(t, err)
+
+	// the wallet can fund an RTP transfer but the ACH debit can't
+	require.Len(t, options.SourceOptions, 1)
+	require.Equal(t, "wallet-pm", options.SourceOptions[0].PaymentMethodID)
+	require.Len(t, options.DestinationOptions, 1)
+	require.Equal(t, "rtp-pm", options.DestinationOptions[0].PaymentMethodID)
+	require.Equal(t, moov.RAIL_RTP, options.DestinationOptions[0].Rail())
+}
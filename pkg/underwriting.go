@@ -0,0 +1,107 @@
+package moov
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+var (
+	ErrNoUnderwriting = errors.New("no underwriting details have been submitted for the account")
+)
+
+const (
+	UNDERWRITING_STATUS_APPROVED       = "approved"
+	UNDERWRITING_STATUS_REJECTED       = "rejected"
+	UNDERWRITING_STATUS_PENDING_REVIEW = "pendingReview"
+	UNDERWRITING_STATUS_PENDING        = "pending"
+	UNDERWRITING_STATUS_NOT_REQUESTED  = "notRequested"
+)
+
+// UnderwritingRequirement is an estimate Moov needs before it can underwrite a business account
+type UnderwritingRequirement string
+
+const (
+	UnderwritingAverageTransactionSize          UnderwritingRequirement = "averageTransactionSize"
+	UnderwritingMaxTransactionSize              UnderwritingRequirement = "maxTransactionSize"
+	UnderwritingAverageMonthlyTransactionVolume UnderwritingRequirement = "averageMonthlyTransactionVolume"
+)
+
+// Underwriting is the review of a business account's expected card volume, all amounts are in cents
+type Underwriting struct {
+	AverageTransactionSize          int `json:"averageTransactionSize,omitempty"`
+	MaxTransactionSize              int `json:"maxTransactionSize,omitempty"`
+	AverageMonthlyTransactionVolume int `json:"averageMonthlyTransactionVolume,omitempty"`
+	// One of UNDERWRITING_STATUS_*
+	Status    string    `json:"status,omitempty"`
+	CreatedOn time.Time `json:"createdOn,omitempty"`
+	UpdatedOn time.Time `json:"updatedOn,omitempty"`
+}
+
+// Requirements returns the estimates that still have to be submitted with UpdateUnderwriting, none once the account
+// has been approved
+func (u Underwriting) Requirements() []UnderwritingRequirement {
+	requirements := []UnderwritingRequirement{}
+	if u.Status == UNDERWRITING_STATUS_APPROVED {
+		return requirements
+	}
+
+	if u.AverageTransactionSize <= 0 {
+		requirements = append(requirements, UnderwritingAverageTransactionSize)
+	}
+	if u.MaxTransactionSize <= 0 {
+		requirements = append(requirements, UnderwritingMaxTransactionSize)
+	}
+	if u.AverageMonthlyTransactionVolume <= 0 {
+		requirements = append(requirements, UnderwritingAverageMonthlyTransactionVolume)
+	}
+	return requirements
+}
+
+// UnderwritingUpdate holds the volume estimates submitted for underwriting, in cents
+type UnderwritingUpdate struct {
+	AverageTransactionSize          int `json:"averageTransactionSize"`
+	MaxTransactionSize              int `json:"maxTransactionSize"`
+	AverageMonthlyTransactionVolume int `json:"averageMonthlyTransactionVolume"`
+}
+
+// GetUnderwriting retrieves the underwriting status of a business account, ErrNoUnderwriting is returned before any
+// estimates have been submitted
+// https://docs.moov.io/api/moov-accounts/underwriting/get/
+func (c Client) GetUnderwriting(ctx context.Context, accountID string) (*Underwriting, error) {
+	resp, err := c.CallHttp(ctx, Endpoint(http.MethodGet, pathUnderwriting, accountID), AcceptJson())
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.Status() {
+	case StatusCompleted:
+		return UnmarshalObjectResponse[Underwriting](resp)
+	case StatusNotFound:
+		return nil, ErrNoUnderwriting
+	default:
+		return nil, resp.Error()
+	}
+}
+
+// UpdateUnderwriting submits the account's volume estimates for underwriting
+// https://docs.moov.io/api/moov-accounts/underwriting/put/
+func (c Client) UpdateUnderwriting(ctx context.Context, accountID string, u UnderwritingUpdate) (*Underwriting, error) {
+	resp, err := c.CallHttp(ctx,
+		Endpoint(http.MethodPut, pathUnderwriting, accountID),
+		AcceptJson(),
+		JsonBody(u))
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.Status() {
+	case StatusCompleted:
+		return UnmarshalObjectResponse[Underwriting](resp)
+	case StatusNotFound:
+		return nil, ErrNoAccount
+	default:
+		return nil, resp.Error()
+	}
+}
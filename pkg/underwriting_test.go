@@ -0,0 +1,71 @@
+package moov_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	moov "github.com/moovfinancial/moov-go/pkg"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetUnderwriting(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+
+		switch r.URL.Path {
+		case "/accounts/account-id/underwriting":
+			WriteJson(w, http.StatusOK, `{
+				"averageTransactionSize": 10000,
+				"status": "pending",
+				"createdOn": "2024-05-01T14:15:22Z",
+				"updatedOn": "2024-05-01T14:15:22Z"
+			}`)
+		default:
+			WriteJson(w, http.StatusNotFound, `{"error": "not found"}`)
+		}
+	})
+
+	underwriting, err := mc.GetUnderwriting(BgCtx(), "account-id")
+	require.NoError(t, err)
+	require.Equal(t, moov.UNDERWRITING_STATUS_PENDING, underwriting.Status)
+	require.Equal(t, 10000, underwriting.AverageTransactionSize)
+	require.Equal(t, []moov.UnderwritingRequirement{
+		moov.UnderwritingMaxTransactionSize,
+		moov.UnderwritingAverageMonthlyTransactionVolume,
+	}, underwriting.Requirements())
+
+	_, err = mc.GetUnderwriting(BgCtx(), "new-account-id")
+	require.ErrorIs(t, err, moov.ErrNoUnderwriting)
+}
+
+func TestUpdateUnderwriting(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPut, r.Method)
+		require.Equal(t, "/accounts/account-id/underwriting", r.URL.Path)
+
+		update := moov.UnderwritingUpdate{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&update))
+		require.Equal(t, moov.UnderwritingUpdate{
+			AverageTransactionSize:          10000,
+			MaxTransactionSize:              50000,
+			AverageMonthlyTransactionVolume: 2500000,
+		}, update)
+
+		WriteJson(w, http.StatusOK, `{
+			"averageTransactionSize": 10000,
+			"maxTransactionSize": 50000,
+			"averageMonthlyTransactionVolume": 2500000,
+			"status": "pendingReview"
+		}`)
+	})
+
+	underwriting, err := mc.UpdateUnderwriting(BgCtx(), "account-id", moov.UnderwritingUpdate{
+		AverageTransactionSize:          10000,
+		MaxTransactionSize:              50000,
+		AverageMonthlyTransactionVolume: 2500000,
+	})
+	require.NoError(t, err)
+	require.Equal(t, moov.UNDERWRITING_STATUS_PENDING_REVIEW, underwriting.Status)
+	require.Empty(t, underwriting.Requirements())
+}
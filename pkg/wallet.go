@@ -1,11 +1,9 @@
 package moov
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"io"
 	"net/http"
-	"net/url"
 	"time"
 )
 
@@ -20,7 +18,8 @@ type AvailableBalance struct {
 	ValueDecimal string `json:"valueDecimal,omitempty"`
 }
 
-type Transaction struct {
+// WalletTransaction is an entry in a wallet's ledger
+type WalletTransaction struct {
 	WalletID                string    `json:"walletID,omitempty"`
 	TransactionID           string    `json:"transactionID,omitempty"`
 	TransactionType         string    `json:"transactionType,omitempty"`
@@ -41,210 +40,255 @@ type Transaction struct {
 	AvailableBalanceDecimal string    `json:"availableBalanceDecimal,omitempty"`
 }
 
+// Transaction is the previous name of WalletTransaction
+//
+// Deprecated: use WalletTransaction
+type Transaction = WalletTransaction
+
 // ListWallets lists all wallets that are associated with a Moov account
 // https://docs.moov.io/api/index.html#tag/Wallets/operation/listWalletsForAccount
-func (c Client) ListWallets(accountID string) ([]Wallet, error) {
-	var resWallets []Wallet
-	url := fmt.Sprintf("%s/%s", baseURL, fmt.Sprintf(pathWallets, accountID))
-
-	body, statusCode, err := c.GetHTTPResponse(http.MethodGet, url, nil, nil)
+func (c Client) ListWallets(ctx context.Context, accountID string) ([]Wallet, error) {
+	resp, err := c.CallHttp(ctx, Endpoint(http.MethodGet, pathWallets, accountID), AcceptJson())
 	if err != nil {
-		return resWallets, err
+		return nil, err
 	}
 
-	switch statusCode {
-	case http.StatusOK:
-		err = json.Unmarshal(body, &resWallets)
-		if err != nil {
-			return resWallets, err
-		}
-		return resWallets, nil
-	case http.StatusTooManyRequests:
-		return resWallets, ErrRateLimit
-	}
-	return resWallets, ErrDefault(statusCode)
+	return CompletedListOrError[Wallet](resp)
 }
 
 // GetWallet retrieves a wallet for the given wallet id
 // https://docs.moov.io/api/index.html#tag/Wallets/operation/getWalletForAccount
-func (c Client) GetWallet(accountID string, walletID string) (Wallet, error) {
-	resWallet := Wallet{}
-	url := fmt.Sprintf("%s/%s/%s", baseURL, fmt.Sprintf(pathWallets, accountID), walletID)
-
-	body, statusCode, err := c.GetHTTPResponse(http.MethodGet, url, nil, nil)
+func (c Client) GetWallet(ctx context.Context, accountID string, walletID string) (*Wallet, error) {
+	resp, err := c.CallHttp(ctx, Endpoint(http.MethodGet, pathWallet, accountID, walletID), AcceptJson())
 	if err != nil {
-		return resWallet, err
+		return nil, err
 	}
 
-	switch statusCode {
-	case http.StatusOK:
-		err = json.Unmarshal(body, &resWallet)
-		if err != nil {
-			return resWallet, err
-		}
-		return resWallet, nil
-	case http.StatusUnauthorized:
-		return resWallet, ErrAuthCredentialsNotSet
-	case http.StatusNotFound:
-		return resWallet, ErrNoAccount
-	case http.StatusTooManyRequests:
-		return resWallet, ErrRateLimit
-	}
-	return resWallet, ErrDefault(statusCode)
+	return CompletedObjectOrError[Wallet](resp)
 }
 
-type transactionFilter map[string]string
+// WalletTransactionFilter narrows and pages the transactions returned by ListWalletTransactionsFiltered, zero values are
+// left out of the query.
+type WalletTransactionFilter struct {
+	TransactionType        string
+	SourceType             string
+	SourceID               string
+	Status                 string
+	CreatedStartDateTime   time.Time
+	CreatedEndDateTime     time.Time
+	CompletedStartDateTime time.Time
+	CompletedEndDateTime   time.Time
+	Count                  int
+	Skip                   int
+}
 
-type ListTransactionFilter func(c transactionFilter) error
+func (f WalletTransactionFilter) args() []callArg {
+	args := []callArg{}
 
-func applyTransactionFilters(url *url.URL, opts ...ListTransactionFilter) {
-	filters := make(transactionFilter)
-	for _, opt := range opts {
-		opt(filters)
+	if f.TransactionType != "" {
+		args = append(args, WithTransactionType(f.TransactionType))
+	}
+	if f.SourceType != "" {
+		args = append(args, WithSourceType(f.SourceType))
+	}
+	if f.SourceID != "" {
+		args = append(args, WithSourceID(f.SourceID))
+	}
+	if f.Status != "" {
+		args = append(args, WithTransactionStatus(f.Status))
+	}
+	if !f.CreatedStartDateTime.IsZero() {
+		args = append(args, WithCreatedStartDateTime(f.CreatedStartDateTime))
 	}
-	q := url.Query()
-	for k, v := range filters {
-		q.Add(k, v)
+	if !f.CreatedEndDateTime.IsZero() {
+		args = append(args, WithCreatedEndDateTime(f.CreatedEndDateTime))
 	}
-	url.RawQuery = q.Encode()
+	if !f.CompletedStartDateTime.IsZero() {
+		args = append(args, WithCompletedStartDateTime(f.CompletedStartDateTime))
+	}
+	if !f.CompletedEndDateTime.IsZero() {
+		args = append(args, WithCompletedEndDateTime(f.CompletedEndDateTime))
+	}
+	if f.Count != 0 {
+		args = append(args, WithTransactionCount(f.Count))
+	}
+	if f.Skip != 0 {
+		args = append(args, WithTransactionSkip(f.Skip))
+	}
+
+	return args
 }
 
+// ListTransactionFilter narrows down the transactions returned by ListWalletTransactions
+type ListTransactionFilter = callArg
+
 // WithTransactionType filters transactions by transaction type
-func WithTransactionType(transactionType string) ListTransactionFilter {
-	return func(c transactionFilter) error {
-		c["transactionType"] = transactionType
+func WithTransactionType(transactionType string) callArg {
+	return callBuilderFn(func(call *callBuilder) error {
+		call.params.Set("transactionType", transactionType)
 		return nil
-	}
+	})
 }
 
 // WithSourceType filters transactions by source type (transfer, dispute, issuing-transaction).
-func WithSourceType(sourceType string) ListTransactionFilter {
-	return func(c transactionFilter) error {
-		c["sourceType"] = sourceType
+func WithSourceType(sourceType string) callArg {
+	return callBuilderFn(func(call *callBuilder) error {
+		call.params.Set("sourceType", sourceType)
 		return nil
-	}
+	})
 }
 
 // WithSourceID filters transactions by source ID
-func WithSourceID(sourceID string) ListTransactionFilter {
-	return func(c transactionFilter) error {
-		c["sourceID"] = sourceID
+func WithSourceID(sourceID string) callArg {
+	return callBuilderFn(func(call *callBuilder) error {
+		call.params.Set("sourceID", sourceID)
 		return nil
-	}
+	})
 }
 
 // WithTransactionStatus filters transactions by transaction status (pending, completed, canceled, failed)
-func WithTransactionStatus(status string) ListTransactionFilter {
-	return func(c transactionFilter) error {
-		c["status"] = status
+func WithTransactionStatus(status string) callArg {
+	return callBuilderFn(func(call *callBuilder) error {
+		call.params.Set("status", status)
 		return nil
-	}
+	})
 }
 
 // WithTransactionCount filters transactions by transaction count
-func WithTransactionCount(count int) ListTransactionFilter {
-	return func(c transactionFilter) error {
-		c["count"] = fmt.Sprintf("%d", count)
+func WithTransactionCount(count int) callArg {
+	return callBuilderFn(func(call *callBuilder) error {
+		call.params.Set("count", fmt.Sprintf("%d", count))
 		return nil
-	}
+	})
 }
 
 // WithTransactionSkip filters transactions by transaction skip
-func WithTransactionSkip(skip int) ListTransactionFilter {
-	return func(c transactionFilter) error {
-		c["skip"] = fmt.Sprintf("%d", skip)
+func WithTransactionSkip(skip int) callArg {
+	return callBuilderFn(func(call *callBuilder) error {
+		call.params.Set("skip", fmt.Sprintf("%d", skip))
 		return nil
-	}
+	})
 }
 
 // WithCreatedStartDateTime filters transactions by created start date time
-func WithCreatedStartDateTime(createdStartDateTime time.Time) ListTransactionFilter {
-	return func(c transactionFilter) error {
-		c["createdStartDateTime"] = createdStartDateTime.Format(time.RFC3339)
+func WithCreatedStartDateTime(createdStartDateTime time.Time) callArg {
+	return callBuilderFn(func(call *callBuilder) error {
+		call.params.Set("createdStartDateTime", createdStartDateTime.Format(time.RFC3339))
 		return nil
-	}
+	})
 }
 
 // WithCreatedEndDateTime filters transactions by created end date time
-func WithCreatedEndDateTime(createdEndDateTime time.Time) ListTransactionFilter {
-	return func(c transactionFilter) error {
-		c["createdEndDateTime"] = createdEndDateTime.Format(time.RFC3339)
+func WithCreatedEndDateTime(createdEndDateTime time.Time) callArg {
+	return callBuilderFn(func(call *callBuilder) error {
+		call.params.Set("createdEndDateTime", createdEndDateTime.Format(time.RFC3339))
 		return nil
-	}
+	})
 }
 
 // WithCompletedStartDateTime filters transactions by completed start date time
-func WithCompletedStartDateTime(completedStartDateTime time.Time) ListTransactionFilter {
-	return func(c transactionFilter) error {
-		c["completedStartDateTime"] = completedStartDateTime.Format(time.RFC3339)
+func WithCompletedStartDateTime(completedStartDateTime time.Time) callArg {
+	return callBuilderFn(func(call *callBuilder) error {
+		call.params.Set("completedStartDateTime", completedStartDateTime.Format(time.RFC3339))
 		return nil
-	}
+	})
 }
 
 // WithCompletedEndDateTime filters transactions by completed end date time
-func WithCompletedEndDateTime(completedEndDateTime time.Time) ListTransactionFilter {
-	return func(c transactionFilter) error {
-		c["completedEndDateTime"] = completedEndDateTime.Format(time.RFC3339)
+func WithCompletedEndDateTime(completedEndDateTime time.Time) callArg {
+	return callBuilderFn(func(call *callBuilder) error {
+		call.params.Set("completedEndDateTime", completedEndDateTime.Format(time.RFC3339))
 		return nil
-	}
+	})
 }
 
-// ListWalletTransactions lists all transactions for the given wallet id
+// ListWalletTransactions lists the transactions for the given wallet id narrowed by the With* filters, ie:
+// WithTransactionStatus. Use WithTransactionCount and WithTransactionSkip to page through the wallet's ledger.
 // https://docs.moov.io/api/index.html#tag/Wallet-transactions
-func (c Client) ListWalletTransactions(accountID string, walletID string, opts ...ListTransactionFilter) ([]Transaction, error) {
-	var resTransactions []Transaction
-	url := fmt.Sprintf("%s/%s", baseURL, fmt.Sprintf(pathWalletTrans, accountID, walletID))
-	req, _ := http.NewRequest(http.MethodGet, url, nil)
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	req.SetBasicAuth(c.Credentials.PublicKey, c.Credentials.SecretKey)
-	applyTransactionFilters(req.URL, opts...)
-	resp, err := c.HttpClient.Do(req)
+func (c Client) ListWalletTransactions(ctx context.Context, accountID string, walletID string, filters ...ListTransactionFilter) ([]WalletTransaction, error) {
+	args := prependArgs(filters, AcceptJson(), paged())
+	resp, err := c.CallHttp(ctx, Endpoint(http.MethodGet, pathWalletTrans, accountID, walletID), args...)
 	if err != nil {
-		return resTransactions, err
+		return nil, err
 	}
-	defer resp.Body.Close()
-	body, _ := io.ReadAll(resp.Body)
 
-	switch resp.StatusCode {
-	case http.StatusOK:
-		err = json.Unmarshal(body, &resTransactions)
-		if err != nil {
-			return resTransactions, err
-		}
-		return resTransactions, nil
-	case http.StatusNotFound:
-		return resTransactions, ErrNoAccount
-	case http.StatusTooManyRequests:
-		return resTransactions, ErrRateLimit
-	}
-	return resTransactions, ErrDefault(resp.StatusCode)
+	return CompletedListOrError[WalletTransaction](resp)
+}
+
+// ListWalletTransactionsFiltered lists the transactions for the given wallet id matching the filter. Use the filter's
+// Count and Skip to page through the wallet's ledger.
+// https://docs.moov.io/api/index.html#tag/Wallet-transactions
+func (c Client) ListWalletTransactionsFiltered(ctx context.Context, accountID string, walletID string, filter WalletTransactionFilter) ([]WalletTransaction, error) {
+	return c.ListWalletTransactions(ctx, accountID, walletID, filter.args()...)
 }
 
 // GetWalletTransaction retrieves a transaction for the given wallet id and transaction id
 // https://docs.moov.io/api/index.html#tag/Wallet-transactions/operation/getWalletTransaction
-func (c Client) GetWalletTransaction(accountID string, walletID string, transactionID string) (Transaction, error) {
-	resTransaction := Transaction{}
-	url := fmt.Sprintf("%s/%s/%s", baseURL, fmt.Sprintf(pathWalletTrans, accountID, walletID), transactionID)
+func (c Client) GetWalletTransaction(ctx context.Context, accountID string, walletID string, transactionID string) (*WalletTransaction, error) {
+	resp, err := c.CallHttp(ctx, Endpoint(http.MethodGet, pathWalletTran, accountID, walletID, transactionID), AcceptJson())
+	if err != nil {
+		return nil, err
+	}
 
-	body, statusCode, err := c.GetHTTPResponse(http.MethodGet, url, nil, nil)
+	return CompletedObjectOrError[WalletTransaction](resp)
+}
+
+// CurrencyBalance is the money held in one currency, in minor units
+type CurrencyBalance struct {
+	// Funds that can be moved out of the wallets
+	Available int `json:"available"`
+	// Net amount of the wallet transactions that haven't completed yet
+	Pending int `json:"pending"`
+}
+
+// AccountBalance is the total balance of an account's wallets
+type AccountBalance struct {
+	// Balances summed across the wallets keyed by currency code, empty when the account has no wallets
+	Currencies map[string]CurrencyBalance `json:"currencies"`
+}
+
+// walletTransactionPageSize is the count pending transactions are paged through with
+const walletTransactionPageSize = 200
+
+// GetAccountBalance sums the available and pending balances of all of the account's wallets per currency. Moov only
+// reports the available balance of a wallet, so the pending balance is the net amount of the wallet's pending
+// transactions and takes a call per page of them.
+func (c Client) GetAccountBalance(ctx context.Context, accountID string) (*AccountBalance, error) {
+	wallets, err := c.ListWallets(ctx, accountID)
 	if err != nil {
-		return resTransaction, err
+		return nil, err
 	}
 
-	switch statusCode {
-	case http.StatusOK:
-		err = json.Unmarshal(body, &resTransaction)
-		if err != nil {
-			return resTransaction, err
+	balance := &AccountBalance{Currencies: map[string]CurrencyBalance{}}
+	for _, wallet := range wallets {
+		currency := wallet.AvailableBalance.Currency
+		total := balance.Currencies[currency]
+		total.Available += wallet.AvailableBalance.Value
+		balance.Currencies[currency] = total
+
+		filter := WalletTransactionFilter{Status: "pending", Count: walletTransactionPageSize}
+		for {
+			transactions, err := c.ListWalletTransactionsFiltered(ctx, accountID, wallet.WalletID, filter)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, transaction := range transactions {
+				pendingCurrency := transaction.Currency
+				if pendingCurrency == "" {
+					pendingCurrency = currency
+				}
+				total := balance.Currencies[pendingCurrency]
+				total.Pending += transaction.NetAmount
+				balance.Currencies[pendingCurrency] = total
+			}
+
+			if len(transactions) < filter.Count {
+				break
+			}
+			filter.Skip += len(transactions)
 		}
-		return resTransaction, nil
-	case http.StatusUnauthorized:
-		return resTransaction, ErrAuthCredentialsNotSet
-	case http.StatusNotFound:
-		return resTransaction, ErrNoAccount
-	case http.StatusTooManyRequests:
-		return resTransaction, ErrRateLimit
-	}
-	return resTransaction, ErrDefault(statusCode)
+	}
+
+	return balance, nil
 }
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"log"
+	"net/http"
 	"testing"
 
 	moov "github.com/moovfinancial/moov-go/pkg"
@@ -33,6 +34,101 @@ func TestWalletMarshal(t *testing.T) {
 	require.Equal(t, "ec7e1848-dc80-4ab0-8827-dd7fc0737b43", wallet.WalletID)
 }
 
+func TestGetWallet_Balance(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/accounts/account-id/wallets/wallet-id", r.URL.Path)
+		WriteJson(w, http.StatusOK, `{"walletID": "wallet-id", "availableBalance": {"currency": "USD", "value": 1204, "valueDecimal": "12.04"}}`)
+	})
+
+	wallet, err := mc.GetWallet(BgCtx(), "account-id", "wallet-id")
+	require.NoError(t, err)
+	require.Equal(t, moov.AvailableBalance{Currency: "USD", Value: 1204, ValueDecimal: "12.04"}, wallet.AvailableBalance)
+}
+
+func TestGetAccountBalance(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/accounts/account-id/wallets":
+			WriteJson(w, http.StatusOK, `[
+				{"walletID": "wallet-1", "availableBalance": {"currency": "USD", "value": 1204}},
+				{"walletID": "wallet-2", "availableBalance": {"currency": "USD", "value": 500}}
+			]`)
+		case "/accounts/account-id/wallets/wallet-1/transactions":
+			require.Equal(t, "pending", r.URL.Query().Get("status"))
+			WriteJson(w, http.StatusOK, `[
+				{"transactionID": "t-1", "status": "pending", "currency": "USD", "netAmount": 300},
+				{"transactionID": "t-2", "status": "pending", "currency": "USD", "netAmount": -100}
+			]`)
+		case "/accounts/account-id/wallets/wallet-2/transactions":
+			WriteJson(w, http.StatusOK, `[{"transactionID": "t-3", "status": "pending", "netAmount": 50}]`)
+		default:
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+	})
+
+	balance, err := mc.GetAccountBalance(BgCtx(), "account-id")
+	require.NoError(t, err)
+	require.Equal(t, map[string]moov.CurrencyBalance{
+		"USD": {Available: 1704, Pending: 250},
+	}, balance.Currencies)
+}
+
+func TestGetAccountBalance_NoWallets(t *testing.T) {
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		WriteJson(w, http.StatusOK, `[]`)
+	})
+
+	balance, err := mc.GetAccountBalance(BgCtx(), "account-id")
+	require.NoError(t, err)
+	require.NotNil(t, balance.Currencies)
+	require.Empty(t, balance.Currencies)
+}
+
+func TestListWalletTransactions_Filter(t *testing.T) {
+	transactions := map[string]string{
+		"completed": `{"transactionID": "t-1", "transactionType": "ach-payment", "status": "completed", "grossAmount": 1000, "fee": 25, "netAmount": 975, "availableBalance": 5975}`,
+		"pending":   `{"transactionID": "t-2", "transactionType": "card-payment", "status": "pending", "grossAmount": 500, "fee": 10, "netAmount": 490, "availableBalance": 5975}`,
+	}
+
+	mc := NewMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/accounts/account-id/wallets/wallet-id/transactions", r.URL.Path)
+
+		query := r.URL.Query()
+		require.Equal(t, "10", query.Get("count"))
+		require.Equal(t, "20", query.Get("skip"))
+
+		status := query.Get("status")
+		if status == "" {
+			WriteJson(w, http.StatusOK, "["+transactions["completed"]+","+transactions["pending"]+"]")
+			return
+		}
+		WriteJson(w, http.StatusOK, "["+transactions[status]+"]")
+	})
+
+	all, err := mc.ListWalletTransactionsFiltered(BgCtx(), "account-id", "wallet-id", moov.WalletTransactionFilter{Count: 10, Skip: 20})
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+
+	completed, err := mc.ListWalletTransactionsFiltered(BgCtx(), "account-id", "wallet-id", moov.WalletTransactionFilter{Status: "completed", Count: 10, Skip: 20})
+	require.NoError(t, err)
+	require.Len(t, completed, 1)
+
+	transaction := completed[0]
+	require.Equal(t, "t-1", transaction.TransactionID)
+	require.Equal(t, "ach-payment", transaction.TransactionType)
+	require.Equal(t, 1000, transaction.GrossAmount)
+	require.Equal(t, 25, transaction.Fee)
+	require.Equal(t, 5975, transaction.AvailableBalance)
+
+	pending, err := mc.ListWalletTransactions(BgCtx(), "account-id", "wallet-id",
+		moov.WithTransactionStatus("pending"),
+		moov.WithTransactionCount(10),
+		moov.WithTransactionSkip(20))
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	require.Equal(t, "t-2", pending[0].TransactionID)
+}
+
 type WalletTestSuite struct {
 	suite.Suite
 	// values for testing will be set in init()
@@ -60,7 +156,7 @@ func (s *WalletTestSuite) SetupSuite() {
 			s.accountID = account.AccountID
 		}
 	}
-	wallets, err := mc.ListWallets(s.accountID)
+	wallets, err := mc.ListWallets(context.Background(), s.accountID)
 	s.NoError(err)
 
 	for _, wallet := range wallets {
@@ -68,7 +164,7 @@ func (s *WalletTestSuite) SetupSuite() {
 	}
 	s.Assert().NotEmpty(s.accountID)
 
-	transactions, err := mc.ListWalletTransactions(s.accountID, s.walletID, moov.WithTransactionCount(1))
+	transactions, err := mc.ListWalletTransactionsFiltered(context.Background(), s.accountID, s.walletID, moov.WalletTransactionFilter{Count: 1})
 	s.NoError(err)
 	for _, transaction := range transactions {
 		s.walletTransactionID = transaction.TransactionID
@@ -81,7 +177,7 @@ func (s *WalletTestSuite) TearDownSuite() {}
 func (s *WalletTestSuite) TestListWallets() {
 	mc := NewTestClient(s.T())
 
-	wallets, err := mc.ListWallets(s.accountID)
+	wallets, err := mc.ListWallets(context.Background(), s.accountID)
 	s.NoError(err)
 	// range over wallets and print walletID
 	for _, wallet := range wallets {
@@ -92,14 +188,14 @@ func (s *WalletTestSuite) TestListWallets() {
 
 func (s *WalletTestSuite) TestGetWallet() {
 	mc := NewTestClient(s.T())
-	wallet, err := mc.GetWallet(s.accountID, s.walletID)
+	wallet, err := mc.GetWallet(context.Background(), s.accountID, s.walletID)
 	s.NoError(err)
 	s.Equal(s.walletID, wallet.WalletID)
 }
 
 func (s *WalletTestSuite) TestListWalletTransactions() {
 	mc := NewTestClient(s.T())
-	walletTransactions, err := mc.ListWalletTransactions(s.accountID, s.walletID, moov.WithTransactionStatus("completed"), moov.WithTransactionCount(50))
+	walletTransactions, err := mc.ListWalletTransactionsFiltered(context.Background(), s.accountID, s.walletID, moov.WalletTransactionFilter{Status: "completed", Count: 50})
 	s.NoError(err)
 	s.NotNil(walletTransactions)
 	s.Greater(len(walletTransactions), 3)
@@ -107,7 +203,7 @@ func (s *WalletTestSuite) TestListWalletTransactions() {
 
 func (s *WalletTestSuite) TestGetWalletTransaction() {
 	mc := NewTestClient(s.T())
-	walletTran, err := mc.GetWalletTransaction(s.accountID, s.walletID, s.walletTransactionID)
+	walletTran, err := mc.GetWalletTransaction(context.Background(), s.accountID, s.walletID, s.walletTransactionID)
 	s.NoError(err)
 	s.Equal(s.walletTransactionID, walletTran.TransactionID)
 }
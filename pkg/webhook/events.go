@@ -0,0 +1,245 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+var ErrUnknownEventType = errors.New("unknown webhook event type")
+
+// Event is the typed data of a webhook, returned by WebhookEvent.Unwrap
+type Event interface {
+	EventType() EventType
+}
+
+type AccountCreated struct {
+	AccountID string `json:"accountID"`
+}
+
+type AccountUpdated struct {
+	AccountID string `json:"accountID"`
+}
+
+type BalanceUpdated struct {
+	AccountID string `json:"accountID"`
+	WalletID  string `json:"walletID"`
+}
+
+type BankAccountCreated struct {
+	AccountID     string `json:"accountID"`
+	BankAccountID string `json:"bankAccountID"`
+}
+
+type BankAccountUpdated struct {
+	AccountID     string `json:"accountID"`
+	BankAccountID string `json:"bankAccountID"`
+	Status        string `json:"status"`
+	StatusReason  string `json:"statusReason,omitempty"`
+}
+
+type BankAccountDeleted struct {
+	AccountID     string `json:"accountID"`
+	BankAccountID string `json:"bankAccountID"`
+}
+
+type CapabilityRequested struct {
+	AccountID    string `json:"accountID"`
+	CapabilityID string `json:"capabilityID"`
+}
+
+type CapabilityUpdated struct {
+	AccountID    string `json:"accountID"`
+	CapabilityID string `json:"capabilityID"`
+	Status       string `json:"status"`
+}
+
+type CardAutoUpdated struct {
+	AccountID  string `json:"accountID"`
+	CardID     string `json:"cardID"`
+	UpdateType string `json:"updateType"`
+}
+
+type DisputeCreated struct {
+	TransferID string `json:"transferID"`
+	DisputeID  string `json:"disputeID"`
+	Status     string `json:"status"`
+}
+
+type DisputeUpdated struct {
+	TransferID string `json:"transferID"`
+	DisputeID  string `json:"disputeID"`
+	Status     string `json:"status"`
+}
+
+type PaymentMethodEnabled struct {
+	AccountID       string `json:"accountID"`
+	PaymentMethodID string `json:"paymentMethodID"`
+	SourceID        string `json:"sourceID"`
+}
+
+type PaymentMethodDisabled struct {
+	AccountID       string `json:"accountID"`
+	PaymentMethodID string `json:"paymentMethodID"`
+	SourceID        string `json:"sourceID"`
+}
+
+type RefundCreated struct {
+	AccountID  string `json:"accountID"`
+	TransferID string `json:"transferID"`
+	RefundID   string `json:"refundID"`
+}
+
+type RefundUpdated struct {
+	AccountID  string `json:"accountID"`
+	TransferID string `json:"transferID"`
+	RefundID   string `json:"refundID"`
+	Status     string `json:"status"`
+}
+
+type RepresentativeCreated struct {
+	AccountID        string `json:"accountID"`
+	RepresentativeID string `json:"representativeID"`
+}
+
+type RepresentativeUpdated struct {
+	AccountID        string `json:"accountID"`
+	RepresentativeID string `json:"representativeID"`
+}
+
+type RepresentativeDeleted struct {
+	AccountID        string `json:"accountID"`
+	RepresentativeID string `json:"representativeID"`
+}
+
+type TransferCreated struct {
+	AccountID  string `json:"accountID"`
+	TransferID string `json:"transferID"`
+	Status     string `json:"status"`
+}
+
+type TransferUpdated struct {
+	AccountID  string `json:"accountID"`
+	TransferID string `json:"transferID"`
+	Status     string `json:"status"`
+}
+
+type WalletTransactionUpdated struct {
+	AccountID     string `json:"accountID"`
+	WalletID      string `json:"walletID"`
+	TransactionID string `json:"transactionID"`
+	Status        string `json:"status"`
+}
+
+func (AccountCreated) EventType() EventType           { return EventTypeAccountCreated }
+func (AccountUpdated) EventType() EventType           { return EventTypeAccountUpdated }
+func (BalanceUpdated) EventType() EventType           { return EventTypeBalanceUpdated }
+func (BankAccountCreated) EventType() EventType       { return EventTypeBankAccountCreated }
+func (BankAccountUpdated) EventType() EventType       { return EventTypeBankAccountUpdated }
+func (BankAccountDeleted) EventType() EventType       { return EventTypeBankAccountDeleted }
+func (CapabilityRequested) EventType() EventType      { return EventTypeCapabilityRequested }
+func (CapabilityUpdated) EventType() EventType        { return EventTypeCapabilityUpdated }
+func (CardAutoUpdated) EventType() EventType          { return EventTypeCardAutoUpdated }
+func (DisputeCreated) EventType() EventType           { return EventTypeDisputeCreated }
+func (DisputeUpdated) EventType() EventType           { return EventTypeDisputeUpdated }
+func (PaymentMethodEnabled) EventType() EventType     { return EventTypePaymentMethodEnabled }
+func (PaymentMethodDisabled) EventType() EventType    { return EventTypePaymentMethodDisabled }
+func (RefundCreated) EventType() EventType            { return EventTypeRefundCreated }
+func (RefundUpdated) EventType() EventType            { return EventTypeRefundUpdated }
+func (RepresentativeCreated) EventType() EventType    { return EventTypeRepresentativeCreated }
+func (RepresentativeUpdated) EventType() EventType    { return EventTypeRepresentativeUpdated }
+func (RepresentativeDeleted) EventType() EventType    { return EventTypeRepresentativeDeleted }
+func (TransferCreated) EventType() EventType          { return EventTypeTransferCreated }
+func (TransferUpdated) EventType() EventType          { return EventTypeTransferUpdated }
+func (WalletTransactionUpdated) EventType() EventType { return EventTypeWalletTransactionUpdated }
+
+var decoders = map[EventType]func(json.RawMessage) (Event, error){}
+
+func register[E Event]() {
+	var zero E
+	decoders[zero.EventType()] = func(data json.RawMessage) (Event, error) {
+		event := new(E)
+		if err := json.Unmarshal(data, event); err != nil {
+			return nil, err
+		}
+		return *event, nil
+	}
+}
+
+func init() {
+	register[AccountCreated]()
+	register[AccountUpdated]()
+	register[BalanceUpdated]()
+	register[BankAccountCreated]()
+	register[BankAccountUpdated]()
+	register[BankAccountDeleted]()
+	register[CapabilityRequested]()
+	register[CapabilityUpdated]()
+	register[CardAutoUpdated]()
+	register[DisputeCreated]()
+	register[DisputeUpdated]()
+	register[PaymentMethodEnabled]()
+	register[PaymentMethodDisabled]()
+	register[RefundCreated]()
+	register[RefundUpdated]()
+	register[RepresentativeCreated]()
+	register[RepresentativeUpdated]()
+	register[RepresentativeDeleted]()
+	register[TransferCreated]()
+	register[TransferUpdated]()
+	register[WalletTransactionUpdated]()
+}
+
+// Unwrap decodes the event's data into the type for its EventType, ie: TransferUpdated for transfer.updated.
+// Returns ErrUnknownEventType for event types this package doesn't have a type for.
+func (e WebhookEvent) Unwrap() (Event, error) {
+	decode, ok := decoders[e.EventType]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEventType, e.EventType)
+	}
+	return decode(e.Data)
+}
+
+// Dispatcher routes webhook events to the handler registered for their type with On
+type Dispatcher struct {
+	handlers map[EventType]func(context.Context, Event) error
+	unknown  func(context.Context, *WebhookEvent) error
+}
+
+func NewDispatcher() *Dispatcher {
+	return &Dispatcher{
+		handlers: map[EventType]func(context.Context, Event) error{},
+	}
+}
+
+// On registers fn to handle events of type E, replacing any handler already registered for it
+func On[E Event](d *Dispatcher, fn func(context.Context, E) error) {
+	var zero E
+	d.handlers[zero.EventType()] = func(ctx context.Context, event Event) error {
+		return fn(ctx, event.(E))
+	}
+}
+
+// OnUnknown registers fn to handle events of types without a typed event
+func (d *Dispatcher) OnUnknown(fn func(context.Context, *WebhookEvent) error) {
+	d.unknown = fn
+}
+
+// Dispatch calls the handler for the event's type. Events of known types without a handler are ignored, events of
+// unknown types go to the OnUnknown handler or return ErrUnknownEventType when there isn't one.
+func (d *Dispatcher) Dispatch(ctx context.Context, event *WebhookEvent) error {
+	typed, err := event.Unwrap()
+	if errors.Is(err, ErrUnknownEventType) && d.unknown != nil {
+		return d.unknown(ctx, event)
+	}
+	if err != nil {
+		return err
+	}
+
+	handler, ok := d.handlers[typed.EventType()]
+	if !ok {
+		return nil
+	}
+	return handler(ctx, typed)
+}
@@ -0,0 +1,148 @@
+package webhook_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/moovfinancial/moov-go/pkg/webhook"
+	"github.com/stretchr/testify/require"
+)
+
+const secret = "webhook-secret"
+
+var payload = []byte(`{
+	"eventID": "event-id",
+	"type": "transfer.updated",
+	"data": {"accountID": "account-id", "transferID": "transfer-id", "status": "completed"},
+	"createdOn": "2024-01-02T15:04:05Z"
+}`)
+
+func signedHeader(sentOn time.Time) http.Header {
+	timestamp := sentOn.UTC().Format(time.RFC3339)
+
+	header := http.Header{}
+	header.Set("X-Timestamp", timestamp)
+	header.Set("X-Nonce", "nonce")
+	header.Set("X-Webhook-ID", "webhook-id")
+	header.Set("X-Signature", webhook.Sign(secret, timestamp, "nonce", "webhook-id"))
+	return header
+}
+
+func TestVerifyWebhook(t *testing.T) {
+	event, err := webhook.VerifyWebhook(payload, signedHeader(time.Now()), secret, 5*time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, "event-id", event.EventID)
+	require.Equal(t, webhook.EventTypeTransferUpdated, event.EventType)
+	require.JSONEq(t, `{"accountID": "account-id", "transferID": "transfer-id", "status": "completed"}`, string(event.Data))
+}
+
+func TestVerifyWebhook_Tampered(t *testing.T) {
+	header := signedHeader(time.Now())
+	header.Set("X-Webhook-ID", "other-webhook-id")
+
+	_, err := webhook.VerifyWebhook(payload, header, secret, 5*time.Minute)
+	require.ErrorIs(t, err, webhook.ErrInvalidSignature)
+
+	_, err = webhook.VerifyWebhook(payload, signedHeader(time.Now()), "wrong-secret", 5*time.Minute)
+	require.ErrorIs(t, err, webhook.ErrInvalidSignature)
+
+	header = signedHeader(time.Now())
+	header.Del("X-Signature")
+	_, err = webhook.VerifyWebhook(payload, header, secret, 5*time.Minute)
+	require.ErrorIs(t, err, webhook.ErrMissingHeaders)
+}
+
+func TestVerifyWebhook_Expired(t *testing.T) {
+	_, err := webhook.VerifyWebhook(payload, signedHeader(time.Now().Add(-10*time.Minute)), secret, 5*time.Minute)
+	require.ErrorIs(t, err, webhook.ErrTimestampExpired)
+
+	_, err = webhook.VerifyWebhook(payload, signedHeader(time.Now().Add(10*time.Minute)), secret, 5*time.Minute)
+	require.ErrorIs(t, err, webhook.ErrTimestampExpired)
+}
+
+func TestDispatcher(t *testing.T) {
+	event, err := webhook.VerifyWebhook(payload, signedHeader(time.Now()), secret, 5*time.Minute)
+	require.NoError(t, err)
+
+	typed, err := event.Unwrap()
+	require.NoError(t, err)
+	require.Equal(t, webhook.TransferUpdated{AccountID: "account-id", TransferID: "transfer-id", Status: "completed"}, typed)
+
+	updated := []webhook.TransferUpdated{}
+	d := webhook.NewDispatcher()
+	webhook.On(d, func(ctx context.Context, e webhook.TransferUpdated) error {
+		updated = append(updated, e)
+		return nil
+	})
+	webhook.On(d, func(ctx context.Context, e webhook.DisputeCreated) error {
+		t.Fatal("dispute handler called for a transfer event")
+		return nil
+	})
+
+	require.NoError(t, d.Dispatch(context.Background(), event))
+	require.Len(t, updated, 1)
+	require.Equal(t, "transfer-id", updated[0].TransferID)
+
+	// known types without a handler are ignored
+	require.NoError(t, d.Dispatch(context.Background(), &webhook.WebhookEvent{EventType: webhook.EventTypeAccountCreated, Data: []byte(`{}`)}))
+}
+
+func TestUnwrap_KnownTypes(t *testing.T) {
+	types := []webhook.EventType{
+		webhook.EventTypeAccountCreated,
+		webhook.EventTypeAccountUpdated,
+		webhook.EventTypeBalanceUpdated,
+		webhook.EventTypeBankAccountCreated,
+		webhook.EventTypeBankAccountUpdated,
+		webhook.EventTypeBankAccountDeleted,
+		webhook.EventTypeCapabilityRequested,
+		webhook.EventTypeCapabilityUpdated,
+		webhook.EventTypeCardAutoUpdated,
+		webhook.EventTypeDisputeCreated,
+		webhook.EventTypeDisputeUpdated,
+		webhook.EventTypePaymentMethodEnabled,
+		webhook.EventTypePaymentMethodDisabled,
+		webhook.EventTypeRefundCreated,
+		webhook.EventTypeRefundUpdated,
+		webhook.EventTypeRepresentativeCreated,
+		webhook.EventTypeRepresentativeUpdated,
+		webhook.EventTypeRepresentativeDeleted,
+		webhook.EventTypeTransferCreated,
+		webhook.EventTypeTransferUpdated,
+		webhook.EventTypeWalletTransactionUpdated,
+	}
+
+	for _, eventType := range types {
+		typed, err := (webhook.WebhookEvent{EventType: eventType, Data: []byte(`{}`)}).Unwrap()
+		require.NoError(t, err, eventType)
+		require.Equal(t, eventType, typed.EventType())
+	}
+
+	event := webhook.WebhookEvent{
+		EventType: webhook.EventTypeRepresentativeUpdated,
+		Data:      []byte(`{"accountID": "account-id", "representativeID": "representative-id"}`),
+	}
+	typed, err := event.Unwrap()
+	require.NoError(t, err)
+	require.Equal(t, webhook.RepresentativeUpdated{AccountID: "account-id", RepresentativeID: "representative-id"}, typed)
+}
+
+func TestDispatcher_UnknownType(t *testing.T) {
+	event := &webhook.WebhookEvent{EventID: "event-id", EventType: "issuedCard.created", Data: []byte(`{}`)}
+
+	_, err := event.Unwrap()
+	require.ErrorIs(t, err, webhook.ErrUnknownEventType)
+
+	d := webhook.NewDispatcher()
+	require.ErrorIs(t, d.Dispatch(context.Background(), event), webhook.ErrUnknownEventType)
+
+	unknown := []string{}
+	d.OnUnknown(func(ctx context.Context, e *webhook.WebhookEvent) error {
+		unknown = append(unknown, e.EventID)
+		return nil
+	})
+	require.NoError(t, d.Dispatch(context.Background(), event))
+	require.Equal(t, []string{"event-id"}, unknown)
+}